@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"vectordb/db"
+	"vectordb/model"
+)
+
+// runHealth checks that the target answers and prints its collections:
+//
+//	vectordb health [target]
+func runHealth(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("health")
+	t := targetFlags(fs)
+	if !a.parse(fs, args, 0, 0) {
+		return 2
+	}
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		return a.fail("health", err)
+	}
+	defer close()
+
+	info, err := b.GetDBInfo(ctx)
+	if err != nil {
+		return a.fail("health", err)
+	}
+	return a.printJSON(map[string]interface{}{
+		"status":           "ok",
+		"collection_count": info.CollectionCount,
+	})
+}
+
+// runFsck checks the collections of the target, it exits with 1 if problems remain and 2 on
+// errors:
+//
+//	vectordb fsck [target] [-repair] [collection ...]
+func runFsck(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("fsck")
+	t := targetFlags(fs)
+	repair := fs.Bool("repair", false, "rebuild the index of inconsistent collections from their objects")
+	if !a.parse(fs, args, 0, -1) {
+		return 2
+	}
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		a.fail("fsck", err)
+		return 2
+	}
+	defer close()
+
+	reports, err := b.Fsck(ctx, &model.ReqFsck{Collections: fs.Args(), Repair: *repair})
+	if err != nil {
+		a.fail("fsck", err)
+		return 2
+	}
+	if a.printJSON(reports) != 0 {
+		return 2
+	}
+
+	for _, report := range reports {
+		inconsistent := len(report.OrphanedVectors)+len(report.MissingVectors)+len(report.CorruptObjects)+len(report.CorruptWAL) > 0
+		if inconsistent && !report.Repaired {
+			return 1
+		}
+	}
+	return 0
+}
+
+// runBackup writes a backup archive of the target to a file or stdout:
+//
+//	vectordb backup [target] [-o file]
+func runBackup(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("backup")
+	t := targetFlags(fs)
+	out := fs.String("o", "", "archive file, stdout by default")
+	if !a.parse(fs, args, 0, 0) {
+		return 2
+	}
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		return a.fail("backup", err)
+	}
+	defer close()
+
+	w, closeOut, err := a.output(*out)
+	if err != nil {
+		return a.fail("backup", err)
+	}
+	err = b.Backup(ctx, w)
+	if cerr := closeOut(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		if *out != "" {
+			os.Remove(*out)
+		}
+		return a.fail("backup", err)
+	}
+	return 0
+}
+
+// runRestore rebuilds an empty data directory from a backup archive, the server must be stopped:
+//
+//	vectordb restore [-path dir] archive.tar.gz
+func runRestore(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("restore")
+	t := &target{}
+	fs.StringVar(&t.path, "path", "", "data directory, persist_path of config.yaml by default")
+	if !a.parse(fs, args, 1, 1) {
+		return 2
+	}
+
+	path, err := t.dataPath()
+	if err != nil {
+		return a.fail("restore", err)
+	}
+
+	f, err := input(fs.Arg(0))
+	if err != nil {
+		return a.fail("restore", err)
+	}
+	defer f.Close()
+
+	if err := db.Restore(f, path); err != nil {
+		return a.fail("restore", err)
+	}
+	fmt.Fprintf(a.stdout, "restored %s into %s\n", fs.Arg(0), path)
+
+	return 0
+}
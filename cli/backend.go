@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+
+	"vectordb/client"
+	"vectordb/db"
+	"vectordb/model"
+	"vectordb/settings"
+	"vectordb/transfer"
+)
+
+// backend is the API the commands run against, *client.Client calls a server and embedded
+// calls a db.DB opened in process
+type backend interface {
+	GetDBInfo(ctx context.Context) (model.ResDBInfo, error)
+	Fsck(ctx context.Context, req *model.ReqFsck) ([]model.ResFsck, error)
+	Backup(ctx context.Context, w io.Writer) error
+	CreateCollection(ctx context.Context, req *model.ReqCreateCollection) error
+	DeleteCollection(ctx context.Context, colname string) error
+	GetCollectionInfo(ctx context.Context, colname string) (model.ResCollectionInfo, error)
+	SnapshotCollection(ctx context.Context, colname string, w io.Writer) error
+	RestoreCollection(ctx context.Context, colname string, r io.Reader, overwrite bool) error
+	InsertObjects(ctx context.Context, colname string, objs *model.ReqInsertObjects) ([]string, error)
+	UpsertObjects(ctx context.Context, colname string, objs *model.ReqInsertObjects) ([]string, error)
+	ExportObjects(ctx context.Context, colname string, req *model.ReqExportObjects, w io.Writer) error
+	GetObjectInfo(ctx context.Context, colname string, objid string) (model.ResObjectInfo, error)
+	SearchObjects(ctx context.Context, colname string, req *model.ReqSearchObject) ([]model.ResSearchObject, error)
+}
+
+// target selects the backend of a command
+type target struct {
+	addr    string
+	path    string
+	timeout time.Duration
+	retries int
+}
+
+func targetFlags(fs *flag.FlagSet) *target {
+	t := &target{}
+	fs.StringVar(&t.addr, "addr", os.Getenv("VECTORDB_ADDR"), "url of a running server like http://127.0.0.1:8081, $VECTORDB_ADDR by default")
+	fs.StringVar(&t.path, "path", "", "data directory opened without -addr, persist_path of config.yaml by default")
+	fs.DurationVar(&t.timeout, "timeout", 0, "timeout of the command, 0 for none")
+	fs.IntVar(&t.retries, "retries", 2, "retries of idempotent requests to the server")
+	return t
+}
+
+// open returns the backend of the target and the context of the command, close releases both
+func (t *target) open(ctx context.Context) (context.Context, backend, func(), error) {
+	cancel := func() {}
+	if t.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+	}
+
+	if t.addr != "" {
+		// the timeout of the command bounds all of its requests, they get none of their own
+		c := client.New(t.addr, client.WithTimeout(0), client.WithRetries(t.retries, 100*time.Millisecond))
+		return ctx, c, cancel, nil
+	}
+
+	path, err := t.dataPath()
+	if err != nil {
+		cancel()
+		return nil, nil, nil, err
+	}
+	// no automatic checkpoints, the WAL of the writes is replayed by the next start
+	database, err := db.Open(path, db.CheckpointPolicy{})
+	if err != nil {
+		cancel()
+		return nil, nil, nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	return ctx, embedded{db: database}, func() { database.Close(); cancel() }, nil
+}
+
+func (t *target) dataPath() (string, error) {
+	if t.path != "" {
+		return t.path, nil
+	}
+	if err := settings.Init(); err != nil {
+		return "", fmt.Errorf("failed to init settings: %w", err)
+	}
+	return settings.Conf.DBConfig.PersistPath, nil
+}
+
+// embedded runs the commands in process with the validation of the REST API
+type embedded struct {
+	db *db.DB
+}
+
+func validate(obj interface{}) error {
+	return binding.Validator.ValidateStruct(obj)
+}
+
+func (e embedded) GetDBInfo(ctx context.Context) (model.ResDBInfo, error) {
+	return e.db.GetDBInfo()
+}
+
+func (e embedded) Fsck(ctx context.Context, req *model.ReqFsck) ([]model.ResFsck, error) {
+	return e.db.Fsck(req)
+}
+
+func (e embedded) Backup(ctx context.Context, w io.Writer) error {
+	return e.db.Backup(w)
+}
+
+func (e embedded) CreateCollection(ctx context.Context, req *model.ReqCreateCollection) error {
+	if err := validate(req); err != nil {
+		return err
+	}
+	return e.db.CreateCollection(req)
+}
+
+func (e embedded) DeleteCollection(ctx context.Context, colname string) error {
+	return e.db.DeleteCollection(colname)
+}
+
+func (e embedded) GetCollectionInfo(ctx context.Context, colname string) (model.ResCollectionInfo, error) {
+	return e.db.GetCollectionInfo(colname)
+}
+
+func (e embedded) SnapshotCollection(ctx context.Context, colname string, w io.Writer) error {
+	return e.db.SnapshotCollection(colname, w)
+}
+
+func (e embedded) RestoreCollection(ctx context.Context, colname string, r io.Reader, overwrite bool) error {
+	return e.db.RestoreCollection(colname, r, &model.ReqRestoreCollection{Overwrite: overwrite})
+}
+
+func (e embedded) InsertObjects(ctx context.Context, colname string, objs *model.ReqInsertObjects) ([]string, error) {
+	if err := validate(objs); err != nil {
+		return nil, err
+	}
+	col, err := e.db.Collection(colname)
+	if err != nil {
+		return nil, err
+	}
+	return col.InsertObjects(objs)
+}
+
+func (e embedded) UpsertObjects(ctx context.Context, colname string, objs *model.ReqInsertObjects) ([]string, error) {
+	if err := validate(objs); err != nil {
+		return nil, err
+	}
+	col, err := e.db.Collection(colname)
+	if err != nil {
+		return nil, err
+	}
+	if err := col.UpsertObjects(objs); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(objs.Objects))
+	for i := range objs.Objects {
+		ids[i] = objs.Objects[i].ID
+	}
+	return ids, nil
+}
+
+func (e embedded) ExportObjects(ctx context.Context, colname string, req *model.ReqExportObjects, w io.Writer) error {
+	col, err := e.db.Collection(colname)
+	if err != nil {
+		return err
+	}
+	export, err := col.Export()
+	if err != nil {
+		return err
+	}
+	defer export.Close()
+
+	if req.Format == transfer.FormatNpy {
+		return transfer.ExportNpyTar(w, export)
+	}
+	return transfer.ExportJSONL(w, export)
+}
+
+func (e embedded) GetObjectInfo(ctx context.Context, colname string, objid string) (model.ResObjectInfo, error) {
+	col, err := e.db.Collection(colname)
+	if err != nil {
+		return model.ResObjectInfo{}, err
+	}
+	return col.GetObjectInfo(objid)
+}
+
+func (e embedded) SearchObjects(ctx context.Context, colname string, req *model.ReqSearchObject) ([]model.ResSearchObject, error) {
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	col, err := e.db.Collection(colname)
+	if err != nil {
+		return nil, err
+	}
+	return col.SearchObjects(req)
+}
@@ -0,0 +1,182 @@
+// Package cli implements the subcommands of the vectordb binary. Every command runs against a
+// server through the client package with -addr, or opens a data directory directly through
+// the db package otherwise, which needs the server to be stopped.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+)
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, app *app, args []string) int
+}
+
+var commands = map[string]command{
+	"health":     {"health [target]", runHealth},
+	"collection": {"collection create|delete|info|list|snapshot|restore [target] ...", runCollection},
+	"object":     {"object get|insert|search [target] ...", runObject},
+	"import":     {"import [target] [-format jsonl|csv|fvecs|bvecs|npy] [-batch n] [-upsert] collection file", runImport},
+	"export":     {"export [target] [-format jsonl|npy] [-o file] collection", runExport},
+	"backup":     {"backup [target] [-o file]", runBackup},
+	"restore":    {"restore [-path dir] archive.tar.gz", runRestore},
+	"fsck":       {"fsck [target] [-repair] [collection ...]", runFsck},
+}
+
+// app holds the output streams of a run
+type app struct {
+	stdout  io.Writer
+	stderr  io.Writer
+	command string // usage of the running command
+}
+
+// Run runs the subcommand args[0] and returns the exit code: 0 on success, 1 if the command
+// failed and 2 on usage errors
+func Run(args []string, stdout io.Writer, stderr io.Writer) int {
+	a := &app{stdout: stdout, stderr: stderr}
+	if len(args) == 0 {
+		a.usage()
+		return 2
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		a.usage()
+		return 2
+	}
+
+	a.command = cmd.usage
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return cmd.run(ctx, a, args[1:])
+}
+
+// runSubcommand runs the subcommand args[0] of the running command
+func (a *app) runSubcommand(ctx context.Context, subcommands map[string]command, args []string) int {
+	if len(args) > 0 {
+		if cmd, ok := subcommands[args[0]]; ok {
+			a.command = cmd.usage
+			return cmd.run(ctx, a, args[1:])
+		}
+	}
+
+	names := make([]string, 0, len(subcommands))
+	for name := range subcommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(a.stderr, "usage:")
+	for _, name := range names {
+		fmt.Fprintf(a.stderr, "  vectordb %s\n", subcommands[name].usage)
+	}
+	return 2
+}
+
+func (a *app) usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(a.stderr, "usage: vectordb [command]")
+	fmt.Fprintln(a.stderr, "the server is started without a command, the commands are:")
+	for _, name := range names {
+		fmt.Fprintf(a.stderr, "  vectordb %s\n", commands[name].usage)
+	}
+	fmt.Fprintln(a.stderr, "target: -addr url to call a running server, or -path dir to open a stopped one (persist_path of config.yaml by default)")
+}
+
+// flagSet returns the flags of a command, usage errors are returned from parse instead of exiting
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// parse parses the flags of fs and checks the number of positional arguments, it prints the
+// usage and returns false on errors
+func (a *app) parse(fs *flag.FlagSet, args []string, minArgs int, maxArgs int) bool {
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "usage: vectordb %s\n", a.command)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return false
+	}
+	if fs.NArg() < minArgs || (maxArgs >= 0 && fs.NArg() > maxArgs) {
+		fs.Usage()
+		return false
+	}
+	return true
+}
+
+func (a *app) fail(action string, err error) int {
+	fmt.Fprintf(a.stderr, "%s failed, err:%v\n", action, err)
+	return 1
+}
+
+func (a *app) printJSON(v interface{}) int {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return a.fail("encode output", err)
+	}
+	fmt.Fprintln(a.stdout, string(out))
+	return 0
+}
+
+// output returns the file at path or stdout if path is empty or -, close is a no-op for stdout
+func (a *app) output(path string) (io.Writer, func() error, error) {
+	if path == "" || path == "-" {
+		return a.stdout, func() error { return nil }, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
+
+// input returns the file at path or stdin if path is -
+func input(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+// decodeJSON decodes the JSON document at path, or stdin if path is -
+func decodeJSON(path string, v interface{}) error {
+	r, err := input(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// parseVector parses comma separated floats like 0.1,0.2
+func parseVector(s string) ([]float32, error) {
+	vec := []float32{}
+	for _, f := range strings.Split(s, ",") {
+		var v float32
+		if _, err := fmt.Sscan(strings.TrimSpace(f), &v); err != nil {
+			return nil, fmt.Errorf("invalid vector component '%s'", f)
+		}
+		vec = append(vec, v)
+	}
+	return vec, nil
+}
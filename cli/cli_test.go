@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"vectordb/db"
+	"vectordb/model"
+	"vectordb/router"
+	"vectordb/transfer"
+)
+
+func run(t *testing.T, args ...string) (int, string, string) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	code := Run(args, stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func writeFile(t *testing.T, name string, data string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(data), 0600))
+	return path
+}
+
+// testTarget runs the commands against the given target flags and returns the path of a backup
+func testTarget(t *testing.T, target ...string) string {
+	cmd := func(args ...string) []string {
+		n := 1
+		if args[0] == "collection" || args[0] == "object" {
+			n = 2
+		}
+		return append(append(append([]string{}, args[:n]...), target...), args[n:]...)
+	}
+
+	spec := writeFile(t, "spec.json", `{"name": "test", "dimension": 2, "index_type": "flat", "index_params": {"maxsize": 100}, "dist_type": "euclidean", "mapping": ["category"]}`)
+	code, _, stderr := run(t, cmd("collection", "create", spec)...)
+	assert.Equal(t, 0, code, stderr)
+	code, _, stderr = run(t, cmd("collection", "create", writeFile(t, "invalid.json", `{"name": "invalid"}`))...)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "create collection failed")
+
+	lines := []string{}
+	for i := 0; i < 25; i++ {
+		lines = append(lines, fmt.Sprintf(`{"id": "%02d", "metadata": {"category": "%d"}, "vector": [%d, 0]}`, i, i%2, i))
+	}
+	objects := writeFile(t, "objects.jsonl", strings.Join(lines, "\n"))
+	code, stdout, stderr := run(t, cmd("import", "-batch", "10", "test", objects)...)
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, "25 objects imported into test\n", stdout)
+	// the first batch fails on the existing ids, upserts replace them
+	code, _, stderr = run(t, cmd("import", "test", objects)...)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "0 objects imported before")
+	code, _, stderr = run(t, cmd("import", "-upsert", "test", objects)...)
+	assert.Equal(t, 0, code, stderr)
+
+	code, stdout, stderr = run(t, cmd("object", "insert", "test", writeFile(t, "object.json", `{"id": "25", "metadata": {"category": "1"}, "vector": [25, 0]}`))...)
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, "25\n", stdout)
+
+	code, stdout, stderr = run(t, cmd("object", "get", "test", "03")...)
+	assert.Equal(t, 0, code, stderr)
+	obj := model.ResObjectInfo{}
+	assert.NoError(t, json.Unmarshal([]byte(stdout), &obj))
+	assert.Equal(t, []float32{3, 0}, obj.Vector)
+
+	code, stdout, stderr = run(t, cmd("object", "search", "-vector", "4.2,0", "-topk", "2", "-filter", `{"field": "category", "op": "eq", "value": "1"}`, "test")...)
+	assert.Equal(t, 0, code, stderr)
+	res := []model.ResSearchObject{}
+	assert.NoError(t, json.Unmarshal([]byte(stdout), &res))
+	if assert.Len(t, res, 2) {
+		assert.Equal(t, "05", res[0].ID)
+		assert.Equal(t, "03", res[1].ID)
+	}
+
+	export := filepath.Join(t.TempDir(), "export.jsonl")
+	code, _, stderr = run(t, cmd("export", "-o", export, "test")...)
+	assert.Equal(t, 0, code, stderr)
+	data, err := os.ReadFile(export)
+	assert.NoError(t, err)
+	exported := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, exported, 26)
+	assert.NoError(t, json.Unmarshal([]byte(exported[3]), &obj))
+	assert.Equal(t, "03", obj.ID)
+	assert.Equal(t, []float32{3, 0}, obj.Vector)
+
+	// the npy array is read back by the import, row i is line i of the JSONL file
+	vectors := filepath.Join(t.TempDir(), "export.npy")
+	code, _, stderr = run(t, cmd("export", "-format", "npy", "-o", vectors, "test")...)
+	assert.Equal(t, 0, code, stderr)
+	data, err = os.ReadFile(strings.TrimSuffix(vectors, ".npy") + ".jsonl")
+	assert.NoError(t, err)
+	exported = strings.Split(strings.TrimSpace(string(data)), "\n")
+	if assert.Len(t, exported, 26) {
+		assert.JSONEq(t, `{"id": "25", "metadata": {"category": "1"}}`, exported[25])
+	}
+	f, err := os.Open(vectors)
+	assert.NoError(t, err)
+	rows := [][]float32{}
+	_, err = transfer.Import(context.Background(), f, transfer.ImportOptions{Format: transfer.FormatNpy, Dimension: 2}, func(objs *model.ReqInsertObjects) error {
+		for _, obj := range objs.Objects {
+			rows = append(rows, obj.Vector)
+		}
+		return nil
+	})
+	f.Close()
+	assert.NoError(t, err)
+	if assert.Len(t, rows, 26) {
+		assert.Equal(t, []float32{25, 0}, rows[25])
+	}
+
+	snapshot := filepath.Join(t.TempDir(), "test.tar.gz")
+	code, _, stderr = run(t, cmd("collection", "snapshot", "-o", snapshot, "test")...)
+	assert.Equal(t, 0, code, stderr)
+	code, _, stderr = run(t, cmd("collection", "restore", "clone", snapshot)...)
+	assert.Equal(t, 0, code, stderr)
+	code, stdout, stderr = run(t, cmd("collection", "info", "clone")...)
+	assert.Equal(t, 0, code, stderr)
+	info := model.ResCollectionInfo{}
+	assert.NoError(t, json.Unmarshal([]byte(stdout), &info))
+	assert.Equal(t, 26, info.ObjectCount)
+
+	code, _, stderr = run(t, cmd("collection", "delete", "clone")...)
+	assert.Equal(t, 0, code, stderr)
+	code, stdout, _ = run(t, cmd("collection", "list")...)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "test\n", stdout)
+
+	code, stdout, stderr = run(t, cmd("health")...)
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, `"status": "ok"`)
+	code, _, stderr = run(t, cmd("fsck", "test")...)
+	assert.Equal(t, 0, code, stderr)
+
+	backup := filepath.Join(t.TempDir(), "backup.tar.gz")
+	code, _, stderr = run(t, cmd("backup", "-o", backup)...)
+	assert.Equal(t, 0, code, stderr)
+	return backup
+}
+
+// testRestore restores a backup of testTarget into an empty directory
+func testRestore(t *testing.T, backup string) {
+	restored := t.TempDir()
+	code, _, stderr := run(t, "restore", "-path", restored, backup)
+	assert.Equal(t, 0, code, stderr)
+
+	code, stdout, stderr := run(t, "collection", "info", "-path", restored, "test")
+	assert.Equal(t, 0, code, stderr)
+	info := model.ResCollectionInfo{}
+	assert.NoError(t, json.Unmarshal([]byte(stdout), &info))
+	assert.Equal(t, 26, info.ObjectCount)
+}
+
+func TestEmbedded(t *testing.T) {
+	testRestore(t, testTarget(t, "-path", t.TempDir()))
+}
+
+func TestRemote(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.CheckpointPolicy{})
+	assert.NoError(t, err)
+	gin.SetMode(gin.TestMode)
+	srv := httptest.NewServer(router.SetupRouter(gin.TestMode, database))
+	backup := testTarget(t, "-addr", srv.URL)
+	srv.Close()
+	database.Close()
+
+	// the restored directory is opened in process once the server is stopped
+	testRestore(t, backup)
+}
+
+func TestImportFormats(t *testing.T) {
+	path := t.TempDir()
+	spec := writeFile(t, "spec.json", `{"name": "test", "dimension": 2, "index_type": "flat", "index_params": {"maxsize": 100}, "dist_type": "euclidean"}`)
+	code, _, stderr := run(t, "collection", "create", "-path", path, spec)
+	assert.Equal(t, 0, code, stderr)
+
+	fvecs := new(bytes.Buffer)
+	for i := 0; i < 3; i++ {
+		binary.Write(fvecs, binary.LittleEndian, int32(2))
+		binary.Write(fvecs, binary.LittleEndian, []float32{float32(i), 1})
+	}
+	code, stdout, stderr := run(t, "import", "-path", path, "test", writeFile(t, "base.fvecs", fvecs.String()))
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, "3 objects imported into test\n", stdout)
+
+	code, stdout, stderr = run(t, "import", "-path", path, "-format", "csv", "test", writeFile(t, "objects.txt", "id,vector\na,5 1\nb,6 1\n"))
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, "2 objects imported into test\n", stdout)
+
+	// vectors are checked against the dimension of the collection before a batch is inserted
+	code, _, stderr = run(t, "import", "-path", path, "-format", "csv", "test", writeFile(t, "objects.csv", "id,vector\nc,7 1\nd,8 1 0\n"))
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "object 2: vector dimension 3, the collection has 2, 0 objects imported before")
+
+	code, stdout, stderr = run(t, "collection", "info", "-path", path, "test")
+	assert.Equal(t, 0, code, stderr)
+	info := model.ResCollectionInfo{}
+	assert.NoError(t, json.Unmarshal([]byte(stdout), &info))
+	assert.Equal(t, 5, info.ObjectCount)
+}
+
+func TestUsage(t *testing.T) {
+	code, _, stderr := run(t)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "vectordb import")
+
+	code, _, stderr = run(t, "collection", "drop")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "vectordb collection create")
+
+	code, _, stderr = run(t, "object", "search", "test")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "-vector")
+
+	// the npy export writes two files
+	code, _, stderr = run(t, "export", "-format", "npy", "test")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "vectordb export")
+}
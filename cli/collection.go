@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"vectordb/model"
+)
+
+var collectionCommands = map[string]command{
+	"create":   {"collection create [target] spec.json", runCollectionCreate},
+	"delete":   {"collection delete [target] name", runCollectionDelete},
+	"info":     {"collection info [target] name", runCollectionInfo},
+	"list":     {"collection list [target]", runCollectionList},
+	"snapshot": {"collection snapshot [target] [-o file] name", runCollectionSnapshot},
+	"restore":  {"collection restore [target] [-overwrite] name snapshot.tar.gz", runCollectionRestore},
+}
+
+func runCollection(ctx context.Context, a *app, args []string) int {
+	return a.runSubcommand(ctx, collectionCommands, args)
+}
+
+// runCollectionCreate creates a collection from the JSON body of the create route, read from
+// a file or stdin with -
+func runCollectionCreate(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("collection create")
+	t := targetFlags(fs)
+	if !a.parse(fs, args, 1, 1) {
+		return 2
+	}
+
+	req := new(model.ReqCreateCollection)
+	if err := decodeJSON(fs.Arg(0), req); err != nil {
+		return a.fail("create collection", err)
+	}
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		return a.fail("create collection", err)
+	}
+	defer close()
+
+	if err := b.CreateCollection(ctx, req); err != nil {
+		return a.fail("create collection", err)
+	}
+	fmt.Fprintf(a.stdout, "collection %s created\n", req.Name)
+	return 0
+}
+
+func runCollectionDelete(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("collection delete")
+	t := targetFlags(fs)
+	if !a.parse(fs, args, 1, 1) {
+		return 2
+	}
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		return a.fail("delete collection", err)
+	}
+	defer close()
+
+	if err := b.DeleteCollection(ctx, fs.Arg(0)); err != nil {
+		return a.fail("delete collection", err)
+	}
+	fmt.Fprintf(a.stdout, "collection %s deleted\n", fs.Arg(0))
+	return 0
+}
+
+func runCollectionInfo(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("collection info")
+	t := targetFlags(fs)
+	if !a.parse(fs, args, 1, 1) {
+		return 2
+	}
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		return a.fail("get collection info", err)
+	}
+	defer close()
+
+	info, err := b.GetCollectionInfo(ctx, fs.Arg(0))
+	if err != nil {
+		return a.fail("get collection info", err)
+	}
+	return a.printJSON(info)
+}
+
+func runCollectionList(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("collection list")
+	t := targetFlags(fs)
+	if !a.parse(fs, args, 0, 0) {
+		return 2
+	}
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		return a.fail("list collections", err)
+	}
+	defer close()
+
+	info, err := b.GetDBInfo(ctx)
+	if err != nil {
+		return a.fail("list collections", err)
+	}
+	for _, name := range info.Collections {
+		fmt.Fprintln(a.stdout, name)
+	}
+	return 0
+}
+
+func runCollectionSnapshot(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("collection snapshot")
+	t := targetFlags(fs)
+	out := fs.String("o", "", "snapshot file, stdout by default")
+	if !a.parse(fs, args, 1, 1) {
+		return 2
+	}
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		return a.fail("snapshot collection", err)
+	}
+	defer close()
+
+	w, closeOut, err := a.output(*out)
+	if err != nil {
+		return a.fail("snapshot collection", err)
+	}
+	err = b.SnapshotCollection(ctx, fs.Arg(0), w)
+	if cerr := closeOut(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		if *out != "" {
+			os.Remove(*out)
+		}
+		return a.fail("snapshot collection", err)
+	}
+	return 0
+}
+
+func runCollectionRestore(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("collection restore")
+	t := targetFlags(fs)
+	overwrite := fs.Bool("overwrite", false, "replace the collection if it exists")
+	if !a.parse(fs, args, 2, 2) {
+		return 2
+	}
+
+	f, err := input(fs.Arg(1))
+	if err != nil {
+		return a.fail("restore collection", err)
+	}
+	defer f.Close()
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		return a.fail("restore collection", err)
+	}
+	defer close()
+
+	if err := b.RestoreCollection(ctx, fs.Arg(0), f, *overwrite); err != nil {
+		return a.fail("restore collection", err)
+	}
+	fmt.Fprintf(a.stdout, "collection %s restored from %s\n", fs.Arg(0), fs.Arg(1))
+	return 0
+}
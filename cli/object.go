@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"vectordb/model"
+)
+
+var objectCommands = map[string]command{
+	"get":    {"object get [target] collection id", runObjectGet},
+	"insert": {"object insert [target] [-upsert] collection object.json", runObjectInsert},
+	"search": {"object search [target] -vector 0.1,0.2 [-topk n] [-filter json] [-params json] collection", runObjectSearch},
+}
+
+func runObject(ctx context.Context, a *app, args []string) int {
+	return a.runSubcommand(ctx, objectCommands, args)
+}
+
+func runObjectGet(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("object get")
+	t := targetFlags(fs)
+	if !a.parse(fs, args, 2, 2) {
+		return 2
+	}
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		return a.fail("get object", err)
+	}
+	defer close()
+
+	obj, err := b.GetObjectInfo(ctx, fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return a.fail("get object", err)
+	}
+	return a.printJSON(obj)
+}
+
+// runObjectInsert inserts the JSON body of the insert route, read from a file or stdin with -
+func runObjectInsert(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("object insert")
+	t := targetFlags(fs)
+	upsert := fs.Bool("upsert", false, "replace the object if its id exists")
+	if !a.parse(fs, args, 2, 2) {
+		return 2
+	}
+
+	obj := model.ReqInsertObject{}
+	if err := decodeJSON(fs.Arg(1), &obj); err != nil {
+		return a.fail("insert object", err)
+	}
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		return a.fail("insert object", err)
+	}
+	defer close()
+
+	objs := &model.ReqInsertObjects{Objects: []model.ReqInsertObject{obj}}
+	var ids []string
+	if *upsert {
+		ids, err = b.UpsertObjects(ctx, fs.Arg(0), objs)
+	} else {
+		ids, err = b.InsertObjects(ctx, fs.Arg(0), objs)
+	}
+	if err != nil {
+		return a.fail("insert object", err)
+	}
+	fmt.Fprintln(a.stdout, ids[0])
+	return 0
+}
+
+func runObjectSearch(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("object search")
+	t := targetFlags(fs)
+	vector := fs.String("vector", "", "query vector, comma separated")
+	topk := fs.Int("topk", 10, "number of results")
+	filter := fs.String("filter", "", "metadata filter as JSON, like {\"field\": \"category\", \"op\": \"eq\", \"value\": \"book\"}")
+	params := fs.String("params", "", "search parameters of the index as JSON, like {\"ef\": 64}")
+	if !a.parse(fs, args, 1, 1) {
+		return 2
+	}
+	if *vector == "" {
+		fs.Usage()
+		return 2
+	}
+
+	req := &model.ReqSearchObject{TopK: *topk}
+	var err error
+	if req.Vector, err = parseVector(*vector); err != nil {
+		return a.fail("search objects", err)
+	}
+	if *filter != "" {
+		if err := json.Unmarshal([]byte(*filter), &req.Filter); err != nil {
+			return a.fail("search objects", fmt.Errorf("invalid filter: %w", err))
+		}
+	}
+	if *params != "" {
+		if err := json.Unmarshal([]byte(*params), &req.XParams); err != nil {
+			return a.fail("search objects", fmt.Errorf("invalid params: %w", err))
+		}
+	}
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		return a.fail("search objects", err)
+	}
+	defer close()
+
+	res, err := b.SearchObjects(ctx, fs.Arg(0), req)
+	if err != nil {
+		return a.fail("search objects", err)
+	}
+	return a.printJSON(res)
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"vectordb/model"
+	"vectordb/transfer"
+)
+
+// runImport inserts the objects of a JSONL, CSV, fvecs, bvecs or npy file in atomic batches, the
+// format follows the file extension unless -format is set:
+//
+//	vectordb import [target] [-format f] [-batch n] [-upsert] collection file
+func runImport(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("import")
+	t := targetFlags(fs)
+	format := fs.String("format", "", "jsonl, csv, fvecs, bvecs or npy, from the file extension by default")
+	batch := fs.Int("batch", transfer.DefaultBatchSize, "objects per insert batch")
+	upsert := fs.Bool("upsert", false, "replace the objects whose id exists")
+	if !a.parse(fs, args, 2, 2) {
+		return 2
+	}
+	if *batch <= 0 {
+		fs.Usage()
+		return 2
+	}
+	if *format == "" {
+		*format = transfer.FormatOf(fs.Arg(1))
+	}
+
+	f, err := input(fs.Arg(1))
+	if err != nil {
+		return a.fail("import", err)
+	}
+	defer f.Close()
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		return a.fail("import", err)
+	}
+	defer close()
+
+	colname := fs.Arg(0)
+	info, err := b.GetCollectionInfo(ctx, colname)
+	if err != nil {
+		return a.fail("import", err)
+	}
+
+	opts := transfer.ImportOptions{
+		Format:    *format,
+		Dimension: info.Dimension,
+		Schema:    info.Schema,
+		BatchSize: *batch,
+		Progress: func(imported int) {
+			fmt.Fprintf(a.stderr, "%d objects imported\n", imported)
+		},
+	}
+	imported, err := transfer.Import(ctx, f, opts, func(objs *model.ReqInsertObjects) error {
+		var err error
+		if *upsert {
+			_, err = b.UpsertObjects(ctx, colname, objs)
+		} else {
+			_, err = b.InsertObjects(ctx, colname, objs)
+		}
+		return err
+	})
+	if err != nil {
+		return a.fail("import", err)
+	}
+
+	fmt.Fprintf(a.stdout, "%d objects imported into %s\n", imported, colname)
+	return 0
+}
+
+// runExport writes every object of a collection from one consistent read transaction, as JSONL
+// or with -format npy as a npy array of the vectors and a JSONL file of the ids and metadata:
+//
+//	vectordb export [target] [-format jsonl|npy] [-o file] collection
+func runExport(ctx context.Context, a *app, args []string) int {
+	fs := a.flagSet("export")
+	t := targetFlags(fs)
+	format := fs.String("format", transfer.FormatJSONL, "jsonl, or npy to write the vectors to the -o file and the rest to the same path with a .jsonl extension")
+	out := fs.String("o", "", "output file, stdout by default for jsonl")
+	if !a.parse(fs, args, 1, 1) {
+		return 2
+	}
+	if *format != transfer.FormatJSONL && *format != transfer.FormatNpy || *format == transfer.FormatNpy && (*out == "" || *out == "-") {
+		fs.Usage()
+		return 2
+	}
+
+	ctx, b, close, err := t.open(ctx)
+	if err != nil {
+		return a.fail("export", err)
+	}
+	defer close()
+
+	req := &model.ReqExportObjects{Format: *format}
+	if *format == transfer.FormatNpy {
+		objects := strings.TrimSuffix(*out, filepath.Ext(*out)) + ".jsonl"
+		if err := exportNpy(ctx, b, fs.Arg(0), req, *out, objects); err != nil {
+			os.Remove(*out)
+			os.Remove(objects)
+			return a.fail("export", err)
+		}
+		return 0
+	}
+
+	w, closeOut, err := a.output(*out)
+	if err != nil {
+		return a.fail("export", err)
+	}
+	err = b.ExportObjects(ctx, fs.Arg(0), req, w)
+	if cerr := closeOut(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		if *out != "" && *out != "-" {
+			os.Remove(*out)
+		}
+		return a.fail("export", err)
+	}
+	return 0
+}
+
+// exportNpy splits the tar of the npy export into the vectors and the objects file as it streams
+func exportNpy(ctx context.Context, b backend, colname string, req *model.ReqExportObjects, vectorsPath string, objectsPath string) error {
+	vectors, err := os.Create(vectorsPath)
+	if err != nil {
+		return err
+	}
+	defer vectors.Close()
+	objects, err := os.Create(objectsPath)
+	if err != nil {
+		return err
+	}
+	defer objects.Close()
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := transfer.ExtractNpyTar(pr, vectors, objects)
+		// unblocks the export if the archive is rejected
+		pr.CloseWithError(err)
+		done <- err
+	}()
+	err = b.ExportObjects(ctx, colname, req, pw)
+	pw.CloseWithError(err)
+	if xerr := <-done; err == nil {
+		err = xerr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := vectors.Close(); err != nil {
+		return err
+	}
+	return objects.Close()
+}
@@ -0,0 +1,209 @@
+// Package client is the Go client of the VectorDB REST API, it has a typed method for every
+// route of router.SetupRouter and takes the request and response types of the model package.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+	defaultBackoff = 100 * time.Millisecond
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	timeout    time.Duration // per attempt, 0 leaves the deadline to the context
+	retries    int           // extra attempts of idempotent requests
+	backoff    time.Duration // doubled after every attempt
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client requests are sent with, http.DefaultClient by default
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout bounds every attempt of a request, it defaults to 30 seconds and 0 disables it.
+// Requests streaming their body or response, backups, snapshots, restores, imports and exports,
+// are only bounded by their context
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithRetries retries idempotent requests that failed with a network error or a 5xx or 429
+// response up to retries times, waiting backoff before the first retry and twice as long before
+// every next one. Inserts, alters, restores and repairs are never retried
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// New returns a client of the server at baseURL, like http://127.0.0.1:8080
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		timeout:    defaultTimeout,
+		backoff:    defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Error is returned for responses with a non 2xx status, Message is the error of the
+// {"error": ...} body or the body itself if it isn't one
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("vectordb: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsStatus reports whether err is an *Error with the given status code
+func IsStatus(err error, code int) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == code
+}
+
+type request struct {
+	method      string
+	path        string
+	body        interface{} // encoded as JSON
+	idempotent  bool
+	contentType string    // of raw
+	raw         io.Reader // sent as is instead of body, never retried
+	stream      bool      // the response body is streamed to the caller
+}
+
+// call sends the request and decodes the data of the response envelope into out
+func (c *Client) call(ctx context.Context, req request, out interface{}) error {
+	return c.do(ctx, req, func(res *http.Response) error {
+		envelope := struct {
+			Data json.RawMessage `json:"data"`
+		}{}
+		if err := json.NewDecoder(res.Body).Decode(&envelope); err != nil {
+			return fmt.Errorf("vectordb: failed to decode response: %w", err)
+		}
+		if out == nil || len(envelope.Data) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(envelope.Data, out); err != nil {
+			return fmt.Errorf("vectordb: failed to decode response data: %w", err)
+		}
+		return nil
+	})
+}
+
+// do sends the request with retries and hands a 2xx response to handle before its body is closed
+func (c *Client) do(ctx context.Context, req request, handle func(*http.Response) error) error {
+	var body []byte
+	if req.body != nil {
+		var err error
+		if body, err = json.Marshal(req.body); err != nil {
+			return fmt.Errorf("vectordb: failed to encode request: %w", err)
+		}
+	}
+
+	attempts := 1
+	if req.idempotent && req.raw == nil {
+		attempts += c.retries
+	}
+	backoff := c.backoff
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		var retry bool
+		retry, err = c.attempt(ctx, req, body, handle)
+		if err == nil || !retry || ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// attempt sends the request once and reports whether a failure may be retried
+func (c *Client) attempt(ctx context.Context, req request, body []byte, handle func(*http.Response) error) (bool, error) {
+	// streams take as long as they need, only the caller's context bounds them
+	if c.timeout > 0 && req.raw == nil && !req.stream {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var reqBody io.Reader
+	contentType := ""
+	switch {
+	case req.raw != nil:
+		reqBody, contentType = req.raw, req.contentType
+	case body != nil:
+		reqBody, contentType = bytes.NewReader(body), "application/json"
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, c.baseURL+req.path, reqBody)
+	if err != nil {
+		return false, fmt.Errorf("vectordb: failed to create request: %w", err)
+	}
+	if contentType != "" {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		// network errors and timed out attempts are retried, unless the caller's context is done
+		return true, fmt.Errorf("vectordb: %s %s: %w", req.method, req.path, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests, responseError(res)
+	}
+
+	return false, handle(res)
+}
+
+func responseError(res *http.Response) error {
+	data, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return &Error{StatusCode: res.StatusCode, Message: err.Error()}
+	}
+
+	body := struct {
+		Error string `json:"error"`
+	}{}
+	if json.Unmarshal(data, &body) == nil && body.Error != "" {
+		return &Error{StatusCode: res.StatusCode, Message: body.Error}
+	}
+	return &Error{StatusCode: res.StatusCode, Message: strings.TrimSpace(string(data))}
+}
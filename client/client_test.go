@@ -0,0 +1,248 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"vectordb/db"
+	"vectordb/model"
+	"vectordb/router"
+	"vectordb/transfer"
+)
+
+func setupClient(t *testing.T) *Client {
+	database, err := db.Open(t.TempDir(), db.CheckpointPolicy{})
+	assert.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	gin.SetMode(gin.TestMode)
+	srv := httptest.NewServer(router.SetupRouter(gin.TestMode, database))
+	t.Cleanup(srv.Close)
+
+	return New(srv.URL)
+}
+
+func TestClient(t *testing.T) {
+	c := setupClient(t)
+	ctx := context.Background()
+
+	err := c.CreateCollection(ctx, &model.ReqCreateCollection{
+		Name: "test", Dimension: 2, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 100},
+		Distance: "euclidean", Mapping: []string{"category"}, MetadataIndexes: map[string]string{"category": "keyword"},
+	})
+	assert.NoError(t, err)
+
+	// errors of the {"error": ...} responses
+	err = c.CreateCollection(ctx, &model.ReqCreateCollection{Name: "invalid"})
+	assert.True(t, IsStatus(err, http.StatusBadRequest))
+	_, err = c.GetCollectionInfo(ctx, "missing")
+	var e *Error
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, http.StatusBadRequest, e.StatusCode)
+		assert.Contains(t, e.Message, "missing")
+	}
+
+	id, err := c.InsertObject(ctx, "test", &model.ReqInsertObject{Metadata: map[string]interface{}{"category": "0"}, Vector: []float32{0, 0}})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id)
+	objs := &model.ReqInsertObjects{}
+	for i := 1; i < 10; i++ {
+		objs.Objects = append(objs.Objects, model.ReqInsertObject{
+			ID: fmt.Sprintf("%02d", i), Metadata: map[string]interface{}{"category": fmt.Sprint(i % 2)}, Vector: []float32{float32(i), 0},
+		})
+	}
+	ids, err := c.InsertObjects(ctx, "test", objs)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 9)
+	_, err = c.InsertObject(ctx, "test", &model.ReqInsertObject{ID: "01", Metadata: map[string]interface{}{}, Vector: []float32{1, 0}})
+	assert.True(t, IsStatus(err, http.StatusBadRequest))
+
+	upserted, err := c.UpsertObject(ctx, "test", &model.ReqInsertObject{ID: "01", Metadata: map[string]interface{}{"category": "0"}, Vector: []float32{1, 0}})
+	assert.NoError(t, err)
+	assert.Equal(t, "01", upserted)
+	assert.NoError(t, c.UpdateObject(ctx, "test", &model.ReqUpdateObject{ID: "02", Metadata: map[string]interface{}{"category": "2"}, Vector: []float32{2, 0}}))
+
+	obj, err := c.GetObjectInfo(ctx, "test", "02")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", obj.Metadata["category"])
+	page, err := c.ScrollObjects(ctx, "test", &model.ReqScrollObjects{Limit: 4, WithMetadata: true})
+	assert.NoError(t, err)
+	if assert.Len(t, page.Objects, 4) {
+		assert.Nil(t, page.Objects[0].Vector)
+		assert.NotNil(t, page.Objects[0].Metadata)
+	}
+	page, err = c.ScrollObjects(ctx, "test", &model.ReqScrollObjects{
+		PageToken: page.NextPage, Limit: 4, WithVector: true, WithMetadata: true, Fields: []string{"category", "missing"},
+		Filter: &model.Filter{Field: "category", Op: "eq", Value: "1"},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, page.Objects, 3) {
+		assert.Equal(t, model.ResObjectInfo{ID: "05", Metadata: map[string]interface{}{"category": "1"}, Vector: []float32{5, 0}}, page.Objects[0])
+	}
+	assert.Empty(t, page.NextPage)
+	_, err = c.ScrollObjects(ctx, "test", &model.ReqScrollObjects{PageToken: "not base64!"})
+	assert.True(t, IsStatus(err, http.StatusBadRequest))
+
+	res, err := c.SearchObjects(ctx, "test", &model.ReqSearchObject{
+		Vector: []float32{3.2, 0}, TopK: 2, Filter: &model.Filter{Field: "category", Op: "eq", Value: "1"},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, res, 2) {
+		assert.Equal(t, "03", res[0].ID)
+		assert.Equal(t, "05", res[1].ID)
+	}
+	count, err := c.CountObjects(ctx, "test", &model.ReqCountObjects{Filter: &model.Filter{Field: "category", Op: "eq", Value: "0"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, count.Count)
+
+	assert.NoError(t, c.DeleteObject(ctx, "test", "09"))
+	_, err = c.GetObjectInfo(ctx, "test", "09")
+	assert.True(t, IsStatus(err, http.StatusBadRequest))
+
+	assert.NoError(t, c.AlterCollection(ctx, "test", &model.ReqAlterCollection{
+		Changes: []model.FieldChange{{Op: "add", Field: &model.Field{Name: "label", Type: "string", Default: "none"}}},
+	}))
+	checkpoint, err := c.CheckpointCollection(ctx, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, "test", checkpoint.Name)
+
+	reports, err := c.Fsck(ctx, &model.ReqFsck{})
+	assert.NoError(t, err)
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, 9, reports[0].ObjectCount)
+		assert.Equal(t, 9, reports[0].IndexCount)
+	}
+
+	// snapshots stream back into a clone
+	snapshot := new(bytes.Buffer)
+	assert.NoError(t, c.SnapshotCollection(ctx, "test", snapshot))
+	assert.NoError(t, c.RestoreCollection(ctx, "clone", bytes.NewReader(snapshot.Bytes()), false))
+	err = c.RestoreCollection(ctx, "clone", bytes.NewReader(snapshot.Bytes()), false)
+	assert.True(t, IsStatus(err, http.StatusBadRequest))
+	info, err := c.GetCollectionInfo(ctx, "clone")
+	assert.NoError(t, err)
+	assert.Equal(t, 9, info.ObjectCount)
+
+	backup := new(bytes.Buffer)
+	assert.NoError(t, c.Backup(ctx, backup))
+	assert.NoError(t, db.Restore(bytes.NewReader(backup.Bytes()), t.TempDir()))
+
+	assert.NoError(t, c.DeleteCollection(ctx, "clone"))
+
+	// imports are parsed by the server, the batches before an error stay
+	assert.NoError(t, c.CreateCollection(ctx, &model.ReqCreateCollection{
+		Name: "imported", Dimension: 2, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 100}, Distance: "euclidean", Mapping: []string{"category"},
+	}))
+	csv := "id,vector,category\na,\"1,0\",x\nb,\"2,0\",y\nc,\"3,0\",x\n"
+	imported, err := c.ImportObjects(ctx, "imported", strings.NewReader(csv), &model.ReqImportObjects{Format: "csv", Batch: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, imported.Imported)
+	_, err = c.ImportObjects(ctx, "imported", strings.NewReader("id,vector,category\nd,\"4,0\",x\na,\"1,0\",x\ne,\"5,0,0\",x\n"), &model.ReqImportObjects{Format: "csv", Batch: 1})
+	if assert.True(t, IsStatus(err, http.StatusBadRequest)) {
+		assert.Contains(t, err.Error(), "1 objects imported before")
+	}
+	_, err = c.ImportObjects(ctx, "imported", strings.NewReader(csv), &model.ReqImportObjects{Format: "parquet"})
+	assert.True(t, IsStatus(err, http.StatusBadRequest))
+	count, err = c.CountObjects(ctx, "imported", &model.ReqCountObjects{})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count.Count)
+
+	// exports stream from one read transaction
+	exported := new(bytes.Buffer)
+	assert.NoError(t, c.ExportObjects(ctx, "imported", &model.ReqExportObjects{}, exported))
+	lines := strings.Split(strings.TrimSpace(exported.String()), "\n")
+	if assert.Len(t, lines, 4) {
+		assert.JSONEq(t, `{"id": "a", "metadata": {"category": "x"}, "vector": [1, 0]}`, lines[0])
+	}
+	exported.Reset()
+	assert.NoError(t, c.ExportObjects(ctx, "imported", &model.ReqExportObjects{Format: "npy"}, exported))
+	vectors, objects := new(bytes.Buffer), new(bytes.Buffer)
+	assert.NoError(t, transfer.ExtractNpyTar(exported, vectors, objects))
+	assert.Equal(t, 4, strings.Count(objects.String(), "\n"))
+	err = c.ExportObjects(ctx, "imported", &model.ReqExportObjects{Format: "csv"}, new(bytes.Buffer))
+	assert.True(t, IsStatus(err, http.StatusBadRequest))
+	assert.NoError(t, c.DeleteCollection(ctx, "imported"))
+
+	dbinfo, err := c.GetDBInfo(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"test"}, dbinfo.Collections)
+}
+
+func TestClientRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error":"unavailable"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"ok","message":"","data":{"id":"01","count":1}}`)
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	// idempotent requests are retried
+	c := New(srv.URL, WithRetries(2, time.Millisecond))
+	count, err := c.CountObjects(ctx, "test", &model.ReqCountObjects{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count.Count)
+	assert.Equal(t, int32(3), calls.Load())
+
+	// inserts are never retried
+	calls.Store(0)
+	_, err = c.InsertObject(ctx, "test", &model.ReqInsertObject{})
+	assert.True(t, IsStatus(err, http.StatusServiceUnavailable))
+	assert.Equal(t, int32(1), calls.Load())
+
+	// the last error is returned once the retries are exhausted
+	calls.Store(0)
+	_, err = New(srv.URL, WithRetries(1, time.Millisecond)).UpsertObject(ctx, "test", &model.ReqInsertObject{})
+	var e *Error
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, "unavailable", e.Message)
+	}
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClientTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL, WithTimeout(10*time.Millisecond)).GetDBInfo(context.Background())
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = New(srv.URL, WithTimeout(0), WithRetries(5, 10*time.Millisecond)).GetDBInfo(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	// streamed responses outlast the timeout of an attempt
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("backup"))
+		w.(http.Flusher).Flush()
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(" archive"))
+	}))
+	defer slow.Close()
+	archive := new(bytes.Buffer)
+	assert.NoError(t, New(slow.URL, WithTimeout(10*time.Millisecond)).Backup(context.Background(), archive))
+	assert.Equal(t, "backup archive", archive.String())
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"vectordb/model"
+)
+
+func (c *Client) GetDBInfo(ctx context.Context) (model.ResDBInfo, error) {
+	res := model.ResDBInfo{}
+	err := c.call(ctx, request{method: http.MethodGet, path: "/api/info", idempotent: true}, &res)
+	return res, err
+}
+
+// Fsck checks the given collections or every collection, only checks without repair are retried
+func (c *Client) Fsck(ctx context.Context, req *model.ReqFsck) ([]model.ResFsck, error) {
+	res := []model.ResFsck{}
+	err := c.call(ctx, request{method: http.MethodPost, path: "/api/admin/fsck", body: req, idempotent: !req.Repair}, &res)
+	return res, err
+}
+
+// Backup streams a backup archive of the database into w
+func (c *Client) Backup(ctx context.Context, w io.Writer) error {
+	return c.download(ctx, "/api/admin/backup", w)
+}
+
+func (c *Client) CreateCollection(ctx context.Context, req *model.ReqCreateCollection) error {
+	return c.call(ctx, request{method: http.MethodPost, path: "/api/collections", body: req}, nil)
+}
+
+func (c *Client) DeleteCollection(ctx context.Context, colname string) error {
+	return c.call(ctx, request{method: http.MethodDelete, path: collectionPath(colname), idempotent: true}, nil)
+}
+
+func (c *Client) GetCollectionInfo(ctx context.Context, colname string) (model.ResCollectionInfo, error) {
+	res := model.ResCollectionInfo{}
+	err := c.call(ctx, request{method: http.MethodGet, path: collectionPath(colname), idempotent: true}, &res)
+	return res, err
+}
+
+func (c *Client) AlterCollection(ctx context.Context, colname string, req *model.ReqAlterCollection) error {
+	return c.call(ctx, request{method: http.MethodPatch, path: collectionPath(colname), body: req}, nil)
+}
+
+func (c *Client) CheckpointCollection(ctx context.Context, colname string) (model.ResCheckpoint, error) {
+	res := model.ResCheckpoint{}
+	err := c.call(ctx, request{method: http.MethodPost, path: collectionPath(colname) + "/checkpoint", idempotent: true}, &res)
+	return res, err
+}
+
+// SnapshotCollection streams a portable snapshot of the collection into w
+func (c *Client) SnapshotCollection(ctx context.Context, colname string, w io.Writer) error {
+	return c.download(ctx, collectionPath(colname)+"/snapshot", w)
+}
+
+// RestoreCollection creates the collection colname from the snapshot read from r, an existing
+// collection is only replaced if overwrite is set
+func (c *Client) RestoreCollection(ctx context.Context, colname string, r io.Reader, overwrite bool) error {
+	return c.call(ctx, request{
+		method:      http.MethodPost,
+		path:        fmt.Sprintf("%s/restore?overwrite=%t", collectionPath(colname), overwrite),
+		raw:         r,
+		contentType: "application/gzip",
+	}, nil)
+}
+
+func (c *Client) download(ctx context.Context, path string, w io.Writer) error {
+	return c.do(ctx, request{method: http.MethodGet, path: path, stream: true}, func(res *http.Response) error {
+		if _, err := io.Copy(w, res.Body); err != nil {
+			return fmt.Errorf("vectordb: failed to download %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+func collectionPath(colname string) string {
+	return "/api/collections/" + url.PathEscape(colname)
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"vectordb/model"
+)
+
+// InsertObject inserts an object and returns its id, it isn't retried since a retry could
+// insert it twice
+func (c *Client) InsertObject(ctx context.Context, colname string, obj *model.ReqInsertObject) (string, error) {
+	res := struct {
+		ID string `json:"id"`
+	}{}
+	err := c.call(ctx, request{method: http.MethodPost, path: objectsPath(colname), body: obj}, &res)
+	return res.ID, err
+}
+
+// InsertObjects inserts the objects as an atomic batch and returns their ids
+func (c *Client) InsertObjects(ctx context.Context, colname string, objs *model.ReqInsertObjects) ([]string, error) {
+	res := struct {
+		IDs []string `json:"ids"`
+	}{}
+	err := c.call(ctx, request{method: http.MethodPost, path: objectsPath(colname) + "/batch", body: objs}, &res)
+	return res.IDs, err
+}
+
+func (c *Client) UpsertObject(ctx context.Context, colname string, obj *model.ReqInsertObject) (string, error) {
+	res := struct {
+		ID string `json:"id"`
+	}{}
+	err := c.call(ctx, request{method: http.MethodPost, path: objectsPath(colname) + "/upsert", body: obj, idempotent: true}, &res)
+	return res.ID, err
+}
+
+func (c *Client) UpsertObjects(ctx context.Context, colname string, objs *model.ReqInsertObjects) ([]string, error) {
+	res := struct {
+		IDs []string `json:"ids"`
+	}{}
+	err := c.call(ctx, request{method: http.MethodPost, path: objectsPath(colname) + "/upsert/batch", body: objs, idempotent: true}, &res)
+	return res.IDs, err
+}
+
+func (c *Client) DeleteObject(ctx context.Context, colname string, objid string) error {
+	return c.call(ctx, request{method: http.MethodDelete, path: objectPath(colname, objid), idempotent: true}, nil)
+}
+
+func (c *Client) UpdateObject(ctx context.Context, colname string, obj *model.ReqUpdateObject) error {
+	return c.call(ctx, request{method: http.MethodPut, path: objectPath(colname, obj.ID), body: obj, idempotent: true}, nil)
+}
+
+// ScrollObjects returns a page of the objects matching req.Filter in id order, pass NextPage of
+// the response as req.PageToken to get the next one. Like for the db package, vectors and
+// metadata are only returned if WithVector and WithMetadata are set.
+func (c *Client) ScrollObjects(ctx context.Context, colname string, req *model.ReqScrollObjects) (model.ResScrollObjects, error) {
+	query := url.Values{}
+	query.Set("page_token", req.PageToken)
+	query.Set("limit", strconv.Itoa(req.Limit))
+	query.Set("with_vector", strconv.FormatBool(req.WithVector))
+	query.Set("with_metadata", strconv.FormatBool(req.WithMetadata))
+	if len(req.Fields) > 0 {
+		query.Set("fields", strings.Join(req.Fields, ","))
+	}
+	if req.Filter != nil {
+		filter, err := json.Marshal(req.Filter)
+		if err != nil {
+			return model.ResScrollObjects{}, fmt.Errorf("vectordb: failed to encode filter: %w", err)
+		}
+		query.Set("filter", string(filter))
+	}
+
+	res := model.ResScrollObjects{}
+	err := c.call(ctx, request{method: http.MethodGet, path: objectsPath(colname) + "?" + query.Encode(), idempotent: true}, &res)
+	return res, err
+}
+
+func (c *Client) GetObjectInfo(ctx context.Context, colname string, objid string) (model.ResObjectInfo, error) {
+	res := model.ResObjectInfo{}
+	err := c.call(ctx, request{method: http.MethodGet, path: objectPath(colname, objid), idempotent: true}, &res)
+	return res, err
+}
+
+func (c *Client) SearchObjects(ctx context.Context, colname string, req *model.ReqSearchObject) ([]model.ResSearchObject, error) {
+	res := []model.ResSearchObject{}
+	err := c.call(ctx, request{method: http.MethodPost, path: objectsPath(colname) + "/search", body: req, idempotent: true}, &res)
+	return res, err
+}
+
+func (c *Client) CountObjects(ctx context.Context, colname string, req *model.ReqCountObjects) (model.ResCountObjects, error) {
+	res := model.ResCountObjects{}
+	err := c.call(ctx, request{method: http.MethodPost, path: objectsPath(colname) + "/count", body: req, idempotent: true}, &res)
+	return res, err
+}
+
+// ImportObjects streams a file in one of the formats of the transfer package to the server,
+// which inserts it in atomic batches. It isn't retried, the body is read once.
+func (c *Client) ImportObjects(ctx context.Context, colname string, r io.Reader, req *model.ReqImportObjects) (model.ResImportObjects, error) {
+	query := url.Values{}
+	query.Set("format", req.Format)
+	query.Set("batch", strconv.Itoa(req.Batch))
+	query.Set("upsert", strconv.FormatBool(req.Upsert))
+	res := model.ResImportObjects{}
+	err := c.call(ctx, request{
+		method:      http.MethodPost,
+		path:        objectsPath(colname) + "/import?" + query.Encode(),
+		raw:         r,
+		contentType: "application/octet-stream",
+	}, &res)
+	return res, err
+}
+
+// ExportObjects streams every object of the collection to w, as JSONL or for format npy as the tar
+// that transfer.ExtractNpyTar splits into the vectors and the objects file
+func (c *Client) ExportObjects(ctx context.Context, colname string, req *model.ReqExportObjects, w io.Writer) error {
+	return c.download(ctx, objectsPath(colname)+"/export?format="+url.QueryEscape(req.Format), w)
+}
+
+func objectsPath(colname string) string {
+	return collectionPath(colname) + "/objects"
+}
+
+func objectPath(colname string, objid string) string {
+	return objectsPath(colname) + "/" + url.PathEscape(objid)
+}
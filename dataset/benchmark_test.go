@@ -16,6 +16,7 @@ import (
 	"time"
 	"vectordb/db/index"
 	"vectordb/db/index/hnsw"
+	"vectordb/db/index/ivf"
 	"vectordb/model"
 
 	"github.com/gofrs/uuid"
@@ -201,6 +202,8 @@ func formatParamString(indexType string, p interface{}) string {
 	switch indexType {
 	case "hnsw":
 		return fmt.Sprintf("efc_%d_m_%d_ef_%d", p.(HNSWConfig).efConstruction, p.(HNSWConfig).maxConnections, p.(HNSWConfig).ef)
+	case "ivf":
+		return fmt.Sprintf("nlist_%d_nprobe_%d", p.(IVFConfig).nlist, p.(IVFConfig).nprobe)
 	default:
 		return "unknown"
 	}
@@ -222,6 +225,13 @@ func runBenchmark(dataset *Dataset, indexType string, p interface{}, topk int) *
 		}
 		index, _ = hnsw.NewHNSW(params, dataset.Distance)
 		searchParams = map[string]any{"ef": p.(HNSWConfig).ef}
+	case "ivf":
+		params := &model.IVFParams{
+			NList:   p.(IVFConfig).nlist,
+			MaxSize: len(dataset.Train) + 1,
+		}
+		index, _ = ivf.NewIVF(params, dataset.Distance)
+		searchParams = map[string]any{"nprobe": p.(IVFConfig).nprobe}
 	}
 
 	workers := runtime.NumCPU() / 2
@@ -292,6 +302,11 @@ type HNSWConfig struct {
 	ef             int
 }
 
+type IVFConfig struct {
+	nlist  int
+	nprobe int
+}
+
 // type NewIndexConfig struct {
 // 	todo int
 // }
@@ -302,21 +317,22 @@ func BenchmarkIndex(b *testing.B) {
 		dataset   string
 		topk      int
 		indexType string
-		params    []HNSWConfig
+		params    []any
 	}{
 		dataset:   "lastfm-65-dot",
 		topk:      10,
 		indexType: "hnsw",
-		params: []HNSWConfig{
-			{256, 8, 64},
-			{256, 16, 64},
-			{256, 24, 64},
-			{256, 32, 64},
-			{256, 40, 64},
-			{64, 24, 64},
-			{128, 24, 64},
-			{192, 24, 64},
-			{320, 24, 64},
+		params: []any{
+			// set indexType to "ivf" and use IVFConfig{nlist, nprobe} for ivf
+			HNSWConfig{256, 8, 64},
+			HNSWConfig{256, 16, 64},
+			HNSWConfig{256, 24, 64},
+			HNSWConfig{256, 32, 64},
+			HNSWConfig{256, 40, 64},
+			HNSWConfig{64, 24, 64},
+			HNSWConfig{128, 24, 64},
+			HNSWConfig{192, 24, 64},
+			HNSWConfig{320, 24, 64},
 		},
 	}
 
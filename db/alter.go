@@ -0,0 +1,318 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"vectordb/model"
+	"vectordb/pkg"
+
+	"go.etcd.io/bbolt"
+)
+
+// field change ops
+const (
+	fieldAdd    = "add"
+	fieldDrop   = "drop"
+	fieldRename = "rename"
+)
+
+// alteredConfig returns a copy of the config with the field changes applied in order
+func alteredConfig(cfg model.CfgCollection, changes []model.FieldChange) (model.CfgCollection, error) {
+	cfg.Mapping = slices.Clone(cfg.Mapping)
+	cfg.MetadataIndexes = maps.Clone(cfg.MetadataIndexes)
+	if cfg.Schema != nil {
+		cfg.Schema = slices.Clone(cfg.Schema)
+	}
+
+	for i := range changes {
+		change := &changes[i]
+		switch change.Op {
+		case fieldAdd:
+			if change.Field == nil {
+				return cfg, fmt.Errorf("change %d: add requires a field", i)
+			}
+			if slices.Contains(cfg.Mapping, change.Field.Name) {
+				return cfg, fmt.Errorf("change %d: metadata field '%s' already exists", i, change.Field.Name)
+			}
+			// existing objects don't have the field, only a default can fill it
+			if change.Field.Required {
+				return cfg, fmt.Errorf("change %d: added field '%s' can't be required", i, change.Field.Name)
+			}
+			if cfg.Schema == nil && change.Field.Default == nil {
+				return cfg, fmt.Errorf("change %d: added field '%s' requires a default value", i, change.Field.Name)
+			}
+			// validates the field and normalizes its default value
+			fields := []model.Field{*change.Field}
+			if err := validateSchema(fields); err != nil {
+				return cfg, fmt.Errorf("change %d: %w", i, err)
+			}
+			*change.Field = fields[0]
+
+			cfg.Mapping = append(cfg.Mapping, change.Field.Name)
+			if cfg.Schema != nil {
+				cfg.Schema = append(cfg.Schema, *change.Field)
+			}
+		case fieldDrop:
+			pos := slices.Index(cfg.Mapping, change.Name)
+			if pos < 0 {
+				return cfg, fmt.Errorf("change %d: metadata field '%s' not found", i, change.Name)
+			}
+			cfg.Mapping = slices.Delete(cfg.Mapping, pos, pos+1)
+			if cfg.Schema != nil {
+				cfg.Schema = slices.Delete(cfg.Schema, pos, pos+1)
+			}
+			delete(cfg.MetadataIndexes, change.Name)
+		case fieldRename:
+			pos := slices.Index(cfg.Mapping, change.Name)
+			if pos < 0 {
+				return cfg, fmt.Errorf("change %d: metadata field '%s' not found", i, change.Name)
+			}
+			if change.NewName == "" {
+				return cfg, fmt.Errorf("change %d: rename requires a new name", i)
+			}
+			if slices.Contains(cfg.Mapping, change.NewName) {
+				return cfg, fmt.Errorf("change %d: metadata field '%s' already exists", i, change.NewName)
+			}
+			cfg.Mapping[pos] = change.NewName
+			if cfg.Schema != nil {
+				cfg.Schema[pos].Name = change.NewName
+			}
+			if typ, ok := cfg.MetadataIndexes[change.Name]; ok {
+				delete(cfg.MetadataIndexes, change.Name)
+				cfg.MetadataIndexes[change.NewName] = typ
+			}
+		default:
+			return cfg, fmt.Errorf("change %d: unsupported op '%s'", i, change.Op)
+		}
+	}
+
+	return cfg, nil
+}
+
+// alterMetadata applies the field changes to the metadata of an object
+func alterMetadata(metadata map[string]interface{}, changes []model.FieldChange) {
+	for _, change := range changes {
+		switch change.Op {
+		case fieldAdd:
+			if change.Field.Default != nil {
+				metadata[change.Field.Name] = change.Field.Default
+			}
+		case fieldDrop:
+			delete(metadata, change.Name)
+		case fieldRename:
+			if value, ok := metadata[change.Name]; ok {
+				delete(metadata, change.Name)
+				metadata[change.NewName] = value
+			}
+		}
+	}
+}
+
+// objects rewritten per transaction by an alter
+const alterBatchSize = 1000
+
+// key of the pending alter in the collection bucket
+const alterPendingKey = "alter_pending"
+
+// pendingAlter is the rewrite of the objects left by an alter, it is committed with the new config
+// and advanced by every batch so that the rewrite resumes when the collection is loaded after a crash
+type pendingAlter struct {
+	Changes []model.FieldChange
+	Indexes []string // renamed indexed fields, their index is refilled from the rewritten objects
+	After   string   // id of the last rewritten object
+}
+
+// alterFields adds, drops or renames metadata fields. The new config is committed first, then the
+// objects are rewritten in batches of alterBatchSize, the caller holds c.mu so that nobody sees
+// objects of the old config in the meantime. The vector index is left untouched
+func (c *Collection) alterFields(changes []model.FieldChange) error {
+	// the rewrite of a previous alter that failed comes first
+	if err := c.resumeAlter(); err != nil {
+		return err
+	}
+
+	pending, err := c.startAlter(changes)
+	if err != nil {
+		return err
+	}
+	if err := c.rewriteObjects(pending); err != nil {
+		return fmt.Errorf("%w, the rewrite resumes when the collection is loaded", err)
+	}
+	return nil
+}
+
+// startAlter commits the new config and the pending rewrite of the objects, the index buckets of
+// renamed fields are emptied to be refilled by the rewrite
+func (c *Collection) startAlter(changes []model.FieldChange) (*pendingAlter, error) {
+	cfg, err := alteredConfig(c.config, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	// an index bucket is only kept if no change touches its name, a renamed field may take the
+	// name of another one that is renamed or dropped
+	touched := make(map[string]bool)
+	for _, change := range changes {
+		if change.Op != fieldAdd {
+			touched[change.Name] = true
+			touched[change.NewName] = true
+		}
+	}
+
+	pending := &pendingAlter{Changes: changes}
+	if err := c.db.kv.Update(func(tx *bbolt.Tx) error {
+		colBucket := tx.Bucket([]byte(c.name))
+		if idxBucket := colBucket.Bucket([]byte(bucketCollectionIndexes)); idxBucket != nil {
+			for field := range c.config.MetadataIndexes {
+				if !touched[field] {
+					continue
+				}
+				if err := idxBucket.DeleteBucket([]byte(field)); err != nil && !errors.Is(err, bbolt.ErrBucketNotFound) {
+					return fmt.Errorf("failed to delete index bucket of field '%s': %w", field, err)
+				}
+			}
+			for field := range cfg.MetadataIndexes {
+				if !touched[field] {
+					continue
+				}
+				if _, err := idxBucket.CreateBucket([]byte(field)); err != nil {
+					return fmt.Errorf("failed to create index bucket of field '%s': %w", field, err)
+				}
+				pending.Indexes = append(pending.Indexes, field)
+			}
+			slices.Sort(pending.Indexes)
+		}
+
+		colmeta, err := pkg.Serialize(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to serialize collection: %w", err)
+		}
+		if err := tx.Bucket([]byte(bucketCollectionsMetadata)).Put([]byte(c.name), colmeta); err != nil {
+			return fmt.Errorf("failed to put collection metadata: %w", err)
+		}
+		return putPendingAlter(colBucket, pending)
+	}); err != nil {
+		return nil, err
+	}
+	c.config = cfg
+
+	return pending, nil
+}
+
+// rewriteBatch applies the changes of the pending alter to the next batch of objects, done
+// reports that every object is rewritten and the pending alter is deleted. Undecodable objects
+// are left as they are, fsck reports them
+func (c *Collection) rewriteBatch(pending *pendingAlter) (done bool, err error) {
+	err = c.db.kv.Update(func(tx *bbolt.Tx) error {
+		colBucket := tx.Bucket([]byte(c.name))
+		objBucket := colBucket.Bucket([]byte(bucketCollectionObjects))
+
+		// the bucket can't be modified while iterating over it
+		ids := make([]string, 0, alterBatchSize)
+		objs := make([]*model.ReqInsertObject, 0, alterBatchSize)
+		cursor := objBucket.Cursor()
+		k, v := cursor.Seek([]byte(pending.After))
+		if k != nil && string(k) == pending.After {
+			k, v = cursor.Next()
+		}
+		for ; k != nil && len(ids) < alterBatchSize; k, v = cursor.Next() {
+			pending.After = string(k)
+			obj := new(model.ReqInsertObject)
+			if err := pkg.Deserialize(v, obj); err != nil {
+				continue
+			}
+			ids = append(ids, string(k))
+			objs = append(objs, obj)
+		}
+		done = k == nil
+
+		for i, obj := range objs {
+			if obj.Metadata == nil {
+				obj.Metadata = make(map[string]interface{})
+			}
+			alterMetadata(obj.Metadata, pending.Changes)
+
+			objBytes, err := pkg.Serialize(obj)
+			if err != nil {
+				return fmt.Errorf("failed to serialize object: %w", err)
+			}
+			if err := objBucket.Put([]byte(ids[i]), objBytes); err != nil {
+				return fmt.Errorf("failed to put object: %w", err)
+			}
+			if err := c.refillMetadataIndexes(colBucket, ids[i], obj.Metadata, pending.Indexes); err != nil {
+				return err
+			}
+		}
+
+		if done {
+			if err := colBucket.Delete([]byte(alterPendingKey)); err != nil {
+				return fmt.Errorf("failed to delete pending alter: %w", err)
+			}
+			return nil
+		}
+		return putPendingAlter(colBucket, pending)
+	})
+	return done, err
+}
+
+// refillMetadataIndexes puts the index entries of an object for the given fields
+func (c *Collection) refillMetadataIndexes(colBucket *bbolt.Bucket, id string, metadata map[string]interface{}, fields []string) error {
+	for _, field := range fields {
+		prefix, ok := metaIndexPrefix(c.config.MetadataIndexes[field], metadata[field])
+		if !ok {
+			continue
+		}
+		fieldBucket := colBucket.Bucket([]byte(bucketCollectionIndexes)).Bucket([]byte(field))
+		if err := fieldBucket.Put(append(prefix, id...), []byte{}); err != nil {
+			return fmt.Errorf("failed to put metadata index of field '%s': %w", field, err)
+		}
+	}
+	return nil
+}
+
+// resumeAlter finishes the rewrite of an alter interrupted by a crash or an error
+func (c *Collection) resumeAlter() error {
+	var pending *pendingAlter
+	if err := c.db.kv.View(func(tx *bbolt.Tx) error {
+		colBucket := tx.Bucket([]byte(c.name))
+		if colBucket == nil {
+			return nil
+		}
+		v := colBucket.Get([]byte(alterPendingKey))
+		if v == nil {
+			return nil
+		}
+		pending = new(pendingAlter)
+		if err := pkg.Deserialize(v, pending); err != nil {
+			return fmt.Errorf("failed to deserialize pending alter: %w", err)
+		}
+		return nil
+	}); err != nil || pending == nil {
+		return err
+	}
+
+	return c.rewriteObjects(pending)
+}
+
+func (c *Collection) rewriteObjects(pending *pendingAlter) error {
+	for done := false; !done; {
+		var err error
+		if done, err = c.rewriteBatch(pending); err != nil {
+			return fmt.Errorf("failed to rewrite objects: %w", err)
+		}
+	}
+	return nil
+}
+
+func putPendingAlter(colBucket *bbolt.Bucket, pending *pendingAlter) error {
+	v, err := pkg.Serialize(pending)
+	if err != nil {
+		return fmt.Errorf("failed to serialize pending alter: %w", err)
+	}
+	if err := colBucket.Put([]byte(alterPendingKey), v); err != nil {
+		return fmt.Errorf("failed to put pending alter: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func TestAlterCollection(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+	defer func() { db.Close() }()
+
+	assert.NoError(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "test", Dimension: 2, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 100.0},
+		Distance: "euclidean", Mapping: []string{"category", "price"},
+		MetadataIndexes: map[string]string{"category": "keyword"},
+	}))
+	ids := []string{}
+	for i := 0; i < 10; i++ {
+		id, err := collection(t, db, "test").InsertObject(&model.ReqInsertObject{
+			Metadata: map[string]interface{}{"category": fmt.Sprintf("c%d", i%2), "price": float64(i)},
+			Vector:   []float32{float32(i), 0},
+		})
+		assert.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	invalid := [][]model.FieldChange{
+		{{Op: "add", Field: &model.Field{Name: "stock", Type: "int"}}}, // no default to backfill
+		{{Op: "add", Field: &model.Field{Name: "price", Type: "float", Default: 0.0}}},
+		{{Op: "add", Field: &model.Field{Name: "stock", Type: "int", Default: "none"}}},
+		{{Op: "drop", Name: "stock"}},
+		{{Op: "rename", Name: "price", NewName: "category"}},
+		{{Op: "rename", Name: "price"}},
+		{{Op: "resize", Name: "price"}},
+		// changes apply in order and fail as a whole
+		{{Op: "drop", Name: "price"}, {Op: "drop", Name: "price"}},
+	}
+	for _, changes := range invalid {
+		assert.Error(t, db.AlterCollection("test", &model.ReqAlterCollection{Changes: changes}), changes)
+	}
+	info, err := db.GetCollectionInfo("test")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"category", "price"}, info.Mapping)
+
+	assert.NoError(t, db.AlterCollection("test", &model.ReqAlterCollection{Changes: []model.FieldChange{
+		{Op: "add", Field: &model.Field{Name: "stock", Type: "int", Default: 5.0}},
+		{Op: "drop", Name: "price"},
+		{Op: "rename", Name: "category", NewName: "kind"},
+	}}))
+
+	info, err = db.GetCollectionInfo("test")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kind", "stock"}, info.Mapping)
+	assert.Equal(t, map[string]string{"kind": "keyword"}, info.MetadataIndexes)
+
+	obj, err := collection(t, db, "test").GetObjectInfo(ids[3])
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"kind": "c1", "stock": int64(5)}, obj.Metadata)
+
+	// the renamed index still resolves filters and objects follow the new mapping
+	res, err := collection(t, db, "test").CountObjects(&model.ReqCountObjects{Filter: &model.Filter{Field: "kind", Op: "eq", Value: "c0"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, res.Count)
+	_, err = collection(t, db, "test").CountObjects(&model.ReqCountObjects{Filter: &model.Filter{Field: "category", Op: "eq", Value: "c0"}})
+	assert.Error(t, err)
+	_, err = collection(t, db, "test").InsertObject(&model.ReqInsertObject{
+		Metadata: map[string]interface{}{"category": "c0", "price": 1.0}, Vector: []float32{0, 0},
+	})
+	assert.Error(t, err)
+	_, err = collection(t, db, "test").InsertObject(&model.ReqInsertObject{
+		Metadata: map[string]interface{}{"kind": "c0", "stock": 1.0}, Vector: []float32{0, 0},
+	})
+	assert.NoError(t, err)
+
+	// the vector index is unchanged
+	results, err := collection(t, db, "test").SearchObjects(&model.ReqSearchObject{Vector: []float32{3, 0}, TopK: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, ids[3], results[0].ID)
+
+	// the changes survive a restart
+	db.Close()
+	db = openDB(t, dir, CheckpointPolicy{})
+	info, err = db.GetCollectionInfo("test")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kind", "stock"}, info.Mapping)
+	res, err = collection(t, db, "test").CountObjects(&model.ReqCountObjects{Filter: &model.Filter{Field: "kind", Op: "eq", Value: "c0"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, res.Count)
+}
+
+func TestAlterSchemaCollection(t *testing.T) {
+	db := openDB(t, t.TempDir(), CheckpointPolicy{})
+	defer db.Close()
+
+	assert.NoError(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "books", Dimension: 2, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 100.0},
+		Distance: "euclidean", Schema: []model.Field{{Name: "title", Type: "string", Required: true}},
+	}))
+	id, err := collection(t, db, "books").InsertObject(&model.ReqInsertObject{
+		Metadata: map[string]interface{}{"title": "dune"}, Vector: []float32{1, 0},
+	})
+	assert.NoError(t, err)
+
+	// added fields can't be required, optional ones don't need a default
+	assert.Error(t, db.AlterCollection("books", &model.ReqAlterCollection{Changes: []model.FieldChange{
+		{Op: "add", Field: &model.Field{Name: "year", Type: "int", Required: true}},
+	}}))
+	assert.NoError(t, db.AlterCollection("books", &model.ReqAlterCollection{Changes: []model.FieldChange{
+		{Op: "add", Field: &model.Field{Name: "year", Type: "int"}},
+		{Op: "add", Field: &model.Field{Name: "tags", Type: "string_array", Default: []interface{}{"new"}}},
+		{Op: "rename", Name: "title", NewName: "name"},
+	}}))
+
+	info, err := db.GetCollectionInfo("books")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Field{
+		{Name: "name", Type: "string", Required: true},
+		{Name: "year", Type: "int"},
+		{Name: "tags", Type: "string_array", Default: []string{"new"}},
+	}, info.Schema)
+
+	obj, err := collection(t, db, "books").GetObjectInfo(id)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "dune", "tags": []string{"new"}}, obj.Metadata)
+
+	_, err = collection(t, db, "books").InsertObject(&model.ReqInsertObject{
+		Metadata: map[string]interface{}{"name": "emma", "year": 1815.0}, Vector: []float32{0, 1},
+	})
+	assert.NoError(t, err)
+	_, err = collection(t, db, "books").InsertObject(&model.ReqInsertObject{
+		Metadata: map[string]interface{}{"title": "emma"}, Vector: []float32{0, 1},
+	})
+	assert.Error(t, err)
+}
+
+func TestAlterCollectionBatches(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+	defer func() { db.Close() }()
+
+	assert.NoError(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "test", Dimension: 2, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 10000.0},
+		Distance: "euclidean", Mapping: []string{"category", "price"},
+		MetadataIndexes: map[string]string{"category": "keyword", "price": "float"},
+	}))
+	objs := make([]model.ReqInsertObject, 2*alterBatchSize+10)
+	for i := range objs {
+		objs[i] = model.ReqInsertObject{
+			ID:       fmt.Sprintf("%05d", i),
+			Metadata: map[string]interface{}{"category": fmt.Sprintf("c%d", i%2), "price": float64(i)},
+			Vector:   []float32{float32(i), 0},
+		}
+	}
+	_, err := collection(t, db, "test").InsertObjects(&model.ReqInsertObjects{Objects: objs})
+	assert.NoError(t, err)
+
+	// the process dies after the first batch, the index of the renamed field takes the name of
+	// the dropped one
+	col := collection(t, db, "test")
+	col.mu.Lock()
+	pending, err := col.startAlter([]model.FieldChange{
+		{Op: "drop", Name: "category"},
+		{Op: "rename", Name: "price", NewName: "category"},
+	})
+	assert.NoError(t, err)
+	done, err := col.rewriteBatch(pending)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	col.mu.Unlock()
+	crash(t, db)
+
+	db = openDB(t, dir, CheckpointPolicy{})
+	info, err := db.GetCollectionInfo("test")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"category"}, info.Mapping)
+	assert.Equal(t, map[string]string{"category": "float"}, info.MetadataIndexes)
+	for _, i := range []int{0, alterBatchSize, len(objs) - 1} {
+		obj, err := collection(t, db, "test").GetObjectInfo(fmt.Sprintf("%05d", i))
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"category": float64(i)}, obj.Metadata)
+	}
+	res, err := collection(t, db, "test").CountObjects(&model.ReqCountObjects{Filter: &model.Filter{Field: "category", Op: "range", Gte: float64(alterBatchSize)}})
+	assert.NoError(t, err)
+	assert.Equal(t, len(objs)-alterBatchSize, res.Count)
+
+	assert.NoError(t, db.kv.View(func(tx *bbolt.Tx) error {
+		assert.Nil(t, tx.Bucket([]byte("test")).Get([]byte(alterPendingKey)))
+		return nil
+	}))
+}
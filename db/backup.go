@@ -0,0 +1,429 @@
+package db
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// Backup archive layout, a gzipped tar holding:
+//
+//	manifest.json       backupManifest
+//	vectordb.db         bbolt file written from a single read transaction
+//	<col>.snapshot      index snapshot at the WAL sequence of the fence
+//	<col>.index         data file of on-disk indexes
+//
+// The fence blocks the writers of every collection while the read transaction is opened and the
+// index snapshots are taken, so the objects and the indexes in the archive agree. The WAL is not
+// archived, every committed entry is covered by the snapshot.
+const (
+	backupVersion      = 1
+	backupManifestName = "manifest.json"
+	backupKVName       = "vectordb.db"
+)
+
+type backupManifest struct {
+	Version     int               `json:"version"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Collections map[string]uint64 `json:"collections"` // WAL sequence of the fence
+}
+
+type backupCollection struct {
+	name     string
+	seq      uint64
+	snapshot *os.File
+	index    *os.File // copy of the index data file, nil for in-memory indexes
+}
+
+// Backup writes a consistent archive of the database to w, writers are only blocked until the
+// fence is taken, not while the archive is written
+func (db *DB) Backup(w io.Writer) error {
+	if err := db.backup(w); err != nil {
+		return fmt.Errorf("failed to back up db: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) backup(w io.Writer) error {
+	tx, cols, err := db.backupFence()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+		closeBackupFiles(cols)
+	}()
+
+	manifest := backupManifest{
+		Version:     backupVersion,
+		CreatedAt:   time.Now().UTC(),
+		Collections: make(map[string]uint64, len(cols)),
+	}
+	for _, bc := range cols {
+		manifest.Collections[bc.name] = bc.seq
+	}
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal backup manifest: %w", err)
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	if err := writeTarEntry(tw, backupManifestName, int64(len(manifestBytes)), func(w io.Writer) error {
+		_, err := w.Write(manifestBytes)
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := writeTarEntry(tw, backupKVName, tx.Size(), func(w io.Writer) error {
+		_, err := tx.WriteTo(w)
+		return err
+	}); err != nil {
+		return err
+	}
+	for _, bc := range cols {
+		if err := writeTarFile(tw, bc.name+".snapshot", bc.snapshot); err != nil {
+			return err
+		}
+		if bc.index != nil {
+			if err := writeTarFile(tw, bc.name+".index", bc.index); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to close backup archive: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("failed to close backup archive: %w", err)
+	}
+
+	return nil
+}
+
+// backupFence opens the read transaction of the backup while the writers of every collection
+// are blocked, then snapshots each index at that point and releases its collection
+func (db *DB) backupFence() (*bbolt.Tx, []*backupCollection, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	names := make([]string, 0, len(db.collections))
+	for name := range db.collections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// checkpoints are blocked as well, they would replace the snapshot files
+	for _, name := range names {
+		col := db.collections[name]
+		col.ckmu.Lock()
+		col.mu.RLock()
+	}
+	unlocked := 0
+	defer func() {
+		for _, name := range names[unlocked:] {
+			col := db.collections[name]
+			col.mu.RUnlock()
+			col.ckmu.Unlock()
+		}
+	}()
+
+	tx, err := db.kv.Begin(false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin read transaction: %w", err)
+	}
+
+	cols := make([]*backupCollection, 0, len(names))
+	fail := func(err error) (*bbolt.Tx, []*backupCollection, error) {
+		tx.Rollback()
+		closeBackupFiles(cols)
+		return nil, nil, err
+	}
+
+	for _, name := range names {
+		col := db.collections[name]
+		bc, err := col.backupFiles()
+		if err != nil {
+			return fail(fmt.Errorf("failed to back up collection '%s': %w", name, err))
+		}
+		cols = append(cols, bc)
+
+		col.mu.RUnlock()
+		col.ckmu.Unlock()
+		unlocked++
+	}
+
+	return tx, cols, nil
+}
+
+// backupFiles snapshots the index if the snapshot on disk is behind and opens it, the open file
+// stays readable after later checkpoints rename a new snapshot over it. The caller holds c.mu
+// and c.ckmu
+func (c *Collection) backupFiles() (*backupCollection, error) {
+	if c.seq != c.snapshotSeq {
+		if err := c.saveSnapshot(); err != nil {
+			return nil, fmt.Errorf("failed to save index snapshot: %w", err)
+		}
+	}
+
+	snapshot, err := os.Open(c.db.snapshotPath(c.name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index snapshot: %w", err)
+	}
+	bc := &backupCollection{name: c.name, seq: c.seq, snapshot: snapshot}
+
+	// on-disk indexes write their data file in place, it is copied before the writers resume
+	index, err := os.Open(c.db.indexPath(c.name))
+	if errors.Is(err, os.ErrNotExist) {
+		return bc, nil
+	}
+	if err != nil {
+		snapshot.Close()
+		return nil, fmt.Errorf("failed to open index file: %w", err)
+	}
+	defer index.Close()
+
+	bc.index, err = os.CreateTemp(c.db.path, c.name+".index.backup-*")
+	if err != nil {
+		snapshot.Close()
+		return nil, fmt.Errorf("failed to create index file copy: %w", err)
+	}
+	if _, err := io.Copy(bc.index, index); err != nil {
+		snapshot.Close()
+		bc.index.Close()
+		os.Remove(bc.index.Name())
+		return nil, fmt.Errorf("failed to copy index file: %w", err)
+	}
+
+	return bc, nil
+}
+
+func closeBackupFiles(cols []*backupCollection) {
+	for _, bc := range cols {
+		bc.snapshot.Close()
+		if bc.index != nil {
+			bc.index.Close()
+			os.Remove(bc.index.Name())
+		}
+	}
+}
+
+func writeTarEntry(tw *tar.Writer, name string, size int64, write func(io.Writer) error) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    size,
+		ModTime: time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to write archive header of '%s': %w", name, err)
+	}
+	if err := write(tw); err != nil {
+		return fmt.Errorf("failed to write '%s' to archive: %w", name, err)
+	}
+	return nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat '%s': %w", name, err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek '%s': %w", name, err)
+	}
+	return writeTarEntry(tw, name, info.Size(), func(w io.Writer) error {
+		_, err := io.Copy(w, f)
+		return err
+	})
+}
+
+// Restore rebuilds a data directory from a backup archive, path must not hold a database yet.
+// The restored collections start a new WAL, their snapshots and committed WAL sequences are
+// reset to its start
+func Restore(r io.Reader, path string) error {
+	if entries, err := os.ReadDir(path); err == nil && len(entries) > 0 {
+		return fmt.Errorf("restore path '%s' is not empty", path)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("couldn't read restore path '%s': %w", path, err)
+	}
+	if err := os.MkdirAll(path, 0750); err != nil {
+		return fmt.Errorf("couldn't create restore path '%s': %w", path, err)
+	}
+
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to open backup archive: %w", err)
+	}
+	tr := tar.NewReader(gr)
+
+	var manifest *backupManifest
+	restored := map[string]bool{}
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read backup archive: %w", err)
+		}
+
+		name := header.Name
+		if manifest == nil {
+			if name != backupManifestName {
+				return fmt.Errorf("backup archive doesn't start with '%s'", backupManifestName)
+			}
+			manifest = new(backupManifest)
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return fmt.Errorf("failed to decode backup manifest: %w", err)
+			}
+			if manifest.Version != backupVersion {
+				return fmt.Errorf("unsupported backup version %d", manifest.Version)
+			}
+			// the names of the collections are joined to the restore path
+			for name := range manifest.Collections {
+				if err := validateCollectionName(name); err != nil {
+					return fmt.Errorf("invalid backup manifest: %w", err)
+				}
+			}
+			continue
+		}
+
+		if !manifest.hasFile(name) || restored[name] {
+			return fmt.Errorf("unexpected file '%s' in backup archive", name)
+		}
+		restored[name] = true
+
+		var src io.Reader = tr
+		if filepath.Ext(name) == ".snapshot" {
+			if src, err = resetSnapshotSeq(tr); err != nil {
+				return fmt.Errorf("failed to restore '%s': %w", name, err)
+			}
+		}
+		if err := restoreFile(src, filepath.Join(path, name)); err != nil {
+			return fmt.Errorf("failed to restore '%s': %w", name, err)
+		}
+	}
+
+	if manifest == nil || !restored[backupKVName] {
+		return fmt.Errorf("incomplete backup archive")
+	}
+	for name := range manifest.Collections {
+		if !restored[name+".snapshot"] {
+			return fmt.Errorf("incomplete backup archive, snapshot of collection '%s' is missing", name)
+		}
+	}
+
+	kv, err := bbolt.Open(filepath.Join(path, backupKVName), 0600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return fmt.Errorf("failed to open kv db: %w", err)
+	}
+	// every collection needs its snapshot, and every snapshot a collection
+	if err := kv.View(func(tx *bbolt.Tx) error {
+		metaBucket := tx.Bucket([]byte(bucketCollectionsMetadata))
+		if metaBucket == nil {
+			return fmt.Errorf("bucket '%s' not found", bucketCollectionsMetadata)
+		}
+		count := 0
+		if err := metaBucket.ForEach(func(k, v []byte) error {
+			if _, ok := manifest.Collections[string(k)]; !ok {
+				return fmt.Errorf("collection '%s' is missing from the backup manifest", k)
+			}
+			count++
+			return nil
+		}); err != nil {
+			return err
+		}
+		if count != len(manifest.Collections) {
+			return fmt.Errorf("the backup manifest has collections that aren't in '%s'", backupKVName)
+		}
+		return nil
+	}); err != nil {
+		kv.Close()
+		return fmt.Errorf("invalid backup archive: %w", err)
+	}
+	if err := kv.Update(func(tx *bbolt.Tx) error {
+		for name := range manifest.Collections {
+			if err := putWALSeq(tx, name, 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		kv.Close()
+		return fmt.Errorf("failed to reset WAL sequences: %w", err)
+	}
+	if err := kv.Close(); err != nil {
+		return fmt.Errorf("failed to close kv db: %w", err)
+	}
+
+	return nil
+}
+
+// hasFile reports whether name is a file the archive of the manifest may hold
+func (m *backupManifest) hasFile(name string) bool {
+	if name == backupKVName {
+		return true
+	}
+	ext := filepath.Ext(name)
+	if ext != ".snapshot" && ext != ".index" {
+		return false
+	}
+	_, ok := m.Collections[name[:len(name)-len(ext)]]
+	return ok
+}
+
+func restoreFile(r io.Reader, dst string) error {
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	return copyAndSync(f, r)
+}
+
+// copyAndSync copies r into f, then syncs and closes f
+func copyAndSync(f *os.File, r io.Reader) error {
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// resetSnapshotSeq returns the snapshot read from r with its WAL sequence reset to the start
+// of a new WAL
+func resetSnapshotSeq(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	header := snapshotHeader{}
+	if err := binary.Read(br, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot header: %w", err)
+	}
+	if header.Magic != snapshotMagic {
+		return nil, fmt.Errorf("invalid snapshot file")
+	}
+	header.Seq = 0
+
+	buf := new(bytes.Buffer)
+	if err := binary.Write(buf, binary.LittleEndian, header); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+	return io.MultiReader(buf, br), nil
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupRestore(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+
+	createWALTestCollection(t, db)
+	insertWALTestObject(t, db, "a", "x", []float32{1, 0})
+	insertWALTestObject(t, db, "b", "y", []float32{2, 0})
+	_, err := db.CheckpointCollection("test")
+	assert.NoError(t, err)
+	insertWALTestObject(t, db, "c", "x", []float32{3, 0})
+
+	// on-disk index, its data file is archived next to the snapshot
+	assert.NoError(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "disk", Dimension: 2, IndexType: "vamana", IndexParams: map[string]interface{}{"maxsize": 100.0, "trainsize": 1000.0, "m": 2.0},
+		Distance: "euclidean", Mapping: []string{"n"},
+	}))
+	for i := 0; i < 10; i++ {
+		_, err := collection(t, db, "disk").InsertObject(&model.ReqInsertObject{
+			ID: fmt.Sprint(i), Metadata: map[string]interface{}{"n": float64(i)}, Vector: []float32{float32(i), 1},
+		})
+		assert.NoError(t, err)
+	}
+
+	archive := new(bytes.Buffer)
+	assert.NoError(t, db.Backup(archive))
+
+	// later writes are not part of the backup
+	insertWALTestObject(t, db, "d", "y", []float32{4, 0})
+	assert.NoError(t, collection(t, db, "test").DeleteObject("a"))
+	db.Close()
+
+	restored := filepath.Join(t.TempDir(), "restored")
+	assert.NoError(t, Restore(bytes.NewReader(archive.Bytes()), restored))
+	assert.Error(t, Restore(bytes.NewReader(archive.Bytes()), restored))
+	assert.Error(t, Restore(bytes.NewReader(archive.Bytes()), dir))
+
+	db = openDB(t, restored, CheckpointPolicy{})
+	assertConsistent(t, db, map[string][]float32{"a": {1, 0}, "b": {2, 0}, "c": {3, 0}})
+	results, err := collection(t, db, "disk").SearchObjects(&model.ReqSearchObject{Vector: []float32{7, 1}, TopK: 3})
+	assert.NoError(t, err)
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, "7", results[0].ID)
+	}
+
+	// the restored directory starts a new WAL that survives a crash
+	insertWALTestObject(t, db, "e", "x", []float32{5, 0})
+	crash(t, db)
+	db = openDB(t, restored, CheckpointPolicy{})
+	defer db.Close()
+	assertConsistent(t, db, map[string][]float32{"a": {1, 0}, "b": {2, 0}, "c": {3, 0}, "e": {5, 0}})
+
+	_, err = os.Stat(filepath.Join(restored, "disk.index"))
+	assert.NoError(t, err)
+}
+
+// rewriteArchive copies a backup archive, rewriting its manifest and dropping the files rejected by keep
+func rewriteArchive(t *testing.T, archive []byte, manifest func(m *backupManifest), keep func(name string) bool) []byte {
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	assert.NoError(t, err)
+	tr := tar.NewReader(gr)
+	out := new(bytes.Buffer)
+	gw := gzip.NewWriter(out)
+	tw := tar.NewWriter(gw)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		assert.NoError(t, err)
+		if header.Name == backupManifestName {
+			m := new(backupManifest)
+			assert.NoError(t, json.Unmarshal(data, m))
+			manifest(m)
+			data, err = json.Marshal(m)
+			assert.NoError(t, err)
+		} else if !keep(header.Name) {
+			continue
+		}
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: header.Name, Mode: 0600, Size: int64(len(data))}))
+		_, err = tw.Write(data)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gw.Close())
+	return out.Bytes()
+}
+
+func TestRestoreInvalidManifest(t *testing.T) {
+	db := openDB(t, t.TempDir(), CheckpointPolicy{})
+	createWALTestCollection(t, db)
+	insertWALTestObject(t, db, "a", "x", []float32{1, 0})
+	archive := new(bytes.Buffer)
+	assert.NoError(t, db.Backup(archive))
+	db.Close()
+
+	// collection names of the manifest are joined to the restore path
+	dir := t.TempDir()
+	escaping := rewriteArchive(t, archive.Bytes(), func(m *backupManifest) {
+		m.Collections["../escaped"] = 0
+	}, func(string) bool { return true })
+	err := Restore(bytes.NewReader(escaping), filepath.Join(dir, "restored"))
+	assert.ErrorContains(t, err, "invalid collection name '../escaped'")
+	_, err = os.Stat(filepath.Join(dir, "escaped.snapshot"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// the manifest and the collections of the kv db agree
+	missing := rewriteArchive(t, archive.Bytes(), func(m *backupManifest) {
+		delete(m.Collections, "test")
+	}, func(name string) bool { return name == backupKVName })
+	err = Restore(bytes.NewReader(missing), filepath.Join(dir, "missing"))
+	assert.ErrorContains(t, err, "collection 'test' is missing from the backup manifest")
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"fmt"
+	"time"
+	"vectordb/model"
+
+	"go.uber.org/zap"
+)
+
+// CheckpointPolicy controls when collections are checkpointed automatically,
+// a checkpoint saves an index snapshot and truncates the WAL entries it covers
+type CheckpointPolicy struct {
+	Interval time.Duration // checkpoint every collection with new WAL entries periodically, 0 disables
+	WALSize  int64         // checkpoint a collection once this many WAL bytes are written since the last checkpoint, 0 disables
+}
+
+// Checkpoint snapshots the index and truncates the WAL up to the snapshot sequence,
+// it returns the last durable sequence number
+func (c *Collection) Checkpoint() (uint64, error) {
+	c.ckmu.Lock()
+	defer c.ckmu.Unlock()
+
+	// writers are blocked while the snapshot is taken, readers are not
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if err := c.checkOpen(); err != nil {
+		return 0, err
+	}
+
+	if c.seq != c.snapshotSeq {
+		if err := c.saveSnapshot(); err != nil {
+			return 0, fmt.Errorf("failed to save index snapshot: %w", err)
+		}
+	}
+
+	// the entry at the snapshot sequence is kept since the WAL can't be emptied,
+	// it is skipped by replay anyway
+	if c.snapshotSeq > 0 {
+		first, err := c.wal.FirstIndex()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get first WAL index: %w", err)
+		}
+		if first < c.snapshotSeq {
+			if err := c.wal.TruncateFront(c.snapshotSeq); err != nil {
+				return 0, fmt.Errorf("failed to truncate WAL: %w", err)
+			}
+		}
+	}
+	c.walBytes.Store(0)
+
+	return c.snapshotSeq, nil
+}
+
+// CheckpointCollection checkpoints the collection colname, see Collection.Checkpoint
+func (db *DB) CheckpointCollection(colname string) (model.ResCheckpoint, error) {
+	col, err := db.Collection(colname)
+	if err != nil {
+		return model.ResCheckpoint{}, err
+	}
+
+	seq, err := col.Checkpoint()
+	if err != nil {
+		return model.ResCheckpoint{}, fmt.Errorf("failed to checkpoint collection '%s': %w", colname, err)
+	}
+
+	return model.ResCheckpoint{Name: colname, Seq: seq}, nil
+}
+
+// maybeCheckpoint asks the checkpointer to checkpoint the collection once the WAL size threshold is hit
+func (c *Collection) maybeCheckpoint() {
+	if c.db.policy.WALSize <= 0 || c.walBytes.Load() < c.db.policy.WALSize {
+		return
+	}
+
+	select {
+	case c.db.checkpointCh <- c:
+	default: // a checkpoint is already pending
+	}
+}
+
+func (db *DB) startCheckpointer() {
+	db.checkpointCh = make(chan *Collection, 1)
+	db.done = make(chan struct{})
+
+	db.wg.Add(1)
+	go db.runCheckpointer()
+}
+
+func (db *DB) stopCheckpointer() {
+	close(db.done)
+	db.wg.Wait()
+}
+
+func (db *DB) runCheckpointer() {
+	defer db.wg.Done()
+
+	var tick <-chan time.Time
+	if db.policy.Interval > 0 {
+		ticker := time.NewTicker(db.policy.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-db.done:
+			return
+		case col := <-db.checkpointCh:
+			db.checkpoint(col)
+		case <-tick:
+			db.mu.RLock()
+			cols := make([]*Collection, 0, len(db.collections))
+			for _, col := range db.collections {
+				cols = append(cols, col)
+			}
+			db.mu.RUnlock()
+
+			for _, col := range cols {
+				db.checkpoint(col)
+			}
+		}
+	}
+}
+
+// checkpoint doesn't hold db.mu while the snapshot is written, a collection deleted or replaced
+// meanwhile is closed and its Checkpoint fails on checkOpen, which isn't reported
+func (db *DB) checkpoint(col *Collection) {
+	if !db.current(col) {
+		return
+	}
+
+	if _, err := col.Checkpoint(); err != nil && db.current(col) {
+		zap.L().Error("checkpoint failed", zap.String("collection", col.name), zap.Error(err))
+	}
+}
+
+// current reports whether the handle is still the one of its collection
+func (db *DB) current(col *Collection) bool {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.collections[col.name] == col
+}
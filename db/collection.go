@@ -1,9 +1,13 @@
 package db
 
 import (
+	"encoding/base64"
 	"fmt"
-	"path/filepath"
+	"io"
+	"math"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"vectordb/db/index"
 	"vectordb/model"
 	"vectordb/pkg"
@@ -12,37 +16,46 @@ import (
 	"go.etcd.io/bbolt"
 )
 
-type WALEntryType int
+// default number of candidates per result to rescore when reranking
+const defaultOversampling = 4.0
 
-const (
-	WALInsert WALEntryType = iota
-	WALDelete
-	WALUpdate
-)
+// default number of objects per page of ScrollObjects
+const defaultScrollLimit = 100
 
-type WALEntry struct {
-	Type   WALEntryType
-	ID     string
-	Vector []float32
-}
+// client supplied object ids are bbolt keys, also embedded in the metadata index keys
+const maxObjectIDLength = 255
 
+// Collection is a handle of a collection of a DB, its methods validate their input like the
+// REST API does
 type Collection struct {
-	name   string
-	config model.CfgCollection
-	index  index.Indexer
-	mu     sync.RWMutex
-	wal    *wal.Log
-	seq    uint64
+	db          *DB
+	name        string
+	config      model.CfgCollection
+	index       index.Indexer
+	distfunc    func([]float32, []float32) float32
+	mu          sync.RWMutex
+	wal         *wal.Log
+	seq         uint64
+	snapshotSeq uint64       // last WAL sequence covered by the index snapshot on disk
+	walBytes    atomic.Int64 // WAL bytes written since the last checkpoint
+	ckmu        sync.Mutex   // serializes checkpoints
+	walErrors   []string     // WAL entries that couldn't be replayed
+	closed      bool         // set by Close, the methods of the handle fail afterwards
 }
 
-func newCollection(colname string, cfg *model.CfgCollection) (*Collection, error) {
+func (db *DB) newCollection(colname string, cfg *model.CfgCollection) (_ *Collection, err error) {
 	col := Collection{
+		db:     db,
 		name:   colname,
 		config: *cfg,
 	}
 
-	walPath := filepath.Join(db.path, colname+".wal")
-	log, err := wal.Open(walPath, &wal.Options{
+	// a restore interrupted by a crash replaces the files before they are opened
+	if err := db.finishRestore(colname); err != nil {
+		return nil, fmt.Errorf("failed to finish restore: %w", err)
+	}
+
+	log, err := wal.Open(db.walPath(colname), &wal.Options{
 		NoSync: false,
 		NoCopy: true,
 	})
@@ -50,13 +63,33 @@ func newCollection(colname string, cfg *model.CfgCollection) (*Collection, error
 		return nil, fmt.Errorf("failed to open WAL: %w", err)
 	}
 	col.wal = log
+	defer func() {
+		if err != nil {
+			col.closeFiles()
+		}
+	}()
 
-	idx, err := index.NewIndexer(cfg)
+	idx, err := index.NewIndexer(cfg, db.indexPath(colname))
 	if err != nil {
 		return nil, err
 	}
 	col.index = idx
 
+	col.distfunc, err = pkg.GetDistanceFunc(cfg.Distance)
+	if err != nil {
+		return nil, err
+	}
+
+	// load the latest index snapshot, then replay only the WAL entries written after it
+	if _, err := col.loadSnapshot(); err != nil {
+		return nil, fmt.Errorf("failed to load index snapshot: %w", err)
+	}
+
+	// an alter interrupted by a crash is finished before the collection is used
+	if err := col.resumeAlter(); err != nil {
+		return nil, fmt.Errorf("failed to resume alter: %w", err)
+	}
+
 	if err := col.replayWAL(); err != nil {
 		return nil, fmt.Errorf("failed to replay WAL: %w", err)
 	}
@@ -64,55 +97,38 @@ func newCollection(colname string, cfg *model.CfgCollection) (*Collection, error
 	return &col, nil
 }
 
-func (c *Collection) replayWAL() error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	first, err := c.wal.FirstIndex()
-	if err != nil {
-		return err
-	}
-	last, err := c.wal.LastIndex()
-	if err != nil {
-		return err
+// closeFiles releases the files of a collection that failed to load, its index may not exist yet
+func (c *Collection) closeFiles() {
+	if closer, ok := c.index.(io.Closer); ok {
+		closer.Close()
 	}
+	c.wal.Close()
+}
 
-	c.seq = last
-
-	for i := first; i <= last; i++ {
-		data, err := c.wal.Read(i)
-		if err != nil {
-			continue
+// validateObjectMeta checks the objects against the collection config, metadata of collections
+// with a schema is replaced by its normalized form. The caller holds c.mu until the objects are
+// committed, so that an alter can't change the config in between
+func (c *Collection) validateObjectMeta(objs []model.ReqInsertObject) error {
+	for i, obj := range objs {
+		if len(obj.ID) > maxObjectIDLength {
+			return fmt.Errorf("object id longer than %d bytes in object %d", maxObjectIDLength, i)
 		}
 
-		var entry WALEntry
-		if err := pkg.Deserialize(data, &entry); err != nil {
+		if c.config.Schema != nil {
+			if len(obj.Vector) != c.config.Dimension {
+				return fmt.Errorf("vector dimension mismatch in object %d", i)
+			}
+			metadata, err := applySchema(c.config.Schema, obj.Metadata)
+			if err != nil {
+				return fmt.Errorf("%w in object %d", err, i)
+			}
+			if err := c.validateMetadataTypes(metadata); err != nil {
+				return fmt.Errorf("%w in object %d", err, i)
+			}
+			objs[i].Metadata = metadata
 			continue
 		}
 
-		switch entry.Type {
-		case WALInsert:
-			c.index.Insert(entry.ID, entry.Vector)
-		case WALDelete:
-			c.index.Delete(entry.ID)
-		case WALUpdate:
-			c.index.Update(entry.ID, entry.Vector)
-		}
-	}
-	return nil
-}
-
-func getCollection(colname string) (*Collection, error) {
-	col, ok := db.collections[colname]
-	if !ok {
-		return nil, fmt.Errorf("collection '%s' not found", colname)
-	}
-
-	return col, nil
-}
-
-func (c *Collection) validateObjectMeta(objs []model.ReqInsertObject) error {
-	for i, obj := range objs {
 		if len(obj.Metadata) != len(c.config.Mapping) {
 			return fmt.Errorf("metadata length mismatch in object %d", i)
 		}
@@ -126,6 +142,10 @@ func (c *Collection) validateObjectMeta(objs []model.ReqInsertObject) error {
 				return fmt.Errorf("metadata key '%s' not found in object %d", key, i)
 			}
 		}
+
+		if err := c.validateMetadataTypes(obj.Metadata); err != nil {
+			return fmt.Errorf("%w in object %d", err, i)
+		}
 	}
 	return nil
 }
@@ -134,6 +154,25 @@ func (c *Collection) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.closed {
+		return nil
+	}
+
+	// snapshot on shutdown so the next start doesn't need to replay the whole WAL, unless the
+	// WAL has entries waiting for Fsck
+	if c.seq != c.snapshotSeq && len(c.walErrors) == 0 {
+		if err := c.saveSnapshot(); err != nil {
+			return fmt.Errorf("failed to save index snapshot: %w", err)
+		}
+	}
+	c.closed = true
+
+	if closer, ok := c.index.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("failed to close index: %w", err)
+		}
+	}
+
 	if c.wal != nil {
 		if err := c.wal.Close(); err != nil {
 			return fmt.Errorf("failed to close WAL: %w", err)
@@ -142,186 +181,350 @@ func (c *Collection) Close() error {
 	return nil
 }
 
-func (c *Collection) insertObject(tx *bbolt.Tx, obj *model.ReqInsertObject) (string, error) {
-	id, err := pkg.NewUUID()
-	if err != nil {
-		return "", err
+// checkOpen fails once the collection is closed, deleted or restored over, the caller holds c.mu
+func (c *Collection) checkOpen() error {
+	if c.closed {
+		return fmt.Errorf("collection '%s' is closed", c.name)
 	}
+	return nil
+}
+
+// insertObject puts a new object and its metadata index entries, the caller inserts the vector
+// into the index
+func (c *Collection) insertObject(tx *bbolt.Tx, obj *model.ReqInsertObject) (WALEntry, error) {
+	colBucket := tx.Bucket([]byte(c.name))
+	objBucket := colBucket.Bucket([]byte(bucketCollectionObjects))
 
-	entry := WALEntry{
-		Type:   WALInsert,
-		ID:     id,
-		Vector: obj.Vector,
+	id := obj.ID
+	if id == "" {
+		var err error
+		if id, err = pkg.NewUUID(); err != nil {
+			return WALEntry{}, err
+		}
+	} else if objBucket.Get([]byte(id)) != nil {
+		return WALEntry{}, fmt.Errorf("object %s already exists", id)
 	}
-	walData, err := pkg.Serialize(entry)
+
+	objBytes, err := pkg.Serialize(obj)
 	if err != nil {
-		return "", fmt.Errorf("failed to serialize WAL entry: %w", err)
+		return WALEntry{}, fmt.Errorf("failed to serialize object: %w", err)
+	}
+
+	if err := objBucket.Put([]byte(id), objBytes); err != nil {
+		return WALEntry{}, fmt.Errorf("failed to put object under collection '%s': %w", c.name, err)
 	}
 
-	c.seq++
-	if err := c.wal.Write(c.seq, walData); err != nil {
-		return "", fmt.Errorf("failed to write to WAL: %w", err)
+	if err := c.updateMetadataIndexes(tx, id, nil, obj.Metadata); err != nil {
+		return WALEntry{}, err
 	}
 
-	colBucket := tx.Bucket([]byte(c.name))
-	objBucket := colBucket.Bucket([]byte(bucketCollectionObjects))
+	return WALEntry{
+		Type:     WALInsert,
+		ID:       id,
+		Vector:   obj.Vector,
+		Metadata: obj.Metadata,
+	}, nil
+}
+
+// getStoredObject returns the stored object or nil if it doesn't exist
+func (c *Collection) getStoredObject(tx *bbolt.Tx, objid string) (*model.ReqInsertObject, error) {
+	objBucket := tx.Bucket([]byte(c.name)).Bucket([]byte(bucketCollectionObjects))
+
+	exist := objBucket.Get([]byte(objid))
+	if exist == nil {
+		return nil, nil
+	}
+	obj := new(model.ReqInsertObject)
+	if err := pkg.Deserialize(exist, obj); err != nil {
+		return nil, fmt.Errorf("failed to deserialize object: %w", err)
+	}
+	return obj, nil
+}
+
+// upsertObject inserts the object or replaces the one with the same id. The index is mutated
+// within the transaction, undo reverts it if the transaction doesn't commit
+func (c *Collection) upsertObject(tx *bbolt.Tx, obj *model.ReqInsertObject) (entry WALEntry, undo func(), err error) {
+	if obj.ID == "" {
+		return entry, nil, fmt.Errorf("upsert requires an object id")
+	}
+
+	old, err := c.getStoredObject(tx, obj.ID)
+	if err != nil {
+		return entry, nil, err
+	}
 
 	objBytes, err := pkg.Serialize(obj)
 	if err != nil {
-		return "", fmt.Errorf("failed to serialize object: %w", err)
+		return entry, nil, fmt.Errorf("failed to serialize object: %w", err)
+	}
+	objBucket := tx.Bucket([]byte(c.name)).Bucket([]byte(bucketCollectionObjects))
+	if err := objBucket.Put([]byte(obj.ID), objBytes); err != nil {
+		return entry, nil, fmt.Errorf("failed to put object under collection '%s': %w", c.name, err)
 	}
 
-	if err := objBucket.Put([]byte(id), objBytes); err != nil {
-		return "", fmt.Errorf("failed to put object under collection '%s': %w", c.name, err)
+	var oldMetadata map[string]interface{}
+	if old != nil {
+		oldMetadata = old.Metadata
+	}
+	if err := c.updateMetadataIndexes(tx, obj.ID, oldMetadata, obj.Metadata); err != nil {
+		return entry, nil, err
 	}
 
-	if err := c.index.Insert(id, obj.Vector); err != nil {
-		return "", err
+	entry = WALEntry{
+		Type:     WALInsert,
+		ID:       obj.ID,
+		Vector:   obj.Vector,
+		Metadata: obj.Metadata,
+	}
+	if old == nil {
+		if err := c.index.Insert(obj.ID, obj.Vector); err != nil {
+			return entry, nil, err
+		}
+		undo = func() { c.index.Delete(obj.ID) }
+	} else {
+		if err := c.index.Update(obj.ID, obj.Vector); err != nil {
+			return entry, nil, err
+		}
+		undo = func() { c.index.Update(obj.ID, old.Vector) }
+		entry.Type = WALUpdate
 	}
 
-	return id, nil
+	return entry, undo, nil
 }
 
-func (c *Collection) InsertObject(obj *model.ReqInsertObject) (string, error) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// deleteObject removes an object and its metadata index entries, the caller deletes the vector
+// from the index
+func (c *Collection) deleteObject(tx *bbolt.Tx, objid string) error {
+	colBucket := tx.Bucket([]byte(c.name))
+	objBucket := colBucket.Bucket([]byte(bucketCollectionObjects))
 
-	var id string
-	if err := db.kv.Update(func(tx *bbolt.Tx) error {
-		var err error
-		id, err = c.insertObject(tx, obj)
-		return err
-	}); err != nil {
-		return "", fmt.Errorf("failed to insert object into collection '%s': %w", c.name, err)
+	if len(c.config.MetadataIndexes) > 0 {
+		old, err := c.getStoredObject(tx, objid)
+		if err != nil {
+			return err
+		}
+		if old != nil {
+			if err := c.updateMetadataIndexes(tx, objid, old.Metadata, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := objBucket.Delete([]byte(objid)); err != nil {
+		return fmt.Errorf("failed to delete object under collection '%s': %w", c.name, err)
+	}
+
+	return nil
+}
+
+// InsertObject inserts the object and returns its id, generated if the object has none
+func (c *Collection) InsertObject(obj *model.ReqInsertObject) (string, error) {
+	ids, err := c.InsertObjects(&model.ReqInsertObjects{Objects: []model.ReqInsertObject{*obj}})
+	if err != nil {
+		return "", err
 	}
 
-	return id, nil
+	return ids[0], nil
 }
 
-func (c *Collection) InsertObjects(objs []model.ReqInsertObject) ([]string, error) {
+// InsertObjects inserts the objects all or nothing: they are written with a single WAL record
+// in a single transaction, and their vectors are inserted into the index after the commit
+func (c *Collection) InsertObjects(req *model.ReqInsertObjects) ([]string, error) {
+	objs := req.Objects
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	n := len(objs)
-	type result struct {
-		pos int
-		id  string
-		err error
-	}
-	ch := make(chan result, n)
-	var wg sync.WaitGroup
-	ids := make([]string, n)
-
-	for i := 0; i < n; i++ {
-		wg.Add(1)
-		go func(i int) {
-			defer wg.Done()
-			var id string
-			err := db.kv.Batch(func(tx *bbolt.Tx) error {
-				var err error
-				id, err = c.insertObject(tx, &objs[i])
-				return err
-			})
-			ch <- result{pos: i, id: id, err: err}
-		}(i)
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
+	if err := c.validateObjectMeta(objs); err != nil {
+		return nil, err
 	}
 
-	wg.Wait()
-	close(ch)
+	ids := make([]string, len(objs))
+	if err := c.commit(func(tx *bbolt.Tx) ([]WALEntry, error) {
+		entries := make([]WALEntry, len(objs))
+		for i := range objs {
+			entry, err := c.insertObject(tx, &objs[i])
+			if err != nil {
+				return nil, fmt.Errorf("%w in object %d", err, i)
+			}
+			ids[i] = entry.ID
+			entries[i] = entry
+		}
+		return entries, nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to insert objects into collection '%s': %w", c.name, err)
+	}
 
-	for res := range ch {
-		if res.err != nil {
-			return nil, res.err
+	for i := range objs {
+		if err := c.index.Insert(ids[i], objs[i].Vector); err != nil {
+			for _, id := range ids[:i] {
+				c.index.Delete(id)
+			}
+			if rerr := c.removeObjects(ids); rerr != nil {
+				return nil, fmt.Errorf("failed to remove objects after index error '%v': %w", err, rerr)
+			}
+			return nil, fmt.Errorf("failed to insert objects into collection '%s': %w in object %d", c.name, err, i)
 		}
-		ids[res.pos] = res.id
 	}
 
 	return ids, nil
 }
 
-func (c *Collection) DeleteObject(objid string) error {
+// removeObjects takes back committed objects whose vectors couldn't be indexed
+func (c *Collection) removeObjects(ids []string) error {
+	return c.commit(func(tx *bbolt.Tx) ([]WALEntry, error) {
+		entries := make([]WALEntry, len(ids))
+		for i, id := range ids {
+			if err := c.deleteObject(tx, id); err != nil {
+				return nil, err
+			}
+			entries[i] = WALEntry{
+				Type: WALDelete,
+				ID:   id,
+			}
+		}
+		return entries, nil
+	})
+}
+
+// UpsertObject inserts the object or replaces the one with the same id
+func (c *Collection) UpsertObject(obj *model.ReqInsertObject) error {
+	return c.UpsertObjects(&model.ReqInsertObjects{Objects: []model.ReqInsertObject{*obj}})
+}
+
+// UpsertObjects inserts or replaces the objects with a single WAL record in a single transaction,
+// either all of them are written or none
+func (c *Collection) UpsertObjects(req *model.ReqInsertObjects) error {
+	objs := req.Objects
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	entry := WALEntry{
-		Type: WALDelete,
-		ID:   objid,
+	if err := c.checkOpen(); err != nil {
+		return err
 	}
-	walData, err := pkg.Serialize(entry)
-	if err != nil {
-		return fmt.Errorf("failed to serialize WAL entry: %w", err)
+	if err := c.validateObjectMeta(objs); err != nil {
+		return err
 	}
 
-	c.seq++
-	if err := c.wal.Write(c.seq, walData); err != nil {
-		return fmt.Errorf("failed to write to WAL: %w", err)
+	undos := make([]func(), 0, len(objs))
+	if err := c.commit(func(tx *bbolt.Tx) ([]WALEntry, error) {
+		entries := make([]WALEntry, len(objs))
+		for i := range objs {
+			entry, undo, err := c.upsertObject(tx, &objs[i])
+			if err != nil {
+				return nil, fmt.Errorf("%w in object %d", err, i)
+			}
+			undos = append(undos, undo)
+			entries[i] = entry
+		}
+		return entries, nil
+	}); err != nil {
+		// later upserts of the same id must be reverted first
+		for i := len(undos) - 1; i >= 0; i-- {
+			undos[i]()
+		}
+		return fmt.Errorf("failed to upsert objects into collection '%s': %w", c.name, err)
 	}
 
-	if err := db.kv.Update(func(tx *bbolt.Tx) error {
-		colBucket := tx.Bucket([]byte(c.name))
-		objBucket := colBucket.Bucket([]byte(bucketCollectionObjects))
+	return nil
+}
 
-		if err := objBucket.Delete([]byte(objid)); err != nil {
-			return fmt.Errorf("failed to delete object under collection '%s': %w", c.name, err)
-		}
+func (c *Collection) DeleteObject(objid string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-		return nil
-	}); err != nil {
-		return fmt.Errorf("failed to delete object from collection '%s': %w", c.name, err)
+	if err := c.checkOpen(); err != nil {
+		return err
 	}
 
-	if err := c.index.Delete(objid); err != nil {
-		return err
+	var old *model.ReqInsertObject
+	if err := c.commit(func(tx *bbolt.Tx) ([]WALEntry, error) {
+		var err error
+		if old, err = c.getStoredObject(tx, objid); err != nil {
+			return nil, err
+		}
+		if err := c.deleteObject(tx, objid); err != nil {
+			return nil, err
+		}
+		if err := c.index.Delete(objid); err != nil {
+			old = nil // the index is unchanged
+			return nil, err
+		}
+		return []WALEntry{{Type: WALDelete, ID: objid}}, nil
+	}); err != nil {
+		if old != nil {
+			c.index.Insert(objid, old.Vector)
+		}
+		return fmt.Errorf("failed to delete object from collection '%s': %w", c.name, err)
 	}
 
 	return nil
 }
 
+// UpdateObject replaces the metadata and the vector of an existing object
 func (c *Collection) UpdateObject(obj *model.ReqUpdateObject) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	entry := WALEntry{
-		Type:   WALUpdate,
-		ID:     obj.ID,
-		Vector: obj.Vector,
-	}
-	walData, err := pkg.Serialize(entry)
-	if err != nil {
-		return fmt.Errorf("failed to serialize WAL entry: %w", err)
+	if err := c.checkOpen(); err != nil {
+		return err
 	}
 
-	c.seq++
-	if err := c.wal.Write(c.seq, walData); err != nil {
-		return fmt.Errorf("failed to write to WAL: %w", err)
+	updateObjs := []model.ReqInsertObject{{
+		Metadata: obj.Metadata,
+		Vector:   obj.Vector,
+	}}
+	if err := c.validateObjectMeta(updateObjs); err != nil {
+		return err
 	}
+	obj.Metadata = updateObjs[0].Metadata
 
-	if err := db.kv.Update(func(tx *bbolt.Tx) error {
-		colBucket := tx.Bucket([]byte(c.name))
-		objBucket := colBucket.Bucket([]byte(bucketCollectionObjects))
+	var old *model.ReqInsertObject
+	if err := c.commit(func(tx *bbolt.Tx) ([]WALEntry, error) {
+		var err error
+		if old, err = c.getStoredObject(tx, obj.ID); err != nil {
+			return nil, err
+		}
+		if old == nil {
+			return nil, fmt.Errorf("object %s not found", obj.ID)
+		}
 
-		if exist := objBucket.Get([]byte(obj.ID)); exist == nil {
-			return fmt.Errorf("object %s not found", obj.ID)
+		if err := c.updateMetadataIndexes(tx, obj.ID, old.Metadata, obj.Metadata); err != nil {
+			return nil, err
 		}
 
 		objBytes, err := pkg.Serialize(obj)
 		if err != nil {
-			return fmt.Errorf("failed to serialize object: %w", err)
+			return nil, fmt.Errorf("failed to serialize object: %w", err)
 		}
 
+		objBucket := tx.Bucket([]byte(c.name)).Bucket([]byte(bucketCollectionObjects))
 		if err := objBucket.Put([]byte(obj.ID), objBytes); err != nil {
-			return fmt.Errorf("failed to update object under collection '%s': %w", c.name, err)
+			return nil, fmt.Errorf("failed to update object under collection '%s': %w", c.name, err)
 		}
 
-		return nil
+		if err := c.index.Update(obj.ID, obj.Vector); err != nil {
+			old = nil // the index is unchanged
+			return nil, err
+		}
+
+		return []WALEntry{{
+			Type:     WALUpdate,
+			ID:       obj.ID,
+			Vector:   obj.Vector,
+			Metadata: obj.Metadata,
+		}}, nil
 	}); err != nil {
+		if old != nil {
+			c.index.Update(obj.ID, old.Vector)
+		}
 		return fmt.Errorf("failed to update object in collection '%s': %w", c.name, err)
 	}
 
-	if err := c.index.Update(obj.ID, obj.Vector); err != nil {
-		return err
-	}
-
 	return nil
 }
 
@@ -329,9 +532,13 @@ func (c *Collection) GetObjects(offset int, limit int) ([]model.ResObjectInfo, e
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
+
 	objs := []model.ResObjectInfo{}
 
-	if err := db.kv.View(func(tx *bbolt.Tx) error {
+	if err := c.db.kv.View(func(tx *bbolt.Tx) error {
 		colBucket := tx.Bucket([]byte(c.name))
 		objBucket := colBucket.Bucket([]byte(bucketCollectionObjects))
 
@@ -369,13 +576,138 @@ func (c *Collection) GetObjects(offset int, limit int) ([]model.ResObjectInfo, e
 	return objs, nil
 }
 
+// ScrollObjects returns a page of the objects matching the filter in id order, the next_page
+// token of the response continues after its last object. Pages don't shift when objects before
+// them are inserted or deleted in the meantime.
+func (c *Collection) ScrollObjects(req *model.ReqScrollObjects) (model.ResScrollObjects, error) {
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return model.ResScrollObjects{}, err
+	}
+	limit, filter := req.Limit, req.Filter
+	if limit <= 0 {
+		limit = defaultScrollLimit
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if err := c.checkOpen(); err != nil {
+		return model.ResScrollObjects{}, err
+	}
+
+	if filter != nil {
+		if err := validateFilter(filter, c.config.Mapping); err != nil {
+			return model.ResScrollObjects{}, err
+		}
+	}
+
+	res := model.ResScrollObjects{Objects: []model.ResObjectInfo{}}
+	if err := c.db.kv.View(func(tx *bbolt.Tx) error {
+		objBucket := tx.Bucket([]byte(c.name)).Bucket([]byte(bucketCollectionObjects))
+		var last []byte
+
+		// add appends the object if it matches, the candidates of exact filters always do
+		add := func(k, v []byte, exact bool) error {
+			obj := new(model.ReqInsertObject)
+			if err := pkg.Deserialize(v, obj); err != nil {
+				return fmt.Errorf("failed to deserialize object: %w", err)
+			}
+			if filter != nil && !exact && !matchFilter(filter, obj.Metadata) {
+				return nil
+			}
+			res.Objects = append(res.Objects, projectObject(string(k), obj, req))
+			last = k
+			return nil
+		}
+
+		// the metadata indexes are walked in id order from the page token, like the objects
+		if filter != nil {
+			if ids, exact := c.indexedIDs(tx, filter, after); ids != nil {
+				for id, ok := ids.next(); ok; id, ok = ids.next() {
+					if len(res.Objects) == limit {
+						res.NextPage = encodePageToken(last)
+						return nil
+					}
+					if v := objBucket.Get([]byte(id)); v != nil {
+						if err := add([]byte(id), v, exact); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			}
+		}
+
+		cursor := objBucket.Cursor()
+		k, v := cursor.Seek([]byte(after))
+		if k != nil && string(k) == after {
+			k, v = cursor.Next()
+		}
+		for ; k != nil; k, v = cursor.Next() {
+			if len(res.Objects) == limit {
+				res.NextPage = encodePageToken(last)
+				return nil
+			}
+			if err := add(k, v, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return model.ResScrollObjects{}, fmt.Errorf("failed to scroll objects of collection '%s': %w", c.name, err)
+	}
+
+	return res, nil
+}
+
+// projectObject leaves out the parts of the object the scroll request doesn't ask for
+func projectObject(id string, obj *model.ReqInsertObject, req *model.ReqScrollObjects) model.ResObjectInfo {
+	res := model.ResObjectInfo{ID: id}
+	if req.WithVector {
+		res.Vector = obj.Vector
+	}
+	if !req.WithMetadata {
+		return res
+	}
+	if len(req.Fields) == 0 {
+		res.Metadata = obj.Metadata
+		return res
+	}
+
+	res.Metadata = make(map[string]interface{}, len(req.Fields))
+	for _, field := range req.Fields {
+		if value, ok := obj.Metadata[field]; ok {
+			res.Metadata[field] = value
+		}
+	}
+	return res
+}
+
+// page tokens are the last id of a page, encoded so that clients don't depend on it
+func encodePageToken(id []byte) string {
+	return base64.RawURLEncoding.EncodeToString(id)
+}
+
+func decodePageToken(token string) (string, error) {
+	id, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(id) > maxObjectIDLength {
+		return "", fmt.Errorf("invalid page token '%s'", token)
+	}
+	return string(id), nil
+}
+
 func (c *Collection) GetObjectInfo(objid string) (model.ResObjectInfo, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	if err := c.checkOpen(); err != nil {
+		return model.ResObjectInfo{}, err
+	}
+
 	res := model.ResObjectInfo{}
 
-	if err := db.kv.View(func(tx *bbolt.Tx) error {
+	if err := c.db.kv.View(func(tx *bbolt.Tx) error {
 		colBucket := tx.Bucket([]byte(c.name))
 		objBucket := colBucket.Bucket([]byte(bucketCollectionObjects))
 
@@ -403,30 +735,216 @@ func (c *Collection) GetObjectInfo(objid string) (model.ResObjectInfo, error) {
 	return res, nil
 }
 
-func (c *Collection) Search(vector []float32, topk int, xparams map[string]interface{}) ([]model.ResSearchObject, error) {
+// CountObjects returns the number of objects matching the filter, or of all objects without a filter
+func (c *Collection) CountObjects(req *model.ReqCountObjects) (model.ResCountObjects, error) {
+	filter := req.Filter
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if err := c.checkOpen(); err != nil {
+		return model.ResCountObjects{}, err
+	}
+
+	if filter != nil {
+		if err := validateFilter(filter, c.config.Mapping); err != nil {
+			return model.ResCountObjects{}, err
+		}
+	}
+
+	cnt := 0
+	if err := c.db.kv.View(func(tx *bbolt.Tx) error {
+		colBucket := tx.Bucket([]byte(c.name))
+		objBucket := colBucket.Bucket([]byte(bucketCollectionObjects))
+
+		if filter == nil {
+			cnt = objBucket.Stats().KeyN
+			return nil
+		}
+
+		ids, exact := c.indexedCandidates(tx, filter)
+		if exact {
+			cnt = len(ids)
+			return nil
+		}
+
+		match := func(v []byte) error {
+			obj := new(model.ReqInsertObject)
+			if err := pkg.Deserialize(v, obj); err != nil {
+				return fmt.Errorf("failed to deserialize object: %w", err)
+			}
+			if matchFilter(filter, obj.Metadata) {
+				cnt++
+			}
+			return nil
+		}
+
+		// check the narrowed down candidates, or every object if the indexes don't help
+		if ids != nil {
+			for id := range ids {
+				if v := objBucket.Get([]byte(id)); v != nil {
+					if err := match(v); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+		return objBucket.ForEach(func(_, v []byte) error {
+			return match(v)
+		})
+	}); err != nil {
+		return model.ResCountObjects{}, fmt.Errorf("failed to count objects in collection '%s': %w", c.name, err)
+	}
+
+	return model.ResCountObjects{Count: cnt}, nil
+}
+
+// SearchObjects returns the topk objects nearest to the vector that match the filter
+func (c *Collection) SearchObjects(req *model.ReqSearchObject) ([]model.ResSearchObject, error) {
+	vector, topk, xparams, filter := req.Vector, req.TopK, req.XParams, req.Filter
+
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	results, err := c.index.Search(vector, topk, xparams)
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
+
+	if filter != nil {
+		if err := validateFilter(filter, c.config.Mapping); err != nil {
+			return nil, err
+		}
+	}
+
+	reranker, ok := c.index.(index.Reranker)
+	rerank, candidates, err := rerankParams(topk, xparams, ok && reranker.RequiresRerank())
 	if err != nil {
 		return nil, err
 	}
 
 	res := []model.ResSearchObject{}
 
-	for _, result := range results {
-		obj, err := c.GetObjectInfo(result.ID)
+	if err := c.db.kv.View(func(tx *bbolt.Tx) error {
+		colBucket := tx.Bucket([]byte(c.name))
+		objBucket := colBucket.Bucket([]byte(bucketCollectionObjects))
+
+		getObject := func(id string) (*model.ReqInsertObject, error) {
+			objBytes := objBucket.Get([]byte(id))
+			if objBytes == nil {
+				return nil, fmt.Errorf("object %s not found", id)
+			}
+
+			obj := new(model.ReqInsertObject)
+			if err := pkg.Deserialize(objBytes, obj); err != nil {
+				return nil, fmt.Errorf("failed to deserialize object: %w", err)
+			}
+			return obj, nil
+		}
+
+		var results []model.SearchResult
+		if filter == nil {
+			results, err = c.index.Search(vector, candidates, xparams)
+		} else {
+			filterer, ok := c.index.(index.Filterer)
+			if !ok {
+				return fmt.Errorf("filter is not supported by index type '%s'", c.config.IndexType)
+			}
+
+			// the index asks for every candidate while traversing, the metadata indexes answer
+			// without reading the object if they resolve the whole filter
+			ids, exact := c.indexedCandidates(tx, filter)
+			var ferr error
+			results, err = filterer.SearchWithFilter(vector, candidates, xparams, func(id string) bool {
+				if ids != nil {
+					if _, ok := ids[id]; !ok || exact {
+						return ok
+					}
+				}
+				obj, err := getObject(id)
+				if err != nil {
+					ferr = err
+					return false
+				}
+				return matchFilter(filter, obj.Metadata)
+			})
+			if err == nil {
+				err = ferr
+			}
+		}
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		res = append(res, model.ResSearchObject{
-			ID:       obj.ID,
-			Metadata: obj.Metadata,
-			Vector:   obj.Vector,
-			Score:    result.Score,
+		for _, result := range results {
+			obj, err := getObject(result.ID)
+			if err != nil {
+				return err
+			}
+
+			// rescore against the full precision vector kept in the object bucket
+			score := result.Score
+			if rerank {
+				score = c.distfunc(vector, obj.Vector)
+			}
+
+			res = append(res, model.ResSearchObject{
+				ID:       result.ID,
+				Metadata: obj.Metadata,
+				Vector:   obj.Vector,
+				Score:    score,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get search results from collection '%s': %w", c.name, err)
+	}
+
+	if rerank {
+		sort.SliceStable(res, func(i, j int) bool {
+			return res[i].Score < res[j].Score
 		})
+		if len(res) > topk {
+			res = res[:topk]
+		}
 	}
 
 	return res, nil
 }
+
+// rerankParams reads the rerank x_params, when reranking is enabled the index is asked
+// for topk * oversampling candidates which are rescored with the exact distance
+func rerankParams(topk int, xparams map[string]interface{}, required bool) (bool, int, error) {
+	rerank := required
+	if value, exists := xparams["rerank"]; exists {
+		v, ok := value.(bool)
+		if !ok {
+			return false, 0, fmt.Errorf("rerank parameter must be a boolean")
+		}
+		if required && !v {
+			return false, 0, fmt.Errorf("rerank can't be disabled for this index type")
+		}
+		rerank = v
+	}
+	if !rerank {
+		return false, topk, nil
+	}
+
+	oversampling := defaultOversampling
+	if value, exists := xparams["oversampling"]; exists {
+		switch v := value.(type) {
+		case float64:
+			oversampling = v
+		case int:
+			oversampling = float64(v)
+		default:
+			return false, 0, fmt.Errorf("oversampling parameter must be a number")
+		}
+	}
+	if oversampling < 1 {
+		return false, 0, fmt.Errorf("oversampling parameter must be at least 1")
+	}
+
+	return true, int(math.Ceil(float64(topk) * oversampling)), nil
+}
@@ -0,0 +1,273 @@
+package db
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func openDB(t *testing.T, path string, policy CheckpointPolicy) *DB {
+	db, err := Open(path, policy)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return db
+}
+
+func collection(t *testing.T, db *DB, colname string) *Collection {
+	col, err := db.Collection(colname)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return col
+}
+
+func TestUpsertObjects(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+	defer func() { db.Close() }()
+
+	assert.NoError(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "test", Dimension: 2, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 100.0},
+		Distance: "euclidean", Mapping: []string{"category"}, MetadataIndexes: map[string]string{"category": "keyword"},
+	}))
+	count := func(category string) int {
+		res, err := collection(t, db, "test").CountObjects(&model.ReqCountObjects{Filter: &model.Filter{Field: "category", Op: "eq", Value: category}})
+		assert.NoError(t, err)
+		return res.Count
+	}
+	nearest := func(vector []float32) string {
+		results, err := collection(t, db, "test").SearchObjects(&model.ReqSearchObject{Vector: vector, TopK: 1})
+		assert.NoError(t, err)
+		return results[0].ID
+	}
+
+	// client supplied ids
+	id, err := collection(t, db, "test").InsertObject(&model.ReqInsertObject{
+		ID: "doc-1", Metadata: map[string]interface{}{"category": "a"}, Vector: []float32{1, 0},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "doc-1", id)
+	_, err = collection(t, db, "test").InsertObject(&model.ReqInsertObject{
+		ID: "doc-1", Metadata: map[string]interface{}{"category": "b"}, Vector: []float32{0, 1},
+	})
+	assert.Error(t, err)
+	_, err = collection(t, db, "test").InsertObject(&model.ReqInsertObject{
+		ID: string(make([]byte, 256)), Metadata: map[string]interface{}{"category": "b"}, Vector: []float32{0, 1},
+	})
+	assert.Error(t, err)
+	generated, err := collection(t, db, "test").InsertObject(&model.ReqInsertObject{
+		Metadata: map[string]interface{}{"category": "a"}, Vector: []float32{5, 5},
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, generated)
+
+	// upsert replaces the object, its metadata index entries and its vector
+	assert.NoError(t, collection(t, db, "test").UpsertObject(&model.ReqInsertObject{
+		ID: "doc-1", Metadata: map[string]interface{}{"category": "b"}, Vector: []float32{-1, 0},
+	}))
+	assert.Equal(t, 1, count("a"))
+	assert.Equal(t, 1, count("b"))
+	assert.Equal(t, "doc-1", nearest([]float32{-1, 0}))
+	assert.Error(t, collection(t, db, "test").UpsertObject(&model.ReqInsertObject{
+		Metadata: map[string]interface{}{"category": "b"}, Vector: []float32{0, 1},
+	}))
+
+	// batch upserts insert new objects and replace existing ones, repeated ids apply in order
+	assert.NoError(t, collection(t, db, "test").UpsertObjects(&model.ReqInsertObjects{Objects: []model.ReqInsertObject{
+		{ID: "doc-2", Metadata: map[string]interface{}{"category": "c"}, Vector: []float32{0, 2}},
+		{ID: "doc-1", Metadata: map[string]interface{}{"category": "c"}, Vector: []float32{0, -2}},
+		{ID: "doc-2", Metadata: map[string]interface{}{"category": "d"}, Vector: []float32{2, 2}},
+	}}))
+	assert.Equal(t, 0, count("b"))
+	assert.Equal(t, 1, count("c"))
+	assert.Equal(t, 1, count("d"))
+	assert.Equal(t, "doc-2", nearest([]float32{2, 2}))
+	assert.Equal(t, "doc-1", nearest([]float32{0, -2}))
+
+	// a failing batch leaves no trace
+	assert.Error(t, collection(t, db, "test").UpsertObjects(&model.ReqInsertObjects{Objects: []model.ReqInsertObject{
+		{ID: "doc-3", Metadata: map[string]interface{}{"category": "e"}, Vector: []float32{9, 9}},
+		{ID: "doc-1", Metadata: map[string]interface{}{"category": "e"}, Vector: []float32{-9, -9}},
+		{Metadata: map[string]interface{}{"category": "e"}, Vector: []float32{0, 0}},
+	}}))
+	assert.Equal(t, 0, count("e"))
+	_, err = collection(t, db, "test").GetObjectInfo("doc-3")
+	assert.Error(t, err)
+	assert.Equal(t, generated, nearest([]float32{9, 9}))
+	assert.Equal(t, "doc-1", nearest([]float32{0, -2}))
+
+	obj, err := collection(t, db, "test").GetObjectInfo("doc-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{0, -2}, obj.Vector)
+
+	// upserts survive a restart
+	db.Close()
+	db = openDB(t, dir, CheckpointPolicy{})
+	assert.Equal(t, "doc-1", nearest([]float32{0, -2}))
+	assert.Equal(t, "doc-2", nearest([]float32{2, 2}))
+}
+
+func TestInsertObjectsAtomic(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+	defer func() { db.Close() }()
+
+	assert.NoError(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "test", Dimension: 2, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 5.0},
+		Distance: "euclidean", Mapping: []string{"category"}, MetadataIndexes: map[string]string{"category": "keyword"},
+	}))
+	batch := func(n int, id string) *model.ReqInsertObjects {
+		objs := &model.ReqInsertObjects{}
+		for i := 0; i < n; i++ {
+			objs.Objects = append(objs.Objects, model.ReqInsertObject{
+				Metadata: map[string]interface{}{"category": "a"}, Vector: []float32{float32(i), 0},
+			})
+		}
+		objs.Objects[n-1].ID = id
+		return objs
+	}
+	count := func() int {
+		res, err := collection(t, db, "test").CountObjects(&model.ReqCountObjects{})
+		assert.NoError(t, err)
+		indexed, err := collection(t, db, "test").CountObjects(&model.ReqCountObjects{Filter: &model.Filter{Field: "category", Op: "eq", Value: "a"}})
+		assert.NoError(t, err)
+		assert.Equal(t, res.Count, indexed.Count)
+		return res.Count
+	}
+
+	// a duplicate id in the batch aborts the transaction
+	_, err := collection(t, db, "test").InsertObjects(batch(3, "doc-1"))
+	assert.NoError(t, err)
+	_, err = collection(t, db, "test").InsertObjects(batch(2, "doc-1"))
+	assert.Error(t, err)
+	assert.Equal(t, 3, count())
+
+	// the index is full after two more objects, the committed batch is taken back
+	_, err = collection(t, db, "test").InsertObjects(batch(3, "doc-2"))
+	assert.Error(t, err)
+	assert.Equal(t, 3, count())
+	_, err = collection(t, db, "test").GetObjectInfo("doc-2")
+	assert.Error(t, err)
+
+	ids, err := collection(t, db, "test").InsertObjects(batch(2, "doc-2"))
+	assert.NoError(t, err)
+	assert.Len(t, ids, 2)
+	assert.Equal(t, "doc-2", ids[1])
+	assert.Equal(t, 5, count())
+
+	// replaying the batch records without a snapshot rebuilds the same index
+	db.Close()
+	assert.NoError(t, os.Remove(filepath.Join(dir, "test.snapshot")))
+	db = openDB(t, dir, CheckpointPolicy{})
+	results, err := collection(t, db, "test").SearchObjects(&model.ReqSearchObject{Vector: []float32{0, 0}, TopK: 10})
+	assert.NoError(t, err)
+	assert.Len(t, results, 5)
+	assert.Equal(t, 5, count())
+}
+
+func TestScrollObjects(t *testing.T) {
+	db := openDB(t, t.TempDir(), CheckpointPolicy{})
+	defer db.Close()
+
+	assert.NoError(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "test", Dimension: 2, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 100.0},
+		Distance: "euclidean", Mapping: []string{"category", "label"}, MetadataIndexes: map[string]string{"category": "keyword"},
+	}))
+	col := collection(t, db, "test")
+	insert := func(i int) {
+		_, err := col.InsertObject(&model.ReqInsertObject{
+			ID: fmt.Sprintf("%02d", i), Metadata: map[string]interface{}{"category": fmt.Sprint(i % 2), "label": fmt.Sprint(i % 3)}, Vector: []float32{float32(i), 0},
+		})
+		assert.NoError(t, err)
+	}
+	for i := 0; i < 20; i += 2 {
+		insert(i)
+		insert(i + 1)
+	}
+	scroll := func(req model.ReqScrollObjects, between func()) []string {
+		ids := []string{}
+		for pages := 0; pages < 30; pages++ {
+			res, err := col.ScrollObjects(&req)
+			if !assert.NoError(t, err) {
+				break
+			}
+			for _, obj := range res.Objects {
+				ids = append(ids, obj.ID)
+			}
+			if res.NextPage == "" {
+				break
+			}
+			req.PageToken = res.NextPage
+			if between != nil {
+				between()
+			}
+		}
+		return ids
+	}
+
+	// pages don't shift while objects before them are deleted, objects after them show up
+	deleted, added := 0, 40
+	ids := scroll(model.ReqScrollObjects{Limit: 3}, func() {
+		assert.NoError(t, col.DeleteObject(fmt.Sprintf("%02d", deleted)))
+		deleted++
+		insert(added)
+		added++
+	})
+	assert.Equal(t, 9, deleted)
+	assert.Len(t, ids, 20+(added-40))
+	assert.Equal(t, []string{"00", "01", "02", "03", "04"}, ids[:5])
+	for i := 1; i < len(ids); i++ {
+		assert.Less(t, ids[i-1], ids[i])
+	}
+
+	// the indexed category is answered by the metadata index, the label by a scan
+	res, err := col.CountObjects(&model.ReqCountObjects{Filter: &model.Filter{Field: "category", Op: "eq", Value: "1"}})
+	assert.NoError(t, err)
+	ids = scroll(model.ReqScrollObjects{Limit: 4, Filter: &model.Filter{Field: "category", Op: "eq", Value: "1"}}, nil)
+	assert.Len(t, ids, res.Count)
+	assert.Equal(t, "09", ids[0])
+	filter := &model.Filter{And: []model.Filter{
+		{Field: "category", Op: "eq", Value: "1"},
+		{Field: "label", Op: "eq", Value: "0"},
+	}}
+	res, err = col.CountObjects(&model.ReqCountObjects{Filter: filter})
+	assert.NoError(t, err)
+	assert.Equal(t, res.Count, len(scroll(model.ReqScrollObjects{Limit: 2, Filter: filter}, nil)))
+	filter = &model.Filter{Field: "label", Op: "ne", Value: "0"}
+	res, err = col.CountObjects(&model.ReqCountObjects{Filter: filter})
+	assert.NoError(t, err)
+	assert.Equal(t, res.Count, len(scroll(model.ReqScrollObjects{Limit: 5, Filter: filter}, nil)))
+
+	// in and or filters merge the ids of every value in id order, range filters are scanned
+	for _, filter := range []*model.Filter{
+		{Field: "category", Op: "in", Values: []interface{}{"0", "1", "2"}},
+		{Or: []model.Filter{{Field: "category", Op: "eq", Value: "0"}, {Field: "category", Op: "in", Values: []interface{}{"1", "0"}}}},
+		{Field: "category", Op: "range", Gte: "1"},
+	} {
+		res, err = col.CountObjects(&model.ReqCountObjects{Filter: filter})
+		assert.NoError(t, err)
+		ids = scroll(model.ReqScrollObjects{Limit: 3, Filter: filter}, nil)
+		assert.Len(t, ids, res.Count)
+		assert.True(t, slices.IsSorted(ids))
+		assert.Len(t, slices.Compact(ids), res.Count)
+	}
+
+	// projections
+	page, err := col.ScrollObjects(&model.ReqScrollObjects{Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, model.ResObjectInfo{ID: "09"}, page.Objects[0])
+	page, err = col.ScrollObjects(&model.ReqScrollObjects{Limit: 1, WithVector: true, WithMetadata: true, Fields: []string{"label", "missing"}})
+	assert.NoError(t, err)
+	assert.Equal(t, model.ResObjectInfo{ID: "09", Metadata: map[string]interface{}{"label": "0"}, Vector: []float32{9, 0}}, page.Objects[0])
+
+	_, err = col.ScrollObjects(&model.ReqScrollObjects{PageToken: "%"})
+	assert.ErrorContains(t, err, "invalid page token")
+	_, err = col.ScrollObjects(&model.ReqScrollObjects{Filter: &model.Filter{Field: "price", Op: "eq", Value: 1}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,509 @@
+package db
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	"vectordb/model"
+	"vectordb/pkg"
+
+	"go.etcd.io/bbolt"
+)
+
+// Collection snapshot layout, a gzipped tar holding:
+//
+//	manifest.json       colSnapshotManifest
+//	config.gob          collection config from collections_metadata
+//	objects             object bucket as uvarint length prefixed key and value pairs
+//	index.snapshot      index snapshot at the WAL sequence of the objects
+//	index.data          data file of on-disk indexes
+//
+// Nothing in it depends on the collection name, so it can be restored under any name. The
+// metadata indexes are rebuilt from the objects on restore.
+const (
+	colSnapshotVersion      = 1
+	colSnapshotManifestName = "manifest.json"
+	colSnapshotConfigName   = "config.gob"
+	colSnapshotObjectsName  = "objects"
+	colSnapshotIndexName    = "index.snapshot"
+	colSnapshotDataName     = "index.data"
+)
+
+// key of the collection bucket committed with the restored objects, until the staged index files
+// replace the files of the collection. Its value is 1 if an index data file was staged
+const restorePendingKey = "restore_pending"
+
+// suffix of the staged index files put next to the files of the collection they replace
+const restoreSuffix = ".restore"
+
+type colSnapshotManifest struct {
+	Version   int       `json:"version"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	Seq       uint64    `json:"seq"` // WAL sequence the objects and the index are taken at
+}
+
+// SnapshotCollection writes a portable snapshot of a collection to w, its writers are only
+// blocked until the read transaction is opened and the index is snapshotted
+func (db *DB) SnapshotCollection(colname string, w io.Writer) error {
+	if err := db.snapshotCollection(colname, w); err != nil {
+		return fmt.Errorf("failed to snapshot collection '%s': %w", colname, err)
+	}
+
+	return nil
+}
+
+func (db *DB) snapshotCollection(colname string, w io.Writer) error {
+	tx, bc, cfg, err := db.colSnapshotFence(colname)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+		closeBackupFiles([]*backupCollection{bc})
+	}()
+
+	manifestBytes, err := json.Marshal(colSnapshotManifest{
+		Version:   colSnapshotVersion,
+		Name:      colname,
+		CreatedAt: time.Now().UTC(),
+		Seq:       bc.seq,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot manifest: %w", err)
+	}
+	cfgBytes, err := pkg.Serialize(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to serialize collection config: %w", err)
+	}
+
+	objBucket := tx.Bucket([]byte(colname)).Bucket([]byte(bucketCollectionObjects))
+	objectsSize := int64(0)
+	if err := objBucket.ForEach(func(k, v []byte) error {
+		objectsSize += int64(uvarintLen(len(k)) + len(k) + uvarintLen(len(v)) + len(v))
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to size object bucket: %w", err)
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	if err := writeTarEntry(tw, colSnapshotManifestName, int64(len(manifestBytes)), func(w io.Writer) error {
+		_, err := w.Write(manifestBytes)
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := writeTarEntry(tw, colSnapshotConfigName, int64(len(cfgBytes)), func(w io.Writer) error {
+		_, err := w.Write(cfgBytes)
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := writeTarEntry(tw, colSnapshotObjectsName, objectsSize, func(w io.Writer) error {
+		bw := bufio.NewWriter(w)
+		if err := objBucket.ForEach(func(k, v []byte) error {
+			return writeRecord(bw, k, v)
+		}); err != nil {
+			return err
+		}
+		return bw.Flush()
+	}); err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, colSnapshotIndexName, bc.snapshot); err != nil {
+		return err
+	}
+	if bc.index != nil {
+		if err := writeTarFile(tw, colSnapshotDataName, bc.index); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to close collection snapshot: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("failed to close collection snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// colSnapshotFence opens the read transaction and snapshots the index while the writers of the
+// collection are blocked, like backupFence for a single collection
+func (db *DB) colSnapshotFence(colname string) (*bbolt.Tx, *backupCollection, model.CfgCollection, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	col, ok := db.collections[colname]
+	if !ok {
+		return nil, nil, model.CfgCollection{}, fmt.Errorf("collection '%s' not found", colname)
+	}
+
+	col.ckmu.Lock()
+	defer col.ckmu.Unlock()
+	col.mu.RLock()
+	defer col.mu.RUnlock()
+
+	tx, err := db.kv.Begin(false)
+	if err != nil {
+		return nil, nil, model.CfgCollection{}, fmt.Errorf("failed to begin read transaction: %w", err)
+	}
+	bc, err := col.backupFiles()
+	if err != nil {
+		tx.Rollback()
+		return nil, nil, model.CfgCollection{}, fmt.Errorf("failed to snapshot collection '%s': %w", colname, err)
+	}
+
+	return tx, bc, col.config, nil
+}
+
+// RestoreCollection creates the collection colname from a collection snapshot, an existing
+// collection is only replaced if overwrite is set. The snapshot is staged next to the data
+// files first, the collection is swapped in a single bbolt transaction and the staged index
+// files are moved into place by newCollection, again after a crash
+func (db *DB) RestoreCollection(colname string, r io.Reader, req *model.ReqRestoreCollection) error {
+	if err := validateCollectionName(colname); err != nil {
+		return err
+	}
+	staged, err := stageColSnapshot(r, db.path)
+	if err != nil {
+		return fmt.Errorf("failed to read collection snapshot: %w", err)
+	}
+	defer staged.remove()
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	old, exists := db.collections[colname]
+	if exists && !req.Overwrite {
+		return fmt.Errorf("collection '%s' already exists", colname)
+	}
+	if err := staged.place(db, colname); err != nil {
+		return fmt.Errorf("failed to stage collection '%s': %w", colname, err)
+	}
+
+	// writers of the old collection fail from now on, its WAL is closed
+	if exists {
+		if err := old.Close(); err != nil {
+			return fmt.Errorf("failed to close collection '%s': %w", colname, err)
+		}
+	}
+
+	col := &Collection{db: db, name: colname, config: staged.config}
+	if err := db.kv.Update(func(tx *bbolt.Tx) error {
+		return col.restoreObjects(tx, staged.objects, exists, staged.data != "")
+	}); err != nil {
+		if exists {
+			// the old collection is still intact in bbolt
+			if reopened, rerr := db.newCollection(colname, &old.config); rerr == nil {
+				db.collections[colname] = reopened
+			} else {
+				db.collections[colname] = &Collection{db: db, name: colname, config: old.config, closed: true}
+				return fmt.Errorf("failed to restore collection '%s': %w, failed to reopen it: %v", colname, err, rerr)
+			}
+		}
+		return fmt.Errorf("failed to restore collection '%s': %w", colname, err)
+	}
+	// the staged index files belong to the committed collection now, even if they can't be moved
+	staged.snapshot, staged.data = "", ""
+
+	restored, err := db.newCollection(colname, &staged.config)
+	if err != nil {
+		// the collection exists in bbolt, a closed handle lets it be deleted or restored again
+		db.collections[colname] = &Collection{db: db, name: colname, config: staged.config, closed: true}
+		return fmt.Errorf("failed to open restored collection '%s': %w", colname, err)
+	}
+	db.collections[colname] = restored
+
+	return nil
+}
+
+// finishRestore replaces the WAL and the index files of a restored collection with the staged
+// index files. It is a no-op unless the restored objects are committed, and can be repeated
+// until it completes
+func (db *DB) finishRestore(colname string) error {
+	var pending []byte
+	if err := db.kv.View(func(tx *bbolt.Tx) error {
+		if colBucket := tx.Bucket([]byte(colname)); colBucket != nil {
+			pending = colBucket.Get([]byte(restorePendingKey))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if pending == nil {
+		return nil
+	}
+
+	if err := os.RemoveAll(db.walPath(colname)); err != nil {
+		return fmt.Errorf("failed to delete WAL directory: %w", err)
+	}
+	// the snapshot is moved last, once it's gone the index file is in place too
+	snapshot := db.snapshotPath(colname)
+	if _, err := os.Stat(snapshot + restoreSuffix); err == nil {
+		data := db.indexPath(colname)
+		if pending[0] == 1 {
+			err = os.Rename(data+restoreSuffix, data)
+		} else {
+			err = os.Remove(data)
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to move index file: %w", err)
+		}
+		if err := os.Rename(snapshot+restoreSuffix, snapshot); err != nil {
+			return fmt.Errorf("failed to move index snapshot: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to stat staged index snapshot: %w", err)
+	}
+
+	return db.kv.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte(colname)).Delete([]byte(restorePendingKey))
+	})
+}
+
+// restoreObjects replaces the buckets of the collection with the staged objects and rebuilds the
+// metadata indexes, the WAL sequence starts over with the new WAL. hasData tells finishRestore
+// whether an index data file was staged
+func (c *Collection) restoreObjects(tx *bbolt.Tx, objects string, replace bool, hasData bool) error {
+	metaBucket := tx.Bucket([]byte(bucketCollectionsMetadata))
+	if replace {
+		if err := tx.DeleteBucket([]byte(c.name)); err != nil && !errors.Is(err, bbolt.ErrBucketNotFound) {
+			return fmt.Errorf("failed to delete collection bucket: %w", err)
+		}
+	}
+
+	cfgBytes, err := pkg.Serialize(c.config)
+	if err != nil {
+		return fmt.Errorf("failed to serialize collection: %w", err)
+	}
+	if err := metaBucket.Put([]byte(c.name), cfgBytes); err != nil {
+		return fmt.Errorf("failed to put collection metadata: %w", err)
+	}
+
+	colBucket, err := tx.CreateBucket([]byte(c.name))
+	if err != nil {
+		return fmt.Errorf("failed to create collection bucket: %w", err)
+	}
+	objBucket, err := colBucket.CreateBucket([]byte(bucketCollectionObjects))
+	if err != nil {
+		return fmt.Errorf("failed to create object bucket under collection '%s': %w", c.name, err)
+	}
+	if err := putWALSeq(tx, c.name, 0); err != nil {
+		return err
+	}
+	pending := []byte{0}
+	if hasData {
+		pending[0] = 1
+	}
+	if err := colBucket.Put([]byte(restorePendingKey), pending); err != nil {
+		return fmt.Errorf("failed to put pending restore: %w", err)
+	}
+	if len(c.config.MetadataIndexes) > 0 {
+		idxBucket, err := colBucket.CreateBucket([]byte(bucketCollectionIndexes))
+		if err != nil {
+			return fmt.Errorf("failed to create index bucket under collection '%s': %w", c.name, err)
+		}
+		for field := range c.config.MetadataIndexes {
+			if _, err := idxBucket.CreateBucket([]byte(field)); err != nil {
+				return fmt.Errorf("failed to create index bucket of field '%s': %w", field, err)
+			}
+		}
+	}
+
+	f, err := os.Open(objects)
+	if err != nil {
+		return fmt.Errorf("failed to open staged objects: %w", err)
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	for {
+		k, v, err := readRecord(br)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read staged objects: %w", err)
+		}
+
+		obj := new(model.ReqInsertObject)
+		if err := pkg.Deserialize(v, obj); err != nil {
+			return fmt.Errorf("failed to deserialize object %s: %w", k, err)
+		}
+		if err := objBucket.Put(k, v); err != nil {
+			return fmt.Errorf("failed to put object under collection '%s': %w", c.name, err)
+		}
+		if err := c.updateMetadataIndexes(tx, string(k), nil, obj.Metadata); err != nil {
+			return err
+		}
+	}
+}
+
+// stagedColSnapshot holds the files of a collection snapshot extracted to the data directory
+type stagedColSnapshot struct {
+	config   model.CfgCollection
+	objects  string
+	snapshot string
+	data     string // empty for in-memory indexes
+}
+
+func stageColSnapshot(r io.Reader, dir string) (*stagedColSnapshot, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gr)
+
+	staged := &stagedColSnapshot{}
+	var manifest *colSnapshotManifest
+	hasConfig := false
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			staged.remove()
+			return nil, err
+		}
+
+		name := header.Name
+		if manifest == nil {
+			if name != colSnapshotManifestName {
+				return nil, fmt.Errorf("collection snapshot doesn't start with '%s'", colSnapshotManifestName)
+			}
+			manifest = new(colSnapshotManifest)
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("failed to decode snapshot manifest: %w", err)
+			}
+			if manifest.Version != colSnapshotVersion {
+				return nil, fmt.Errorf("unsupported collection snapshot version %d", manifest.Version)
+			}
+			continue
+		}
+
+		var dst *string
+		var src io.Reader = tr
+		switch name {
+		case colSnapshotConfigName:
+			if hasConfig {
+				staged.remove()
+				return nil, fmt.Errorf("unexpected file '%s' in collection snapshot", name)
+			}
+			cfgBytes, err := io.ReadAll(tr)
+			if err == nil {
+				err = pkg.Deserialize(cfgBytes, &staged.config)
+			}
+			if err != nil {
+				staged.remove()
+				return nil, fmt.Errorf("failed to decode collection config: %w", err)
+			}
+			hasConfig = true
+			continue
+		case colSnapshotObjectsName:
+			dst = &staged.objects
+		case colSnapshotIndexName:
+			dst = &staged.snapshot
+			if src, err = resetSnapshotSeq(tr); err != nil {
+				staged.remove()
+				return nil, err
+			}
+		case colSnapshotDataName:
+			dst = &staged.data
+		}
+		if dst == nil || *dst != "" {
+			staged.remove()
+			return nil, fmt.Errorf("unexpected file '%s' in collection snapshot", name)
+		}
+
+		f, err := os.CreateTemp(dir, "restore-"+name+"-*")
+		if err != nil {
+			staged.remove()
+			return nil, err
+		}
+		*dst = f.Name()
+		if err := copyAndSync(f, src); err != nil {
+			staged.remove()
+			return nil, fmt.Errorf("failed to stage '%s': %w", name, err)
+		}
+	}
+
+	if manifest == nil || !hasConfig || staged.objects == "" || staged.snapshot == "" {
+		staged.remove()
+		return nil, fmt.Errorf("incomplete collection snapshot")
+	}
+
+	return staged, nil
+}
+
+// place renames the staged index files next to the files of the collection they replace
+func (s *stagedColSnapshot) place(db *DB, colname string) error {
+	snapshot := db.snapshotPath(colname) + restoreSuffix
+	if err := os.Rename(s.snapshot, snapshot); err != nil {
+		return err
+	}
+	s.snapshot = snapshot
+	if s.data != "" {
+		data := db.indexPath(colname) + restoreSuffix
+		if err := os.Rename(s.data, data); err != nil {
+			return err
+		}
+		s.data = data
+	}
+	return nil
+}
+
+// remove deletes the staged files that weren't moved into place
+func (s *stagedColSnapshot) remove() {
+	for _, path := range []string{s.objects, s.snapshot, s.data} {
+		if path != "" {
+			os.Remove(path)
+		}
+	}
+}
+
+func uvarintLen(n int) int {
+	var buf [binary.MaxVarintLen64]byte
+	return binary.PutUvarint(buf[:], uint64(n))
+}
+
+func writeRecord(w io.Writer, k, v []byte) error {
+	buf := binary.AppendUvarint(nil, uint64(len(k)))
+	buf = append(buf, k...)
+	buf = binary.AppendUvarint(buf, uint64(len(v)))
+	buf = append(buf, v...)
+	_, err := w.Write(buf)
+	return err
+}
+
+// readRecord returns io.EOF at the end of the records
+func readRecord(r *bufio.Reader) ([]byte, []byte, error) {
+	klen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	k := make([]byte, klen)
+	if _, err := io.ReadFull(r, k); err != nil {
+		return nil, nil, io.ErrUnexpectedEOF
+	}
+	vlen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, nil, io.ErrUnexpectedEOF
+	}
+	v := make([]byte, vlen)
+	if _, err := io.ReadFull(r, v); err != nil {
+		return nil, nil, io.ErrUnexpectedEOF
+	}
+	return k, v, nil
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func TestSnapshotRestoreCollection(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+
+	createWALTestCollection(t, db)
+	insertWALTestObject(t, db, "a", "x", []float32{1, 0})
+	insertWALTestObject(t, db, "b", "y", []float32{2, 0})
+	insertWALTestObject(t, db, "c", "x", []float32{3, 0})
+
+	snapshot := new(bytes.Buffer)
+	assert.NoError(t, db.SnapshotCollection("test", snapshot))
+	assert.Error(t, db.SnapshotCollection("missing", new(bytes.Buffer)))
+
+	insertWALTestObject(t, db, "d", "y", []float32{4, 0})
+	assert.NoError(t, collection(t, db, "test").DeleteObject("a"))
+
+	// clone under a new name, the original is untouched
+	assert.NoError(t, db.RestoreCollection("staging", bytes.NewReader(snapshot.Bytes()), &model.ReqRestoreCollection{}))
+	info, err := db.GetCollectionInfo("staging")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, info.ObjectCount)
+	assert.Equal(t, map[string]string{"category": "keyword"}, info.MetadataIndexes)
+	res, err := collection(t, db, "staging").CountObjects(&model.ReqCountObjects{Filter: &model.Filter{Field: "category", Op: "eq", Value: "x"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.Count)
+	results, err := collection(t, db, "staging").SearchObjects(&model.ReqSearchObject{Vector: []float32{1, 0}, TopK: 1})
+	assert.NoError(t, err)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "a", results[0].ID)
+	}
+	assertConsistent(t, db, map[string][]float32{"b": {2, 0}, "c": {3, 0}, "d": {4, 0}})
+
+	// the clone is independent of the original
+	_, err = collection(t, db, "staging").InsertObject(&model.ReqInsertObject{
+		ID: "e", Metadata: map[string]interface{}{"category": "x"}, Vector: []float32{5, 0},
+	})
+	assert.NoError(t, err)
+	assertConsistent(t, db, map[string][]float32{"b": {2, 0}, "c": {3, 0}, "d": {4, 0}})
+
+	// restoring over an existing collection needs overwrite
+	assert.Error(t, db.RestoreCollection("test", bytes.NewReader(snapshot.Bytes()), &model.ReqRestoreCollection{}))
+	assert.Error(t, db.RestoreCollection("test", bytes.NewReader([]byte("not a snapshot")), &model.ReqRestoreCollection{Overwrite: true}))
+	assertConsistent(t, db, map[string][]float32{"b": {2, 0}, "c": {3, 0}, "d": {4, 0}})
+
+	assert.NoError(t, db.RestoreCollection("test", bytes.NewReader(snapshot.Bytes()), &model.ReqRestoreCollection{Overwrite: true}))
+	assertConsistent(t, db, map[string][]float32{"a": {1, 0}, "b": {2, 0}, "c": {3, 0}})
+
+	// the restored collections survive a crash
+	insertWALTestObject(t, db, "f", "y", []float32{6, 0})
+	crash(t, db)
+	db = openDB(t, dir, CheckpointPolicy{})
+	assertConsistent(t, db, map[string][]float32{"a": {1, 0}, "b": {2, 0}, "c": {3, 0}, "f": {6, 0}})
+	info, err = db.GetCollectionInfo("staging")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, info.ObjectCount)
+
+	// a restore whose objects are committed moves its staged index files on the next start
+	staged, err := stageColSnapshot(bytes.NewReader(snapshot.Bytes()), dir)
+	assert.NoError(t, err)
+	defer staged.remove()
+	assert.NoError(t, staged.place(db, "test"))
+	col := &Collection{db: db, name: "test", config: staged.config}
+	assert.NoError(t, db.kv.Update(func(tx *bbolt.Tx) error {
+		return col.restoreObjects(tx, staged.objects, true, false)
+	}))
+	crash(t, db)
+	db = openDB(t, dir, CheckpointPolicy{})
+	defer db.Close()
+	assertConsistent(t, db, map[string][]float32{"a": {1, 0}, "b": {2, 0}, "c": {3, 0}})
+	_, err = os.Stat(db.snapshotPath("test") + restoreSuffix)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -5,96 +5,135 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 	"vectordb/model"
 	"vectordb/pkg"
 
 	"go.etcd.io/bbolt"
 )
 
-var db *DB
-
 const (
 	bucketCollectionsMetadata = "collections_metadata"
 	bucketCollectionObjects   = "collection_objects"
+	bucketCollectionIndexes   = "collection_indexes"
 )
 
+// kvMmapSize is the address space mapped for the kv file up front, growing the file past the
+// mapping waits for every open read transaction, like the ones of exports and backups. With
+// a mapping this large bbolt grows the file in sparse steps of 16MB.
+const kvMmapSize = 1 << 30
+
+// DB is an open data directory, every collection of it is accessed through the same instance
 type DB struct {
-	collections map[string]*Collection
-	kv          *bbolt.DB
-	mu          sync.RWMutex
-	path        string
+	collections  map[string]*Collection
+	kv           *bbolt.DB
+	mu           sync.RWMutex
+	path         string
+	policy       CheckpointPolicy
+	checkpointCh chan *Collection
+	done         chan struct{}
+	wg           sync.WaitGroup
+	closeOnce    sync.Once
 }
 
-func Init(path string) (err error) {
+// Open opens the data directory at path, created if it doesn't exist, and loads its collections.
+// Only one DB can have a directory open at a time, the instance must be closed with Close
+func Open(path string, policy CheckpointPolicy) (*DB, error) {
 	// set default path
 	if path == "" {
 		path = "./vectordb_data"
 	}
 
 	// if the path doesn't exist
-	_, err = os.Stat(path)
+	_, err := os.Stat(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			err := os.MkdirAll(path, 0750)
 			if err != nil {
-				return fmt.Errorf("couldn't create store path '%s': %w", path, err)
+				return nil, fmt.Errorf("couldn't create store path '%s': %w", path, err)
 			}
 		} else {
-			return fmt.Errorf("couldn't get info about store path '%s': %w", path, err)
+			return nil, fmt.Errorf("couldn't get info about store path '%s': %w", path, err)
 		}
 	}
 
-	db = &DB{
+	db := &DB{
 		collections: make(map[string]*Collection),
 		path:        path,
+		policy:      policy,
 	}
-	if err = db.NewDB(path); err != nil {
-		return fmt.Errorf("failed to load db: %w", err)
+	if err = db.load(path); err != nil {
+		return nil, fmt.Errorf("failed to load db: %w", err)
 	}
-	return nil
-}
-
-func Close() {
-	_ = db.Close()
+	db.startCheckpointer()
+	return db, nil
 }
 
-func (db *DB) NewDB(path string) (err error) {
+func (db *DB) load(path string) (err error) {
 	kvpath := filepath.Join(path, "vectordb.db")
-	db.kv, err = bbolt.Open(kvpath, 0600, nil)
+	// fail instead of waiting while another process, like a running server, holds the file
+	db.kv, err = bbolt.Open(kvpath, 0600, &bbolt.Options{Timeout: time.Second, InitialMmapSize: kvMmapSize})
 	if err != nil {
 		return fmt.Errorf("failed to open kv db: %w", err)
 	}
+	// release the directory if loading fails, so it can be opened again
+	defer func() {
+		if err != nil {
+			for _, col := range db.collections {
+				col.Close()
+			}
+			db.kv.Close()
+		}
+	}()
 
 	// load collections and metadata
 	db.collections = map[string]*Collection{}
 
+	// collections are opened outside of the transaction, WAL replay writes to bbolt
+	configs := map[string]*model.CfgCollection{}
 	if err := db.kv.Update(func(tx *bbolt.Tx) error {
 		b, err := tx.CreateBucketIfNotExists([]byte(bucketCollectionsMetadata))
 		if err != nil {
 			return fmt.Errorf("failed to create or load bucket: %w", err)
 		}
-		b.ForEach(func(k, v []byte) error {
+		return b.ForEach(func(k, v []byte) error {
 			colmeta := &model.CfgCollection{}
 			if err := pkg.Deserialize(v, colmeta); err != nil {
 				return fmt.Errorf("failed to deserialize collection: %w", err)
 			}
-			col, err := newCollection(string(k), colmeta)
-			if err != nil {
-				return fmt.Errorf("failed to new collection instance: %w", err)
-			}
-			db.collections[string(k)] = col
+			configs[string(k)] = colmeta
 			return nil
 		})
-		return nil
 	}); err != nil {
 		return fmt.Errorf("failed to load collections: %w", err)
 	}
 
+	for name, colmeta := range configs {
+		col, err := db.newCollection(name, colmeta)
+		if err != nil {
+			return fmt.Errorf("failed to load collections: failed to new collection instance: %w", err)
+		}
+		db.collections[name] = col
+	}
+
 	return nil
 }
 
-func (db *DB) Close() (err error) {
+// Close checkpoints and closes every collection, collection handles can't be used afterwards.
+// Only the first call closes the DB, later ones return an error
+func (db *DB) Close() error {
+	err := errors.New("db is already closed")
+	db.closeOnce.Do(func() {
+		err = db.close()
+	})
+	return err
+}
+
+func (db *DB) close() (err error) {
+	db.stopCheckpointer()
+
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
@@ -111,11 +150,56 @@ func (db *DB) Close() (err error) {
 	return nil
 }
 
-func (db *DB) CreateCollection(colname string, cfg *model.CfgCollection) (err error) {
+// CreateCollection validates the collection request and creates an empty collection
+func (db *DB) CreateCollection(col *model.ReqCreateCollection) error {
+	if err := validateCollectionName(col.Name); err != nil {
+		return err
+	}
+	if col.Distance != "dot" && col.Distance != "cosine" && col.Distance != "euclidean" {
+		return fmt.Errorf("invalid distance metric")
+	}
+
+	mapping := col.Mapping
+	if col.Schema != nil {
+		if err := validateSchema(col.Schema); err != nil {
+			return err
+		}
+		mapping = make([]string, len(col.Schema))
+		for i, field := range col.Schema {
+			mapping[i] = field.Name
+		}
+	}
+
+	if err := validateMetadataIndexes(col.MetadataIndexes, mapping); err != nil {
+		return err
+	}
+	if err := validateSchemaIndexes(col.MetadataIndexes, col.Schema); err != nil {
+		return err
+	}
+
+	cfg := &model.CfgCollection{
+		Dimension:       col.Dimension,
+		IndexType:       col.IndexType,
+		IndexParams:     col.IndexParams,
+		Distance:        col.Distance,
+		Mapping:         mapping,
+		Quantization:    col.Quantization,
+		MetadataIndexes: col.MetadataIndexes,
+		Schema:          col.Schema,
+	}
+
+	return db.createCollection(col.Name, cfg)
+}
+
+func (db *DB) createCollection(colname string, cfg *model.CfgCollection) (err error) {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
-	col, err := newCollection(colname, cfg)
+	if _, ok := db.collections[colname]; ok {
+		return fmt.Errorf("collection '%s' already exists", colname)
+	}
+
+	col, err := db.newCollection(colname, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to new collection instance: %w", err)
 	}
@@ -140,6 +224,22 @@ func (db *DB) CreateCollection(colname string, cfg *model.CfgCollection) (err er
 		if err != nil {
 			return fmt.Errorf("failed to create object bucket under collection '%s': %w", colname, err)
 		}
+		// WAL entries from now on must be committed to count
+		if err := putWALSeq(tx, colname, col.seq); err != nil {
+			return err
+		}
+
+		if len(cfg.MetadataIndexes) > 0 {
+			idxBucket, err := colBucket.CreateBucket([]byte(bucketCollectionIndexes))
+			if err != nil {
+				return fmt.Errorf("failed to create index bucket under collection '%s': %w", colname, err)
+			}
+			for field := range cfg.MetadataIndexes {
+				if _, err := idxBucket.CreateBucket([]byte(field)); err != nil {
+					return fmt.Errorf("failed to create index bucket of field '%s': %w", field, err)
+				}
+			}
+		}
 
 		return nil
 	}); err != nil {
@@ -149,18 +249,21 @@ func (db *DB) CreateCollection(colname string, cfg *model.CfgCollection) (err er
 	return nil
 }
 
+// DeleteCollection deletes the collection with its objects and files, handles of it can't be
+// used afterwards
 func (db *DB) DeleteCollection(name string) (err error) {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
-	if col, ok := db.collections[name]; ok {
-		if err := col.Close(); err != nil {
-			return fmt.Errorf("failed to close collection WAL: %w", err)
-		}
+	col, ok := db.collections[name]
+	if !ok {
+		return fmt.Errorf("collection '%s' not found", name)
 	}
-	walpath := filepath.Join(db.path, name+".wal")
-	if err := os.RemoveAll(walpath); err != nil {
-		return fmt.Errorf("failed to delete WAL directory: %w", err)
+	if err := col.Close(); err != nil {
+		return fmt.Errorf("failed to close collection WAL: %w", err)
+	}
+	if err := db.removeCollectionFiles(name); err != nil {
+		return err
 	}
 
 	if err := db.kv.Update(func(tx *bbolt.Tx) error {
@@ -183,10 +286,59 @@ func (db *DB) DeleteCollection(name string) (err error) {
 	return nil
 }
 
+// validateCollectionName rejects the names that can't be used in the file names of a collection
+func validateCollectionName(name string) error {
+	if name == "" || name == "." || strings.Contains(name, "..") || strings.ContainsAny(name, "/\\\x00") {
+		return fmt.Errorf("invalid collection name '%s'", name)
+	}
+	return nil
+}
+
+// removeCollectionFiles deletes the WAL, the index snapshot and the index file of a collection
+func (db *DB) removeCollectionFiles(name string) error {
+	if err := os.RemoveAll(db.walPath(name)); err != nil {
+		return fmt.Errorf("failed to delete WAL directory: %w", err)
+	}
+	if err := os.Remove(db.snapshotPath(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete index snapshot: %w", err)
+	}
+	if err := os.Remove(db.indexPath(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete index file: %w", err)
+	}
+	return nil
+}
+
+// AlterCollection adds, drops or renames metadata fields of a collection, added fields are
+// backfilled with their default value. Only the collection is blocked while its objects are
+// rewritten
+func (db *DB) AlterCollection(colname string, req *model.ReqAlterCollection) error {
+	col, err := db.Collection(colname)
+	if err != nil {
+		return err
+	}
+
+	col.mu.Lock()
+	defer col.mu.Unlock()
+
+	if err := col.checkOpen(); err != nil {
+		return err
+	}
+	if err := col.alterFields(req.Changes); err != nil {
+		return fmt.Errorf("failed to alter collection '%s': %w", colname, err)
+	}
+
+	return nil
+}
+
 func (db *DB) GetCollectionInfo(colname string) (model.ResCollectionInfo, error) {
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
+	col, ok := db.collections[colname]
+	if !ok {
+		return model.ResCollectionInfo{}, fmt.Errorf("collection '%s' not found", colname)
+	}
+
 	cnt := 0
 	if err := db.kv.View(func(tx *bbolt.Tx) error {
 		colBucket := tx.Bucket([]byte(colname))
@@ -204,20 +356,43 @@ func (db *DB) GetCollectionInfo(colname string) (model.ResCollectionInfo, error)
 	}
 
 	// todo: get extra stats
+	// alters replace the config under col.mu only
+	col.mu.RLock()
+	walErrors := col.walErrors
+	cfg := col.config
+	col.mu.RUnlock()
 
 	info := model.ResCollectionInfo{
-		Name:        colname,
-		Dimension:   db.collections[colname].config.Dimension,
-		IndexType:   db.collections[colname].config.IndexType,
-		IndexParams: db.collections[colname].config.IndexParams,
-		Distance:    db.collections[colname].config.Distance,
-		Mapping:     db.collections[colname].config.Mapping,
-		ObjectCount: cnt,
+		Name:            colname,
+		Dimension:       cfg.Dimension,
+		IndexType:       cfg.IndexType,
+		IndexParams:     cfg.IndexParams,
+		Distance:        cfg.Distance,
+		Mapping:         cfg.Mapping,
+		Quantization:    cfg.Quantization,
+		ObjectCount:     cnt,
+		MetadataIndexes: cfg.MetadataIndexes,
+		Schema:          cfg.Schema,
+		WALErrors:       walErrors,
 	}
 
 	return info, nil
 }
 
+// Collection returns a handle of the collection colname, it stays valid until the collection is
+// deleted or restored over, or the DB is closed, its methods return an error afterwards
+func (db *DB) Collection(colname string) (*Collection, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	col, ok := db.collections[colname]
+	if !ok {
+		return nil, fmt.Errorf("collection '%s' not found", colname)
+	}
+
+	return col, nil
+}
+
 func (db *DB) GetDBInfo() (model.ResDBInfo, error) {
 	db.mu.RLock()
 	defer db.mu.RUnlock()
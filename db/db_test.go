@@ -0,0 +1,100 @@
+package db
+
+import (
+	"bytes"
+	"testing"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenInstances(t *testing.T) {
+	create := func(db *DB) {
+		assert.NoError(t, db.CreateCollection(&model.ReqCreateCollection{
+			Name: "test", Dimension: 2, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 100.0},
+			Distance: "euclidean",
+		}))
+	}
+
+	// two data directories are independent databases in one process
+	dir := t.TempDir()
+	a := openDB(t, dir, CheckpointPolicy{})
+	defer func() { a.Close() }()
+	b := openDB(t, t.TempDir(), CheckpointPolicy{})
+	defer b.Close()
+	create(a)
+	create(b)
+
+	_, err := collection(t, a, "test").InsertObject(&model.ReqInsertObject{ID: "a", Vector: []float32{1, 0}})
+	assert.NoError(t, err)
+	res, err := collection(t, b, "test").CountObjects(&model.ReqCountObjects{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, res.Count)
+
+	// a data directory is opened once
+	_, err = Open(dir, CheckpointPolicy{})
+	assert.Error(t, err)
+
+	// a deleted collection has no handle, the handles taken before fail
+	col := collection(t, b, "test")
+	assert.NoError(t, b.DeleteCollection("test"))
+	_, err = b.Collection("test")
+	assert.ErrorContains(t, err, "not found")
+	_, err = col.GetObjectInfo("a")
+	assert.EqualError(t, err, "collection 'test' is closed")
+	_, err = col.ScrollObjects(&model.ReqScrollObjects{})
+	assert.EqualError(t, err, "collection 'test' is closed")
+	_, err = col.InsertObject(&model.ReqInsertObject{Vector: []float32{1, 0}})
+	assert.EqualError(t, err, "collection 'test' is closed")
+
+	col = collection(t, a, "test")
+	assert.NoError(t, a.Close())
+	assert.EqualError(t, a.Close(), "db is already closed")
+	_, err = col.CountObjects(&model.ReqCountObjects{})
+	assert.EqualError(t, err, "collection 'test' is closed")
+	a = openDB(t, dir, CheckpointPolicy{})
+	obj, err := collection(t, a, "test").GetObjectInfo("a")
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1, 0}, obj.Vector)
+}
+
+func TestCreateCollectionIndexParams(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+	defer func() { db.Close() }()
+
+	// Go callers don't go through JSON numbers
+	assert.NoError(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "ints", Dimension: 2, IndexType: "hnsw", Distance: "euclidean",
+		IndexParams: map[string]interface{}{"maxsize": 10, "efconstruction": int64(20), "mmax": uint8(4), "compactthreshold": float32(0.5)},
+	}))
+	_, err := collection(t, db, "ints").InsertObject(&model.ReqInsertObject{Vector: []float32{1, 0}})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+	db = openDB(t, dir, CheckpointPolicy{})
+
+	err = db.CreateCollection(&model.ReqCreateCollection{
+		Name: "strings", Dimension: 2, IndexType: "flat", Distance: "euclidean",
+		IndexParams: map[string]interface{}{"maxsize": "10"},
+	})
+	assert.ErrorContains(t, err, "invalid type for key 'maxsize', expected: int but got: string")
+	err = db.CreateCollection(&model.ReqCreateCollection{
+		Name: "nil", Dimension: 2, IndexType: "flat", Distance: "euclidean",
+		IndexParams: map[string]interface{}{"maxsize": nil},
+	})
+	assert.ErrorContains(t, err, "got: nil")
+}
+
+func TestCollectionNames(t *testing.T) {
+	db := openDB(t, t.TempDir(), CheckpointPolicy{})
+	defer db.Close()
+
+	// names are used in the file names of the collection
+	for _, name := range []string{"", "..", "../x", "a/b", `a\b`} {
+		err := db.CreateCollection(&model.ReqCreateCollection{
+			Name: name, Dimension: 2, IndexType: "flat", Distance: "euclidean", IndexParams: map[string]interface{}{"maxsize": 10},
+		})
+		assert.ErrorContains(t, err, "invalid collection name")
+		assert.ErrorContains(t, db.RestoreCollection(name, bytes.NewReader(nil), &model.ReqRestoreCollection{}), "invalid collection name")
+	}
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"fmt"
+	"vectordb/model"
+	"vectordb/pkg"
+
+	"go.etcd.io/bbolt"
+)
+
+// Export is a consistent view of the objects of a collection, held by a bbolt read transaction
+// until it is closed. Writers of the collection are not blocked by it, unless they grow the kv
+// file past kvMmapSize, which waits for the end of the export.
+type Export struct {
+	tx        *bbolt.Tx
+	bucket    *bbolt.Bucket
+	name      string
+	count     int
+	dimension int
+}
+
+// Export opens a read transaction on the objects of the collection, Collection.mu is only
+// held until the transaction is opened
+func (c *Collection) Export() (*Export, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
+
+	tx, err := c.db.kv.Begin(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin read transaction: %w", err)
+	}
+	colBucket := tx.Bucket([]byte(c.name))
+	if colBucket == nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("collection '%s' not found", c.name)
+	}
+	bucket := colBucket.Bucket([]byte(bucketCollectionObjects))
+
+	return &Export{
+		tx:        tx,
+		bucket:    bucket,
+		name:      c.name,
+		count:     bucket.Stats().KeyN,
+		dimension: c.config.Dimension,
+	}, nil
+}
+
+// Count returns the number of objects in the export
+func (e *Export) Count() int {
+	return e.count
+}
+
+func (e *Export) Dimension() int {
+	return e.dimension
+}
+
+// Each calls fn for every object in id order, it can be called more than once and sees the
+// same objects every time
+func (e *Export) Each(fn func(obj model.ResObjectInfo) error) error {
+	return e.bucket.ForEach(func(k, v []byte) error {
+		obj := new(model.ReqInsertObject)
+		if err := pkg.Deserialize(v, obj); err != nil {
+			return fmt.Errorf("failed to deserialize object %s of collection '%s': %w", k, e.name, err)
+		}
+
+		return fn(model.ResObjectInfo{
+			ID:       string(k),
+			Metadata: obj.Metadata,
+			Vector:   obj.Vector,
+		})
+	})
+}
+
+// Close ends the read transaction of the export
+func (e *Export) Close() error {
+	return e.tx.Rollback()
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport(t *testing.T) {
+	db := openDB(t, t.TempDir(), CheckpointPolicy{})
+	defer db.Close()
+
+	assert.NoError(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "test", Dimension: 2, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 100.0},
+		Distance: "euclidean", Mapping: []string{"category"},
+	}))
+	col := collection(t, db, "test")
+	for i := 0; i < 5; i++ {
+		_, err := col.InsertObject(&model.ReqInsertObject{
+			ID: fmt.Sprintf("%02d", i), Metadata: map[string]interface{}{"category": "a"}, Vector: []float32{float32(i), 0},
+		})
+		assert.NoError(t, err)
+	}
+
+	export, err := col.Export()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer export.Close()
+	assert.Equal(t, 5, export.Count())
+	assert.Equal(t, 2, export.Dimension())
+
+	// writers go on while the export is open, it doesn't see their changes
+	done := make(chan error, 1)
+	go func() {
+		_, err := col.InsertObject(&model.ReqInsertObject{ID: "05", Metadata: map[string]interface{}{"category": "a"}, Vector: []float32{5, 0}})
+		if err == nil {
+			err = col.DeleteObject("00")
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("writes are blocked by the export")
+	}
+
+	for i := 0; i < 2; i++ {
+		ids := []string{}
+		assert.NoError(t, export.Each(func(obj model.ResObjectInfo) error {
+			ids = append(ids, obj.ID)
+			assert.Equal(t, "a", obj.Metadata["category"])
+			return nil
+		}))
+		assert.Equal(t, []string{"00", "01", "02", "03", "04"}, ids)
+	}
+	res, err := col.CountObjects(&model.ReqCountObjects{})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, res.Count)
+}
@@ -0,0 +1,272 @@
+package db
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"vectordb/model"
+)
+
+// validateFilter checks that a filter expression is well formed and only refers to mapping fields,
+// fields of nested objects are referred to by dotted paths
+func validateFilter(f *model.Filter, mapping []string) error {
+	set := 0
+	for _, ok := range []bool{f.And != nil, f.Or != nil, f.Not != nil, f.Field != ""} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("filter must have exactly one of and, or, not, field")
+	}
+
+	switch {
+	case f.And != nil || f.Or != nil:
+		filters := f.And
+		if f.Or != nil {
+			filters = f.Or
+		}
+		if len(filters) == 0 {
+			return fmt.Errorf("filter and/or must not be empty")
+		}
+		for i := range filters {
+			if err := validateFilter(&filters[i], mapping); err != nil {
+				return err
+			}
+		}
+		return nil
+	case f.Not != nil:
+		return validateFilter(f.Not, mapping)
+	}
+
+	root, _, _ := strings.Cut(f.Field, ".")
+	if !slices.Contains(mapping, root) {
+		return fmt.Errorf("filter field '%s' not found in mapping", f.Field)
+	}
+
+	switch f.Op {
+	case "eq", "ne":
+		if !isScalar(f.Value) {
+			return fmt.Errorf("filter %s on '%s' requires a string, number or bool value", f.Op, f.Field)
+		}
+	case "in":
+		if len(f.Values) == 0 {
+			return fmt.Errorf("filter in on '%s' requires values", f.Field)
+		}
+		for _, v := range f.Values {
+			if !isScalar(v) {
+				return fmt.Errorf("filter in on '%s' requires string, number or bool values", f.Field)
+			}
+		}
+	case "range":
+		bounds := 0
+		for _, v := range []interface{}{f.Gt, f.Gte, f.Lt, f.Lte} {
+			if v == nil {
+				continue
+			}
+			if _, ok := toFloat(v); !ok {
+				if _, ok := v.(string); !ok {
+					return fmt.Errorf("filter range on '%s' requires number or string bounds", f.Field)
+				}
+			}
+			bounds++
+		}
+		if bounds == 0 {
+			return fmt.Errorf("filter range on '%s' requires at least one of gt, gte, lt, lte", f.Field)
+		}
+	default:
+		return fmt.Errorf("unsupported filter op: '%s'", f.Op)
+	}
+
+	return nil
+}
+
+// matchFilter evaluates a validated filter against the metadata of an object,
+// conditions on missing fields or on values of another type never match and
+// conditions on string arrays match if any element does
+func matchFilter(f *model.Filter, metadata map[string]interface{}) bool {
+	switch {
+	case f.And != nil:
+		for i := range f.And {
+			if !matchFilter(&f.And[i], metadata) {
+				return false
+			}
+		}
+		return true
+	case f.Or != nil:
+		for i := range f.Or {
+			if matchFilter(&f.Or[i], metadata) {
+				return true
+			}
+		}
+		return false
+	case f.Not != nil:
+		return !matchFilter(f.Not, metadata)
+	}
+
+	value, ok := lookupField(metadata, f.Field)
+	if !ok {
+		return false
+	}
+
+	if arr, ok := value.([]string); ok {
+		if f.Op == "ne" {
+			for _, e := range arr {
+				if !matchValue(f, e) {
+					return false
+				}
+			}
+			return true
+		}
+		for _, e := range arr {
+			if matchValue(f, e) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return matchValue(f, value)
+}
+
+// lookupField returns the value at a dotted path of nested objects
+func lookupField(metadata map[string]interface{}, path string) (interface{}, bool) {
+	for {
+		key, rest, nested := strings.Cut(path, ".")
+		value, ok := metadata[key]
+		if !ok || value == nil {
+			return nil, false
+		}
+		if !nested {
+			return value, true
+		}
+		if metadata, ok = value.(map[string]interface{}); !ok {
+			return nil, false
+		}
+		path = rest
+	}
+}
+
+// matchValue evaluates the condition of a field filter against a single value
+func matchValue(f *model.Filter, value interface{}) bool {
+	switch f.Op {
+	case "eq":
+		c, ok := compareValues(value, f.Value)
+		return ok && c == 0
+	case "ne":
+		c, ok := compareValues(value, f.Value)
+		return ok && c != 0
+	case "in":
+		for _, v := range f.Values {
+			if c, ok := compareValues(value, v); ok && c == 0 {
+				return true
+			}
+		}
+		return false
+	case "range":
+		if f.Gt != nil {
+			if c, ok := compareValues(value, f.Gt); !ok || c <= 0 {
+				return false
+			}
+		}
+		if f.Gte != nil {
+			if c, ok := compareValues(value, f.Gte); !ok || c < 0 {
+				return false
+			}
+		}
+		if f.Lt != nil {
+			if c, ok := compareValues(value, f.Lt); !ok || c >= 0 {
+				return false
+			}
+		}
+		if f.Lte != nil {
+			if c, ok := compareValues(value, f.Lte); !ok || c > 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// compareValues orders two metadata values of the same kind, bools are only comparable for equality
+// and datetimes compare against RFC 3339 strings
+func compareValues(a, b interface{}) (int, bool) {
+	if x, ok := a.(time.Time); ok {
+		var y time.Time
+		switch v := b.(type) {
+		case time.Time:
+			y = v
+		case string:
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return 0, false
+			}
+			y = t
+		default:
+			return 0, false
+		}
+		return x.Compare(y), true
+	}
+
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	}
+
+	switch x := a.(type) {
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(x, y), true
+	case bool:
+		y, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		if x == y {
+			return 0, true
+		}
+		return 1, true
+	}
+
+	return 0, false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case string, bool:
+		return true
+	}
+	_, ok := toFloat(v)
+	return ok
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseFilter(t *testing.T, data string) *model.Filter {
+	f := new(model.Filter)
+	assert.NoError(t, json.Unmarshal([]byte(data), f))
+	return f
+}
+
+func TestMatchFilter(t *testing.T) {
+	mapping := []string{"category", "price", "instock"}
+	metadata := map[string]interface{}{"category": "shoes", "price": 79.0, "instock": true}
+
+	cases := []struct {
+		filter string
+		match  bool
+	}{
+		{`{"field": "category", "op": "eq", "value": "shoes"}`, true},
+		{`{"field": "category", "op": "ne", "value": "shoes"}`, false},
+		{`{"field": "price", "op": "eq", "value": 79}`, true},
+		{`{"field": "price", "op": "range", "lt": 100}`, true},
+		{`{"field": "price", "op": "range", "gte": 79, "lte": 79}`, true},
+		{`{"field": "price", "op": "range", "gt": 79}`, false},
+		{`{"field": "category", "op": "range", "gte": "s", "lt": "t"}`, true},
+		{`{"field": "category", "op": "in", "values": ["boots", "shoes"]}`, true},
+		{`{"field": "category", "op": "in", "values": ["boots", 1]}`, false},
+		{`{"field": "instock", "op": "eq", "value": true}`, true},
+		{`{"field": "price", "op": "eq", "value": "79"}`, false},
+		{`{"and": [{"field": "category", "op": "eq", "value": "shoes"}, {"field": "price", "op": "range", "lt": 100}]}`, true},
+		{`{"and": [{"field": "category", "op": "eq", "value": "shoes"}, {"field": "price", "op": "range", "lt": 50}]}`, false},
+		{`{"or": [{"field": "category", "op": "eq", "value": "boots"}, {"field": "price", "op": "range", "lt": 100}]}`, true},
+		{`{"not": {"field": "instock", "op": "eq", "value": true}}`, false},
+	}
+	for _, c := range cases {
+		f := parseFilter(t, c.filter)
+		assert.NoError(t, validateFilter(f, mapping), c.filter)
+		assert.Equal(t, c.match, matchFilter(f, metadata), c.filter)
+	}
+
+	// metadata read back from the object bucket may hold other number types
+	assert.True(t, matchFilter(parseFilter(t, `{"field": "price", "op": "range", "lt": 100}`), map[string]interface{}{"price": 79}))
+}
+
+func TestValidateFilter(t *testing.T) {
+	mapping := []string{"category", "price"}
+
+	invalid := []string{
+		`{}`,
+		`{"field": "color", "op": "eq", "value": "red"}`,
+		`{"field": "price", "op": "gt", "value": 1}`,
+		`{"field": "price", "op": "eq"}`,
+		`{"field": "price", "op": "eq", "value": [1, 2]}`,
+		`{"field": "price", "op": "in", "values": []}`,
+		`{"field": "price", "op": "range"}`,
+		`{"field": "price", "op": "range", "lt": true}`,
+		`{"and": []}`,
+		`{"and": [{"field": "price", "op": "eq", "value": 1}], "field": "price"}`,
+		`{"not": {"or": [{"field": "color", "op": "eq", "value": "red"}]}}`,
+	}
+	for _, data := range invalid {
+		assert.Error(t, validateFilter(parseFilter(t, data), mapping), data)
+	}
+}
@@ -0,0 +1,138 @@
+package db
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"vectordb/db/index"
+	"vectordb/model"
+	"vectordb/pkg"
+
+	"go.etcd.io/bbolt"
+)
+
+// Fsck checks that the object bucket, the WAL and the index of the collections agree, every
+// collection is checked if none are given. repair rebuilds the index of inconsistent collections
+// from their object bucket and checkpoints them, which drops unreadable WAL entries
+func (db *DB) Fsck(req *model.ReqFsck) ([]model.ResFsck, error) {
+	colnames, repair := req.Collections, req.Repair
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if len(colnames) == 0 {
+		for name := range db.collections {
+			colnames = append(colnames, name)
+		}
+		sort.Strings(colnames)
+	}
+
+	reports := make([]model.ResFsck, 0, len(colnames))
+	for _, name := range colnames {
+		col, ok := db.collections[name]
+		if !ok {
+			return nil, fmt.Errorf("collection '%s' not found", name)
+		}
+
+		report, err := col.fsck(repair)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check collection '%s': %w", name, err)
+		}
+		if report.Repaired {
+			if _, err := col.Checkpoint(); err != nil {
+				return nil, fmt.Errorf("failed to checkpoint collection '%s': %w", name, err)
+			}
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+func (c *Collection) fsck(repair bool) (model.ResFsck, error) {
+	// writers are blocked so that the object bucket and the index are compared at the same point
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := model.ResFsck{
+		Name:            c.name,
+		OrphanedVectors: []string{},
+		MissingVectors:  []string{},
+		CorruptObjects:  []string{},
+		CorruptWAL:      []string{},
+	}
+
+	lister, ok := c.index.(index.Lister)
+	if !ok {
+		return report, fmt.Errorf("index type '%s' can't list its ids", c.config.IndexType)
+	}
+
+	objects := make(map[string]bool) // whether the object is decodable
+	if err := c.db.kv.View(func(tx *bbolt.Tx) error {
+		objBucket := tx.Bucket([]byte(c.name)).Bucket([]byte(bucketCollectionObjects))
+		return objBucket.ForEach(func(k, v []byte) error {
+			obj := new(model.ReqInsertObject)
+			if err := pkg.Deserialize(v, obj); err != nil {
+				report.CorruptObjects = append(report.CorruptObjects, string(k))
+				objects[string(k)] = false
+				return nil
+			}
+			objects[string(k)] = true
+			return nil
+		})
+	}); err != nil {
+		return report, err
+	}
+	report.ObjectCount = len(objects)
+
+	first, err := c.wal.FirstIndex()
+	if err != nil {
+		return report, err
+	}
+	last, err := c.wal.LastIndex()
+	if err != nil {
+		return report, err
+	}
+	// replay skips the entries covered by the index snapshot
+	if first <= c.snapshotSeq {
+		first = c.snapshotSeq + 1
+	}
+	for i := first; i <= last && i > 0; i++ {
+		if _, err := c.readWAL(i); err != nil {
+			report.CorruptWAL = append(report.CorruptWAL, fmt.Sprintf("entry %d: %v", i, err))
+		}
+	}
+
+	ids := lister.IDs()
+	report.IndexCount = len(ids)
+	indexed := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		indexed[id] = struct{}{}
+		if _, ok := objects[id]; !ok {
+			report.OrphanedVectors = append(report.OrphanedVectors, id)
+		}
+	}
+	for id, decodable := range objects {
+		if _, ok := indexed[id]; !ok && decodable {
+			report.MissingVectors = append(report.MissingVectors, id)
+		}
+	}
+	slices.Sort(report.OrphanedVectors)
+	slices.Sort(report.MissingVectors)
+
+	if !repair || len(report.OrphanedVectors)+len(report.MissingVectors)+len(report.CorruptWAL)+len(c.walErrors) == 0 {
+		return report, nil
+	}
+
+	if _, err := c.rebuildIndex(); err != nil {
+		return report, fmt.Errorf("failed to rebuild index: %w", err)
+	}
+	// the rebuilt index holds every committed object, the snapshot can move past the WAL errors
+	c.walErrors = nil
+	if err := c.saveSnapshot(); err != nil {
+		return report, fmt.Errorf("failed to save index snapshot: %w", err)
+	}
+	report.Repaired = true
+
+	return report, nil
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func TestFsck(t *testing.T) {
+	db := openDB(t, t.TempDir(), CheckpointPolicy{})
+	defer db.Close()
+
+	createWALTestCollection(t, db)
+	insertWALTestObject(t, db, "a", "x", []float32{1, 0})
+	insertWALTestObject(t, db, "b", "y", []float32{2, 0})
+	insertWALTestObject(t, db, "c", "x", []float32{3, 0})
+
+	reports, err := db.Fsck(&model.ReqFsck{})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.ResFsck{{
+		Name: "test", ObjectCount: 3, IndexCount: 3, OrphanedVectors: []string{},
+		MissingVectors: []string{}, CorruptObjects: []string{}, CorruptWAL: []string{},
+	}}, reports)
+
+	// break every layer behind the collection's back
+	col := db.collections["test"]
+	assert.NoError(t, col.index.Insert("orphan", []float32{9, 9}))
+	assert.NoError(t, col.index.Delete("b"))
+	assert.NoError(t, db.kv.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("test")).Bucket([]byte(bucketCollectionObjects)).Put([]byte("c"), []byte("not a gob object"))
+	}))
+	assert.NoError(t, col.wal.Write(col.seq+1, []byte("not a gob entry")))
+	col.seq++
+
+	reports, err = db.Fsck(&model.ReqFsck{Collections: []string{"test"}})
+	assert.NoError(t, err)
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, 3, reports[0].ObjectCount)
+		assert.Equal(t, 3, reports[0].IndexCount)
+		assert.Equal(t, []string{"orphan"}, reports[0].OrphanedVectors)
+		assert.Equal(t, []string{"b"}, reports[0].MissingVectors)
+		assert.Equal(t, []string{"c"}, reports[0].CorruptObjects)
+		assert.Len(t, reports[0].CorruptWAL, 1)
+		assert.False(t, reports[0].Repaired)
+	}
+
+	// the repair rebuilds the index from the decodable objects and drops the WAL
+	reports, err = db.Fsck(&model.ReqFsck{Repair: true})
+	assert.NoError(t, err)
+	if assert.Len(t, reports, 1) {
+		assert.True(t, reports[0].Repaired)
+	}
+
+	reports, err = db.Fsck(&model.ReqFsck{})
+	assert.NoError(t, err)
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, 2, reports[0].IndexCount)
+		assert.Empty(t, reports[0].OrphanedVectors)
+		assert.Empty(t, reports[0].MissingVectors)
+		assert.Equal(t, []string{"c"}, reports[0].CorruptObjects)
+		assert.Empty(t, reports[0].CorruptWAL)
+	}
+
+	results, err := collection(t, db, "test").SearchObjects(&model.ReqSearchObject{Vector: []float32{2, 0}, TopK: 10})
+	assert.NoError(t, err)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "b", results[0].ID)
+	}
+
+	_, err = db.Fsck(&model.ReqFsck{Collections: []string{"missing"}})
+	assert.Error(t, err)
+}
+
+func TestFsckCorruptWALOffline(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+	createWALTestCollection(t, db)
+	insertWALTestObject(t, db, "a", "x", []float32{1, 0})
+	_, err := db.CheckpointCollection("test")
+	assert.NoError(t, err)
+	insertWALTestObject(t, db, "b", "y", []float32{2, 0})
+	col := db.collections["test"]
+	data, err := col.wal.Read(col.seq)
+	assert.NoError(t, err)
+	crash(t, db)
+
+	// overwrite the committed entry of b in the WAL segment
+	segments, err := filepath.Glob(filepath.Join(db.walPath("test"), "*"))
+	assert.NoError(t, err)
+	corrupted := false
+	for _, segment := range segments {
+		content, err := os.ReadFile(segment)
+		assert.NoError(t, err)
+		if i := bytes.Index(content, data); i >= 0 {
+			copy(content[i:], bytes.Repeat([]byte{0xff}, len(data)))
+			assert.NoError(t, os.WriteFile(segment, content, 0600))
+			corrupted = true
+		}
+	}
+	assert.True(t, corrupted)
+	snapshot, err := os.ReadFile(db.snapshotPath("test"))
+	assert.NoError(t, err)
+
+	// opening and checking without repair leaves the files as they are
+	for i := 0; i < 2; i++ {
+		db = openDB(t, dir, CheckpointPolicy{})
+		reports, err := db.Fsck(&model.ReqFsck{})
+		assert.NoError(t, err)
+		if assert.Len(t, reports, 1) {
+			assert.Len(t, reports[0].CorruptWAL, 1)
+			assert.Equal(t, []string{"b"}, reports[0].MissingVectors)
+			assert.False(t, reports[0].Repaired)
+		}
+		_, err = db.CheckpointCollection("test")
+		assert.ErrorContains(t, err, "has WAL errors")
+		assert.NoError(t, db.Close())
+
+		content, err := os.ReadFile(db.snapshotPath("test"))
+		assert.NoError(t, err)
+		assert.Equal(t, snapshot, content)
+	}
+
+	db = openDB(t, dir, CheckpointPolicy{})
+	reports, err := db.Fsck(&model.ReqFsck{Repair: true})
+	assert.NoError(t, err)
+	if assert.Len(t, reports, 1) {
+		assert.True(t, reports[0].Repaired)
+	}
+	assert.NoError(t, db.Close())
+
+	db = openDB(t, dir, CheckpointPolicy{})
+	defer db.Close()
+	info, err := db.GetCollectionInfo("test")
+	assert.NoError(t, err)
+	assert.Empty(t, info.WALErrors)
+	assertConsistent(t, db, map[string][]float32{"a": {1, 0}, "b": {2, 0}})
+}
@@ -0,0 +1,160 @@
+package binary
+
+import (
+	"container/heap"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"sync"
+	"vectordb/model"
+	"vectordb/pkg"
+)
+
+// Binary keeps one sign bit per dimension and scans with the hamming distance,
+// its scores are not in the collection's dist_type so the results are always rescored by the collection
+type Binary struct {
+	codes   map[string][]uint64
+	maxSize int
+	mu      sync.RWMutex
+}
+
+// binarySnapshot is the gob encoded state of a binary index
+type binarySnapshot struct {
+	Codes map[string][]uint64
+}
+
+func NewBinary(params *model.BinaryParams, distance string) (*Binary, error) {
+	if _, err := pkg.GetDistanceFunc(distance); err != nil {
+		return nil, err
+	}
+
+	return &Binary{
+		codes:   make(map[string][]uint64),
+		maxSize: params.MaxSize,
+	}, nil
+}
+
+func (b *Binary) Insert(id string, vector []float32) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.codes[id]; exists {
+		return fmt.Errorf("id %s already exists in index", id)
+	}
+	if len(b.codes) >= b.maxSize {
+		return fmt.Errorf("binary index is full")
+	}
+	b.codes[id] = pkg.PackSignBits(vector)
+	return nil
+}
+
+func (b *Binary) Delete(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.codes[id]; !exists {
+		return fmt.Errorf("id %s not found in index", id)
+	}
+
+	delete(b.codes, id)
+	return nil
+}
+
+func (b *Binary) Update(id string, vector []float32) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.codes[id]; !exists {
+		return fmt.Errorf("id %s not found in index", id)
+	}
+
+	b.codes[id] = pkg.PackSignBits(vector)
+	return nil
+}
+
+// IDs returns the ids of the vectors in the index
+func (b *Binary) IDs() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	ids := make([]string, 0, len(b.codes))
+	for id := range b.codes {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (b *Binary) Search(vector []float32, topk int, xparams map[string]interface{}) ([]model.SearchResult, error) {
+	return b.SearchWithFilter(vector, topk, xparams, nil)
+}
+
+// SearchWithFilter returns the topk accepted candidates with the smallest hamming distance
+func (b *Binary) SearchWithFilter(vector []float32, topk int, xparams map[string]interface{}, filter func(id string) bool) ([]model.SearchResult, error) {
+	q := pkg.PackSignBits(vector)
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	resultspq := pkg.NewMaxPQ()
+	heap.Init(resultspq)
+
+	for id, code := range b.codes {
+		if filter != nil && !filter(id) {
+			continue
+		}
+		dist := pkg.HammingDistance(q, code)
+		if resultspq.Len() < topk {
+			heap.Push(resultspq, pkg.NewItem(id, dist))
+		} else if resultspq.Len() > 0 && dist < resultspq.Top().(*pkg.Item).Distance {
+			heap.Pop(resultspq)
+			heap.Push(resultspq, pkg.NewItem(id, dist))
+		}
+	}
+
+	resultspq.SwitchOrder() // switch to minpq
+
+	results := make([]model.SearchResult, 0, resultspq.Len())
+	for resultspq.Len() > 0 {
+		item := heap.Pop(resultspq).(*pkg.Item)
+		results = append(results, model.SearchResult{
+			ID:    item.Node.(string),
+			Score: item.Distance,
+		})
+	}
+
+	return results, nil
+}
+
+// RequiresRerank reports that hamming distances must be rescored with the exact distance
+func (b *Binary) RequiresRerank() bool {
+	return true
+}
+
+func (b *Binary) Save(w io.Writer) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if err := gob.NewEncoder(w).Encode(binarySnapshot{Codes: b.codes}); err != nil {
+		return fmt.Errorf("failed to encode binary index: %w", err)
+	}
+	return nil
+}
+
+func (b *Binary) Load(r io.Reader) error {
+	snap := binarySnapshot{}
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("failed to decode binary index: %w", err)
+	}
+	if len(snap.Codes) > b.maxSize {
+		return fmt.Errorf("binary index snapshot exceeds max size")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.codes = snap.Codes
+	if b.codes == nil {
+		b.codes = make(map[string][]uint64)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package binary
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinaryOperations(t *testing.T) {
+	params := &model.BinaryParams{
+		MaxSize: 500,
+	}
+
+	index, err := NewBinary(params, "cosine")
+	assert.NoError(t, err)
+	assert.NotNil(t, index)
+	assert.True(t, index.RequiresRerank())
+
+	vectors := make(map[string][]float32)
+	vectors["vec0"] = []float32{0.05, -0.61, 0.76, -0.74}
+	vectors["vec1"] = []float32{-0.19, 0.81, 0.75, 0.11}
+	vectors["vec2"] = []float32{0.36, 0.55, -0.47, 0.94}
+	vectors["vec3"] = []float32{-0.18, -0.01, -0.85, 0.80}
+	vectors["vec4"] = []float32{-0.24, -0.18, 0.22, -0.44}
+	vectors["vec5"] = []float32{0.35, 0.08, 0.11, 0.44}
+
+	// insert
+	for id, vec := range vectors {
+		err := index.Insert(id, vec)
+		assert.NoError(t, err)
+	}
+
+	// ids are inserted once
+	assert.EqualError(t, index.Insert("vec0", vectors["vec0"]), "id vec0 already exists in index")
+
+	// search the same vector, every sign agrees
+	testID := "vec4"
+	results, err := index.Search(vectors[testID], 3, nil)
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, testID, results[0].ID)
+	assert.Equal(t, float32(0), results[0].Score)
+
+	// update flips the signs
+	err = index.Update(testID, []float32{0.24, 0.18, -0.22, 0.44})
+	assert.NoError(t, err)
+	results, err = index.Search(vectors[testID], 6, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(4), results[len(results)-1].Score)
+	opposite := []string{}
+	for _, result := range results {
+		if result.Score == 4 {
+			opposite = append(opposite, result.ID)
+		}
+	}
+	assert.ElementsMatch(t, []string{"vec2", testID}, opposite) // vec2 has the same signs
+
+	// delete
+	err = index.Delete(testID)
+	assert.NoError(t, err)
+	results, err = index.Search(vectors[testID], 6, nil)
+	assert.NoError(t, err)
+	for _, result := range results {
+		assert.NotEqual(t, testID, result.ID)
+	}
+	err = index.Delete("nonexistent")
+	assert.Error(t, err)
+}
+
+func TestBinaryHighDimension(t *testing.T) {
+	params := &model.BinaryParams{
+		MaxSize: 1000,
+	}
+
+	index, err := NewBinary(params, "cosine")
+	assert.NoError(t, err)
+
+	// dimensions span several words
+	dim := 1536
+	vectors := make([][]float32, 1000)
+	for i := range vectors {
+		vectors[i] = make([]float32, dim)
+		for d := range vectors[i] {
+			vectors[i][d] = rand.Float32()*2 - 1
+		}
+		err := index.Insert(fmt.Sprintf("vec%d", i), vectors[i])
+		assert.NoError(t, err)
+	}
+	assert.Len(t, index.codes["vec0"], 24)
+
+	// test max size limit
+	err = index.Insert("full", vectors[0])
+	assert.Error(t, err)
+
+	results, err := index.Search(vectors[42], 10, nil)
+	assert.NoError(t, err)
+	assert.Len(t, results, 10)
+	assert.Equal(t, "vec42", results[0].ID)
+
+	// snapshot
+	buf := new(bytes.Buffer)
+	assert.NoError(t, index.Save(buf))
+	loaded, err := NewBinary(params, "cosine")
+	assert.NoError(t, err)
+	assert.NoError(t, loaded.Load(buf))
+	assert.Equal(t, index.codes, loaded.codes)
+}
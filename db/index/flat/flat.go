@@ -1,7 +1,9 @@
 package flat
 
 import (
+	"encoding/gob"
 	"fmt"
+	"io"
 	"sort"
 	"sync"
 	"vectordb/model"
@@ -9,16 +11,32 @@ import (
 )
 
 type Flat struct {
-	distfunc func([]float32, []float32) float32
-	vectors  map[string][]float32
-	maxSize  int
-	mu       sync.RWMutex // map in go is not concurrency safe
+	distfunc     func([]float32, []float32) float32
+	distfuncInt8 func([]float32, []int8, *pkg.ScalarQuantizer) float32
+	vectors      map[string][]float32
+	codes        map[string][]int8    // int8 quantized vectors, replace vectors once the quantizer is trained
+	quantizer    *pkg.ScalarQuantizer // nil until trained
+	quantization string
+	maxSize      int
+	mu           sync.RWMutex // map in go is not concurrency safe
 }
 
+// flatSnapshot is the gob encoded state of a flat index
+type flatSnapshot struct {
+	Vectors   map[string][]float32
+	Codes     map[string][]int8
+	Quantizer *pkg.ScalarQuantizer
+}
+
+// number of vectors collected before training the int8 quantizer
+const quantizerTrainSize = 1000
+
 func NewFlat(params *model.FlatParams, distance string) (*Flat, error) {
 	f := &Flat{
-		vectors: make(map[string][]float32, params.MaxSize),
-		maxSize: params.MaxSize,
+		vectors:      make(map[string][]float32, params.MaxSize),
+		codes:        make(map[string][]int8),
+		quantization: params.Quantization,
+		maxSize:      params.MaxSize,
 	}
 	switch distance {
 	case "dot":
@@ -30,6 +48,13 @@ func NewFlat(params *model.FlatParams, distance string) (*Flat, error) {
 	default:
 		return nil, fmt.Errorf("invalid distance metric")
 	}
+	switch f.quantization {
+	case "", "none":
+	case "int8":
+		f.distfuncInt8, _ = pkg.GetDistanceFuncInt8(distance)
+	default:
+		return nil, fmt.Errorf("unsupported quantization: '%s'", f.quantization)
+	}
 
 	return f, nil
 }
@@ -38,10 +63,14 @@ func (f *Flat) Insert(id string, vector []float32) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	if len(f.vectors) >= f.maxSize {
+	if f.size() >= f.maxSize {
 		return fmt.Errorf("flat index is full")
 	}
-	f.vectors[id] = vector
+	f.put(id, vector)
+
+	if f.quantization == "int8" && f.quantizer == nil && len(f.vectors) >= quantizerTrainSize {
+		f.train()
+	}
 	return nil
 }
 
@@ -49,12 +78,12 @@ func (f *Flat) Delete(id string) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	_, exists := f.vectors[id]
-	if !exists {
+	if !f.exists(id) {
 		return fmt.Errorf("id %s not found in index", id)
 	}
 
 	delete(f.vectors, id)
+	delete(f.codes, id)
 	return nil
 }
 
@@ -62,28 +91,60 @@ func (f *Flat) Update(id string, vector []float32) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	_, exists := f.vectors[id]
-	if !exists {
+	if !f.exists(id) {
 		return fmt.Errorf("id %s not found in index", id)
 	}
 
-	f.vectors[id] = vector
+	f.put(id, vector)
 	return nil
 }
 
+// IDs returns the ids of the vectors in the index
+func (f *Flat) IDs() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	ids := make([]string, 0, len(f.vectors)+len(f.codes))
+	for id := range f.vectors {
+		ids = append(ids, id)
+	}
+	for id := range f.codes {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 func (f *Flat) Search(vector []float32, topk int, xparams map[string]interface{}) ([]model.SearchResult, error) {
+	return f.SearchWithFilter(vector, topk, xparams, nil)
+}
+
+// SearchWithFilter scans only the vectors accepted by the filter
+func (f *Flat) SearchWithFilter(vector []float32, topk int, xparams map[string]interface{}, filter func(id string) bool) ([]model.SearchResult, error) {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
-	results := make([]model.SearchResult, 0, len(f.vectors))
+	results := make([]model.SearchResult, 0, f.size())
 
 	for id, storedVector := range f.vectors {
+		if filter != nil && !filter(id) {
+			continue
+		}
 		score := f.distfunc(vector, storedVector)
 		results = append(results, model.SearchResult{
 			ID:    id,
 			Score: score,
 		})
 	}
+	for id, code := range f.codes {
+		if filter != nil && !filter(id) {
+			continue
+		}
+		score := f.distfuncInt8(vector, code, f.quantizer)
+		results = append(results, model.SearchResult{
+			ID:    id,
+			Score: score,
+		})
+	}
 
 	// sort by distance score, smaller is more similar
 	sort.Slice(results, func(i, j int) bool {
@@ -96,3 +157,81 @@ func (f *Flat) Search(vector []float32, topk int, xparams map[string]interface{}
 
 	return results[:topk], nil
 }
+
+func (f *Flat) Save(w io.Writer) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	snap := flatSnapshot{
+		Vectors:   f.vectors,
+		Codes:     f.codes,
+		Quantizer: f.quantizer,
+	}
+	if err := gob.NewEncoder(w).Encode(snap); err != nil {
+		return fmt.Errorf("failed to encode flat index: %w", err)
+	}
+	return nil
+}
+
+func (f *Flat) Load(r io.Reader) error {
+	snap := flatSnapshot{}
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("failed to decode flat index: %w", err)
+	}
+	if len(snap.Vectors)+len(snap.Codes) > f.maxSize {
+		return fmt.Errorf("flat index snapshot exceeds max size")
+	}
+	if len(snap.Codes) > 0 && snap.Quantizer == nil {
+		return fmt.Errorf("flat index snapshot is inconsistent")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.vectors = snap.Vectors
+	if f.vectors == nil {
+		f.vectors = make(map[string][]float32, f.maxSize)
+	}
+	f.codes = snap.Codes
+	if f.codes == nil {
+		f.codes = make(map[string][]int8)
+	}
+	f.quantizer = snap.Quantizer
+	return nil
+}
+
+func (f *Flat) size() int {
+	return len(f.vectors) + len(f.codes)
+}
+
+func (f *Flat) exists(id string) bool {
+	if _, ok := f.vectors[id]; ok {
+		return true
+	}
+	_, ok := f.codes[id]
+	return ok
+}
+
+// put stores the vector quantized if the quantizer is trained, in full precision otherwise
+func (f *Flat) put(id string, vector []float32) {
+	if f.quantizer != nil {
+		delete(f.vectors, id)
+		f.codes[id] = f.quantizer.Encode(vector)
+		return
+	}
+	f.vectors[id] = vector
+}
+
+// train learns the int8 quantizer from the collected vectors and quantizes all of them
+func (f *Flat) train() {
+	vectors := make([][]float32, 0, len(f.vectors))
+	for _, vector := range f.vectors {
+		vectors = append(vectors, vector)
+	}
+
+	f.quantizer = pkg.TrainScalarQuantizer(vectors)
+	for id, vector := range f.vectors {
+		f.codes[id] = f.quantizer.Encode(vector)
+	}
+	f.vectors = make(map[string][]float32)
+}
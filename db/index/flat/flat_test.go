@@ -1,6 +1,7 @@
 package flat
 
 import (
+	"bytes"
 	"fmt"
 	"math/rand/v2"
 	"testing"
@@ -88,3 +89,124 @@ func TestFlatEdgeCases(t *testing.T) {
 	err = index.Delete("nonexistent")
 	assert.Error(t, err)
 }
+
+func TestFlatSaveLoad(t *testing.T) {
+	params := &model.FlatParams{
+		MaxSize: 500,
+	}
+
+	index, err := NewFlat(params, "cosine")
+	assert.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		err := index.Insert(fmt.Sprintf("vec%d", i), []float32{rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32()})
+		assert.NoError(t, err)
+	}
+
+	buf := new(bytes.Buffer)
+	err = index.Save(buf)
+	assert.NoError(t, err)
+
+	loaded, err := NewFlat(params, "cosine")
+	assert.NoError(t, err)
+	err = loaded.Load(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, index.vectors, loaded.vectors)
+
+	// loaded index gives the same results
+	query := []float32{0.05, 0.61, 0.76, 0.74}
+	expected, err := index.Search(query, 5, nil)
+	assert.NoError(t, err)
+	results, err := loaded.Search(query, 5, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, results)
+
+	// snapshot larger than max size
+	small, err := NewFlat(&model.FlatParams{MaxSize: 10}, "cosine")
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, index.Save(buf))
+	assert.Error(t, small.Load(buf))
+}
+
+func TestFlatInt8Quantization(t *testing.T) {
+	params := &model.FlatParams{
+		MaxSize:      5000,
+		Quantization: "int8",
+	}
+
+	index, err := NewFlat(params, "euclidean")
+	assert.NoError(t, err)
+
+	vectors := make([][]float32, 2000)
+	for i := range vectors {
+		vectors[i] = []float32{rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32()}
+		err := index.Insert(fmt.Sprintf("vec%d", i), vectors[i])
+		assert.NoError(t, err)
+	}
+
+	// vectors are quantized once the quantizer is trained
+	assert.NotNil(t, index.quantizer)
+	assert.Empty(t, index.vectors)
+	assert.Len(t, index.codes, 2000)
+
+	// search the same vector
+	results, err := index.Search(vectors[42], 3, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "vec42", results[0].ID)
+	assert.InDelta(t, 0, results[0].Score, 0.01)
+
+	// update and delete quantized vectors
+	err = index.Update("vec42", []float32{0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5})
+	assert.NoError(t, err)
+	results, err = index.Search([]float32{0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5}, 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "vec42", results[0].ID)
+	err = index.Delete("vec42")
+	assert.NoError(t, err)
+	assert.Len(t, index.codes, 1999)
+
+	// snapshot keeps the quantizer
+	buf := new(bytes.Buffer)
+	assert.NoError(t, index.Save(buf))
+	loaded, err := NewFlat(params, "euclidean")
+	assert.NoError(t, err)
+	assert.NoError(t, loaded.Load(buf))
+	assert.Equal(t, index.quantizer, loaded.quantizer)
+	assert.Equal(t, index.codes, loaded.codes)
+
+	// unsupported quantization
+	_, err = NewFlat(&model.FlatParams{MaxSize: 10, Quantization: "int4"}, "euclidean")
+	assert.Error(t, err)
+}
+
+func TestFlatFilter(t *testing.T) {
+	index, err := NewFlat(&model.FlatParams{MaxSize: 1000}, "euclidean")
+	assert.NoError(t, err)
+
+	for i := 0; i < 1000; i++ {
+		err := index.Insert(fmt.Sprintf("vec%d", i), []float32{rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32()})
+		assert.NoError(t, err)
+	}
+
+	// only every 50th vector is accepted, topk results are still found
+	accept := func(id string) bool {
+		n := 0
+		fmt.Sscanf(id, "vec%d", &n)
+		return n%50 == 0
+	}
+	results, err := index.SearchWithFilter([]float32{0.5, 0.5, 0.5, 0.5}, 10, nil, accept)
+	assert.NoError(t, err)
+	assert.Len(t, results, 10)
+	for i, result := range results {
+		assert.True(t, accept(result.ID))
+		if i > 0 {
+			assert.LessOrEqual(t, results[i-1].Score, result.Score)
+		}
+	}
+
+	// no vector accepted
+	results, err = index.SearchWithFilter([]float32{0.5, 0.5, 0.5, 0.5}, 10, nil, func(string) bool { return false })
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
@@ -2,9 +2,12 @@ package hnsw
 
 import (
 	"container/heap"
+	"encoding/gob"
 	"fmt"
+	"io"
 	"math"
 	"math/rand/v2"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"vectordb/model"
@@ -15,6 +18,10 @@ import (
 
 type HNSW struct {
 	distfunc       func([]float32, []float32) float32
+	distfuncInt8   func([]float32, []int8, *pkg.ScalarQuantizer) float32
+	quantization   string               // "int8" stores quantized vectors in the nodes once the quantizer is trained
+	quantizer      *pkg.ScalarQuantizer // nil until trained
+	qmu            sync.RWMutex         // held exclusively while the nodes are being quantized
 	maxSize        int
 	efconstruction int                             // size of dynamic candidate list, the number of nearest neighbors to keep in a priority queue for insertion
 	m              int                             // number of established connections, the number of nearest neighbors to connect a new entry to when it is inserted
@@ -27,24 +34,49 @@ type HNSW struct {
 	maxlevel       atomic.Int32                    // current maximum level used
 	nodes          []*Node                         // all nodes in the hnsw graph
 	nodesidx       cmap.ConcurrentMap[string, int] // map from id to nodes index
+	tombstones     int                             // number of deleted nodes still in the graph
+	compactRatio   float64                         // fraction of tombstoned nodes that triggers a compaction
+	compacting     atomic.Bool                     // whether a background compaction is running
+	compactions    sync.WaitGroup                  // background compactions, waited for by Save and Close
 	mu             sync.RWMutex
 }
 
 type Node struct {
 	id          string
-	vector      []float32
+	vector      []float32 // nil once quantized
+	code        []int8
 	level       int
 	connections [][]string
+	deleted     atomic.Bool // tombstoned nodes keep routing searches but are never returned
 	mu          sync.RWMutex
 }
 
+// hnswSnapshot is the gob encoded state of a hnsw graph, nodes are kept in insertion order
+type hnswSnapshot struct {
+	EntryPoint string
+	MaxLevel   int32
+	Quantizer  *pkg.ScalarQuantizer
+	Nodes      []nodeSnapshot
+}
+
+type nodeSnapshot struct {
+	ID          string
+	Vector      []float32
+	Code        []int8
+	Level       int
+	Connections [][]string
+	Deleted     bool
+}
+
 // set default parameters
 var defaultParams = map[string]interface{}{
-	"ef":             64,
-	"m":              32,
-	"heuristic":      true,
-	"extend":         false,
-	"efconstruction": 64,
+	"ef":               64,
+	"m":                32,
+	"heuristic":        true,
+	"extend":           false,
+	"efconstruction":   64,
+	"qtrainsize":       1000, // number of vectors collected before training the int8 quantizer
+	"compactthreshold": 0.2,
 }
 
 func NewHNSW(params *model.HNSWParams, distance string) (*HNSW, error) {
@@ -59,6 +91,14 @@ func NewHNSW(params *model.HNSWParams, distance string) (*HNSW, error) {
 		extend:         params.Extend,
 		nodes:          []*Node{},
 		nodesidx:       cmap.New[int](),
+		quantization:   params.Quantization,
+		compactRatio:   params.CompactThreshold,
+	}
+	if hnsw.compactRatio == 0 {
+		hnsw.compactRatio = defaultParams["compactthreshold"].(float64)
+	}
+	if hnsw.compactRatio < 0 || hnsw.compactRatio > 1 {
+		return nil, fmt.Errorf("compactthreshold must be between 0 and 1")
 	}
 	switch distance {
 	case "dot":
@@ -70,45 +110,132 @@ func NewHNSW(params *model.HNSWParams, distance string) (*HNSW, error) {
 	default:
 		return nil, fmt.Errorf("invalid distance metric")
 	}
+	switch hnsw.quantization {
+	case "", "none":
+	case "int8":
+		hnsw.distfuncInt8, _ = pkg.GetDistanceFuncInt8(distance)
+	default:
+		return nil, fmt.Errorf("unsupported quantization: '%s'", hnsw.quantization)
+	}
 
 	return hnsw, nil
 }
 
 func (h *HNSW) Insert(id string, vector []float32) error {
-	if len(h.nodes) >= h.maxSize {
-		return fmt.Errorf("hnsw index is full")
+	h.qmu.RLock()
+	train, err := h.insert(id, vector)
+	h.qmu.RUnlock()
+
+	if train {
+		h.train()
 	}
 
+	return err
+}
+
+// insert adds or revives the node, it reports whether enough nodes are inserted to train the
+// quantizer, which is checked under h.mu since concurrent inserts grow h.nodes
+func (h *HNSW) insert(id string, vector []float32) (bool, error) {
 	h.mu.Lock()
+	if len(h.nodes)-h.tombstones >= h.maxSize {
+		h.mu.Unlock()
+		return false, fmt.Errorf("hnsw index is full")
+	}
+
+	if idx, exists := h.nodesidx.Get(id); exists {
+		node := h.nodes[idx]
+		if !node.deleted.Load() {
+			h.mu.Unlock()
+			return false, fmt.Errorf("id %s already exists in index", id)
+		}
+
+		// revive the tombstoned node with the new vector
+		node.deleted.Store(false)
+		h.tombstones--
+		ep := h.entrypoint.Load()
+		currMaxLevel := h.maxlevel.Load()
+		h.mu.Unlock()
+
+		h.connect(node, vector, ep, currMaxLevel, true)
+		return false, nil
+	}
+
 	if len(h.nodes) == 0 {
-		node := newNode(id, vector, 0)
+		node := h.newNode(id, vector, 0)
 		h.nodes = append(h.nodes, node)
 		h.nodesidx.Set(id, len(h.nodes)-1)
 		h.entrypoint.Store(node)
 		h.maxlevel.Store(0)
+		train := h.trainable()
 		h.mu.Unlock()
-		return nil
+		return train, nil
 	}
 
 	level := int(math.Floor(-math.Log(rand.Float64()) * h.ml))
-	node := newNode(id, vector, level)
+	node := h.newNode(id, vector, level)
 	h.nodes = append(h.nodes, node)
 	h.nodesidx.Set(id, len(h.nodes)-1)
 	ep := h.entrypoint.Load()
 	currMaxLevel := h.maxlevel.Load()
+	train := h.trainable()
 	h.mu.Unlock()
 
+	h.connect(node, vector, ep, currMaxLevel, false)
+
+	if level > int(h.maxlevel.Load()) {
+		h.mu.Lock()
+		h.maxlevel.Store(int32(level))
+		h.entrypoint.Store(node)
+		h.mu.Unlock()
+	}
+
+	return train, nil
+}
+
+// trainable expects the caller to hold h.qmu and h.mu
+func (h *HNSW) trainable() bool {
+	return h.quantization == "int8" && h.quantizer == nil && len(h.nodes) >= defaultParams["qtrainsize"].(int)
+}
+
+// connect links a node with its closest neighbours, from its level down to level 0,
+// when relinking an existing node its vector is replaced and its own connections are rebuilt
+func (h *HNSW) connect(node *Node, vector []float32, ep *Node, currMaxLevel int32, relink bool) {
+	level := node.level
+
+	if relink {
+		node.mu.Lock()
+		if h.quantizer != nil {
+			node.code = h.quantizer.Encode(vector)
+		} else {
+			node.vector = vector
+		}
+		node.mu.Unlock()
+	}
+
 	// look up entry point in greedy search, find shortest path from top layer(max level) above the current level
 	for l := currMaxLevel; l > int32(level); l-- {
-		ep = h.searchLayerClosest(node.vector, ep, int(l))
+		ep = h.searchLayerClosest(vector, ep, int(l))
 	}
 
 	// look up closest neighbours and create connections, from the current level to level 0
 	for l := min(level, int(currMaxLevel)); l >= 0; l-- {
-		resultspq := h.searchLayer(node.vector, ep, h.efconstruction, l) // maxpq here
+		resultspq := h.searchLayer(vector, ep, h.efconstruction, l, nil) // maxpq here
+
+		if relink {
+			// the node finds itself through the connections it still has
+			for i, item := range resultspq.Items {
+				if item.Node.(*Node) == node {
+					heap.Remove(resultspq, i)
+					break
+				}
+			}
+			node.mu.Lock()
+			node.connections[l] = nil
+			node.mu.Unlock()
+		}
 
 		if h.heuristic {
-			resultspq = h.selectNeighboursHeuristic(node.vector, resultspq, h.m, l, h.extend, true)
+			resultspq = h.selectNeighboursHeuristic(vector, resultspq, h.m, l, h.extend, true)
 		} else {
 			resultspq = h.selectNeighboursSimple(resultspq, h.m)
 		}
@@ -127,87 +254,82 @@ func (h *HNSW) Insert(id string, vector []float32) error {
 			}
 		}
 	}
+}
 
-	if level > int(h.maxlevel.Load()) {
-		h.mu.Lock()
-		h.maxlevel.Store(int32(level))
-		h.entrypoint.Store(node)
+// Delete tombstones the node, it keeps routing searches until a compaction physically removes it,
+// a background compaction starts once the tombstoned nodes exceed the compaction threshold
+func (h *HNSW) Delete(id string) error {
+	h.mu.Lock()
+	idx, exists := h.nodesidx.Get(id)
+	if !exists || h.nodes[idx].deleted.Load() {
 		h.mu.Unlock()
+		return fmt.Errorf("id %s not found in index", id)
+	}
+
+	h.nodes[idx].deleted.Store(true)
+	h.tombstones++
+	compact := float64(h.tombstones) >= h.compactRatio*float64(len(h.nodes))
+	h.mu.Unlock()
+
+	if compact && h.compacting.CompareAndSwap(false, true) {
+		h.compactions.Add(1)
+		go func() {
+			defer h.compactions.Done()
+			defer h.compacting.Store(false)
+			h.Compact()
+		}()
 	}
 
 	return nil
 }
 
-// Warning: This implementation is not fully tested, may cause connectivity problem and low recall
-func (h *HNSW) Delete(id string) error {
-	h.mu.Lock()
-	defer h.mu.Unlock()
+// Update replaces the vector of a node in place and reconnects it
+func (h *HNSW) Update(id string, vector []float32) error {
+	h.qmu.RLock()
+	defer h.qmu.RUnlock()
 
+	h.mu.RLock()
 	idx, exists := h.nodesidx.Get(id)
-	if !exists {
+	if !exists || h.nodes[idx].deleted.Load() {
+		h.mu.RUnlock()
 		return fmt.Errorf("id %s not found in index", id)
 	}
 	node := h.nodes[idx]
-
 	ep := h.entrypoint.Load()
 	currMaxLevel := h.maxlevel.Load()
-	for l := currMaxLevel; l > int32(node.level); l-- {
-		ep = h.searchLayerClosest(node.vector, ep, int(l))
-	}
-
-	for l := node.level; l >= 0; l-- {
-		resultspq := h.searchLayer(node.vector, ep, h.efconstruction, l)
-		resultspq.SwitchOrder()
-		ep = resultspq.Top().(*pkg.Item).Node.(*Node)
-		for resultspq.Len() > 0 {
-			neighbour := heap.Pop(resultspq).(*pkg.Item).Node.(*Node)
-			neighbour.mu.Lock()
-			newneighbours := []string{}
-			for _, neighbourID := range neighbour.connections[l] {
-				if neighbourID != node.id {
-					newneighbours = append(newneighbours, neighbourID)
-				}
-			}
-			neighbour.connections[l] = newneighbours
-			neighbour.mu.Unlock()
-		}
-		node.mu.Lock()
-		node.connections[l] = nil
-		node.mu.Unlock()
-	}
-
-	// infinity distance
-	node.mu.Lock()
-	for i := 0; i < len(node.vector); i++ {
-		node.vector[i] = float32(math.MaxFloat32)
-	}
-	node.mu.Unlock()
-
-	for i := idx; i < len(h.nodes)-1; i++ {
-		h.nodesidx.Set(h.nodes[i+1].id, i)
-	}
-	copy(h.nodes[idx:], h.nodes[idx+1:])
-	h.nodes = h.nodes[:len(h.nodes)-1]
-	h.nodesidx.Remove(id)
+	h.mu.RUnlock()
 
+	h.connect(node, vector, ep, currMaxLevel, true)
 	return nil
 }
 
-func (h *HNSW) Update(id string, vector []float32) error {
-	h.mu.Lock()
-	_, exists := h.nodesidx.Get(id)
-	h.mu.Unlock()
-	if !exists {
-		return fmt.Errorf("id %s not found in index", id)
-	}
+// IDs returns the ids of the vectors in the index
+func (h *HNSW) IDs() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 
-	h.Delete(id)
-	return h.Insert(id, vector)
+	ids := make([]string, 0, len(h.nodes)-h.tombstones)
+	for _, node := range h.nodes {
+		if alive(node) {
+			ids = append(ids, node.id)
+		}
+	}
+	return ids
 }
 
 func (h *HNSW) Search(vector []float32, topk int, xparams map[string]interface{}) ([]model.SearchResult, error) {
+	return h.SearchWithFilter(vector, topk, xparams, nil)
+}
+
+// SearchWithFilter only returns the nodes accepted by the filter, rejected nodes are
+// still traversed at level 0 until ef accepted nodes are found or the graph is exhausted
+func (h *HNSW) SearchWithFilter(vector []float32, topk int, xparams map[string]interface{}, filter func(id string) bool) ([]model.SearchResult, error) {
+	h.qmu.RLock()
+	defer h.qmu.RUnlock()
+
 	h.mu.RLock()
 	if len(h.nodes) == 0 {
+		h.mu.RUnlock()
 		return nil, nil
 	}
 
@@ -219,6 +341,7 @@ func (h *HNSW) Search(vector []float32, topk int, xparams map[string]interface{}
 		case int:
 			ef = v
 		default:
+			h.mu.RUnlock()
 			return nil, fmt.Errorf("ef parameter must be a number")
 		}
 	}
@@ -231,7 +354,13 @@ func (h *HNSW) Search(vector []float32, topk int, xparams map[string]interface{}
 		ep = h.searchLayerClosest(vector, ep, int(l))
 	}
 
-	resultspq := h.searchLayer(vector, ep, ef, 0) // maxpq here
+	accept := alive
+	if filter != nil {
+		accept = func(node *Node) bool {
+			return alive(node) && filter(node.id)
+		}
+	}
+	resultspq := h.searchLayer(vector, ep, ef, 0, accept) // maxpq here
 
 	if topk > resultspq.Len() {
 		topk = resultspq.Len()
@@ -255,6 +384,15 @@ func (h *HNSW) Search(vector []float32, topk int, xparams map[string]interface{}
 	return results, nil
 }
 
+func (h *HNSW) newNode(id string, vector []float32, level int) *Node {
+	node := newNode(id, vector, level)
+	if h.quantizer != nil {
+		node.vector = nil
+		node.code = h.quantizer.Encode(vector)
+	}
+	return node
+}
+
 func newNode(id string, vector []float32, level int) *Node {
 	node := &Node{
 		id:          id,
@@ -271,7 +409,7 @@ func newNode(id string, vector []float32, level int) *Node {
 }
 
 func (h *HNSW) searchLayerClosest(q []float32, ep *Node, level int) *Node {
-	mindist := h.distfunc(q, ep.vector)
+	mindist := h.distance(q, ep)
 	for {
 		findClosest := false
 		ep.mu.RLock()
@@ -280,7 +418,7 @@ func (h *HNSW) searchLayerClosest(q []float32, ep *Node, level int) *Node {
 		for _, neighbourID := range connections {
 			idx, _ := h.nodesidx.Get(neighbourID)
 			neighbour := h.nodes[idx]
-			if dist := h.distfunc(q, neighbour.vector); dist < mindist {
+			if dist := h.distance(q, neighbour); dist < mindist {
 				mindist = dist
 				ep = neighbour
 				findClosest = true
@@ -293,11 +431,13 @@ func (h *HNSW) searchLayerClosest(q []float32, ep *Node, level int) *Node {
 	return ep
 }
 
-func (h *HNSW) searchLayer(q []float32, ep *Node, ef int, level int) *pkg.PriorityQueue {
+// searchLayer returns the ef closest nodes accepted by the filter, a nil filter accepts every node,
+// rejected nodes are still expanded so that they keep the graph connected
+func (h *HNSW) searchLayer(q []float32, ep *Node, ef int, level int, accept func(*Node) bool) *pkg.PriorityQueue {
 	visited := make(map[string]struct{})
 	visited[ep.id] = struct{}{}
 
-	epitem := pkg.NewItem(ep, h.distfunc(q, ep.vector))
+	epitem := pkg.NewItem(ep, h.distance(q, ep))
 
 	candidates := pkg.NewMinPQ()
 	heap.Init(candidates)
@@ -305,13 +445,22 @@ func (h *HNSW) searchLayer(q []float32, ep *Node, ef int, level int) *pkg.Priori
 
 	results := pkg.NewMaxPQ()
 	heap.Init(results)
-	heap.Push(results, epitem)
+	if accept == nil || accept(ep) {
+		heap.Push(results, epitem)
+	}
+
+	// distance of the farthest result, unbounded while filtered results are still missing
+	farthest := func() float32 {
+		if results.Len() == 0 || accept != nil && results.Len() < ef {
+			return math.MaxFloat32
+		}
+		return results.Top().(*pkg.Item).Distance
+	}
 
 	for candidates.Len() > 0 {
 		candidate := heap.Pop(candidates).(*pkg.Item)
-		farthest := results.Top().(*pkg.Item)
 
-		if candidate.Distance > farthest.Distance {
+		if candidate.Distance > farthest() {
 			break
 		}
 
@@ -326,12 +475,14 @@ func (h *HNSW) searchLayer(q []float32, ep *Node, ef int, level int) *pkg.Priori
 			}
 
 			visited[neighbourID] = struct{}{}
-			dist := h.distfunc(q, neighbour.vector)
+			dist := h.distance(q, neighbour)
 
-			if dist < farthest.Distance || results.Len() < ef {
+			if dist < farthest() || results.Len() < ef {
 				nbitem := pkg.NewItem(neighbour, dist)
 				heap.Push(candidates, nbitem)
-				heap.Push(results, nbitem)
+				if accept == nil || accept(neighbour) {
+					heap.Push(results, nbitem)
+				}
 
 				if results.Len() > ef {
 					heap.Pop(results)
@@ -381,7 +532,7 @@ func (h *HNSW) selectNeighboursHeuristic(q []float32, candidates *pkg.PriorityQu
 					visited[neighbourID] = struct{}{}
 					idx, _ := h.nodesidx.Get(neighbourID)
 					neighbour := h.nodes[idx]
-					heap.Push(candidatesext, pkg.NewItem(neighbour, h.distfunc(q, neighbour.vector)))
+					heap.Push(candidatesext, pkg.NewItem(neighbour, h.distance(q, neighbour)))
 				}
 			}
 		}
@@ -418,7 +569,9 @@ func (h *HNSW) addConnections(node *Node, neighbour *Node, level int) {
 	node.mu.Lock()
 	neighbour.mu.Lock()
 	node.connections[level] = append(node.connections[level], neighbour.id)
-	neighbour.connections[level] = append(neighbour.connections[level], node.id)
+	if !slices.Contains(neighbour.connections[level], node.id) {
+		neighbour.connections[level] = append(neighbour.connections[level], node.id)
+	}
 	neighbour.mu.Unlock()
 	node.mu.Unlock()
 }
@@ -429,15 +582,16 @@ func (h *HNSW) shrink(node *Node, m int, level int) {
 
 	nodeneighbours := pkg.NewMaxPQ()
 	heap.Init(nodeneighbours)
+	vector := h.nodeVector(node)
 
 	for _, neighbourID := range node.connections[level] {
 		idx, _ := h.nodesidx.Get(neighbourID)
 		neighbour := h.nodes[idx]
-		heap.Push(nodeneighbours, pkg.NewItem(neighbour, h.distfunc(neighbour.vector, node.vector)))
+		heap.Push(nodeneighbours, pkg.NewItem(neighbour, h.distance(vector, neighbour)))
 	}
 
 	if h.heuristic {
-		nodeneighbours = h.selectNeighboursHeuristic(node.vector, nodeneighbours, m, level, h.extend, true)
+		nodeneighbours = h.selectNeighboursHeuristic(vector, nodeneighbours, m, level, h.extend, true)
 	} else {
 		nodeneighbours = h.selectNeighboursSimple(nodeneighbours, m)
 	}
@@ -449,3 +603,239 @@ func (h *HNSW) shrink(node *Node, m int, level int) {
 
 	node.connections[level] = newneighbours
 }
+
+// Save waits for a running background compaction so that the snapshot doesn't race with it
+func (h *HNSW) Save(w io.Writer) error {
+	h.compactions.Wait()
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	snap := hnswSnapshot{
+		MaxLevel:  h.maxlevel.Load(),
+		Quantizer: h.quantizer,
+		Nodes:     make([]nodeSnapshot, 0, len(h.nodes)),
+	}
+	if ep := h.entrypoint.Load(); ep != nil {
+		snap.EntryPoint = ep.id
+	}
+
+	for _, node := range h.nodes {
+		node.mu.RLock()
+		connections := make([][]string, len(node.connections))
+		for l := range node.connections {
+			connections[l] = append([]string(nil), node.connections[l]...)
+		}
+		snap.Nodes = append(snap.Nodes, nodeSnapshot{
+			ID:          node.id,
+			Vector:      node.vector,
+			Code:        node.code,
+			Level:       node.level,
+			Connections: connections,
+			Deleted:     node.deleted.Load(),
+		})
+		node.mu.RUnlock()
+	}
+
+	if err := gob.NewEncoder(w).Encode(snap); err != nil {
+		return fmt.Errorf("failed to encode hnsw index: %w", err)
+	}
+	return nil
+}
+
+// Close waits for a running background compaction, the index holds no other resource
+func (h *HNSW) Close() error {
+	h.compactions.Wait()
+	return nil
+}
+
+func (h *HNSW) Load(r io.Reader) error {
+	snap := hnswSnapshot{}
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("failed to decode hnsw index: %w", err)
+	}
+	nodes := make([]*Node, 0, len(snap.Nodes))
+	tombstones := 0
+	nodesidx := cmap.New[int]()
+	var ep *Node
+	for i, ns := range snap.Nodes {
+		if ns.Vector == nil && (ns.Code == nil || snap.Quantizer == nil) {
+			return fmt.Errorf("hnsw index snapshot node %s has no vector", ns.ID)
+		}
+		node := newNode(ns.ID, ns.Vector, ns.Level)
+		node.code = ns.Code
+		node.deleted.Store(ns.Deleted)
+		if ns.Deleted {
+			tombstones++
+		}
+		for l := 0; l < len(ns.Connections) && l <= ns.Level; l++ {
+			node.connections[l] = ns.Connections[l]
+		}
+		nodes = append(nodes, node)
+		nodesidx.Set(ns.ID, i)
+		if ns.ID == snap.EntryPoint {
+			ep = node
+		}
+	}
+	if len(nodes) > 0 && ep == nil {
+		return fmt.Errorf("hnsw index snapshot entry point %s not found", snap.EntryPoint)
+	}
+	if len(nodes)-tombstones > h.maxSize {
+		return fmt.Errorf("hnsw index snapshot exceeds max size")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.quantizer = snap.Quantizer
+	h.nodes = nodes
+	h.nodesidx = nodesidx
+	h.tombstones = tombstones
+	h.entrypoint.Store(ep)
+	h.maxlevel.Store(snap.MaxLevel)
+	return nil
+}
+
+// distance compares a full precision vector with a node, which may be quantized
+func (h *HNSW) distance(q []float32, node *Node) float32 {
+	if node.code != nil {
+		return h.distfuncInt8(q, node.code, h.quantizer)
+	}
+	return h.distfunc(q, node.vector)
+}
+
+// nodeVector returns the vector of a node, decoded if it is quantized
+func (h *HNSW) nodeVector(node *Node) []float32 {
+	if node.code != nil {
+		return h.quantizer.Decode(node.code)
+	}
+	return node.vector
+}
+
+// train learns the int8 quantizer from the vectors inserted so far and quantizes every node,
+// insertions and searches are blocked meanwhile
+func (h *HNSW) train() {
+	h.qmu.Lock()
+	defer h.qmu.Unlock()
+
+	if h.quantizer != nil {
+		return
+	}
+
+	vectors := make([][]float32, 0, len(h.nodes))
+	for _, node := range h.nodes {
+		vectors = append(vectors, node.vector)
+	}
+	quantizer := pkg.TrainScalarQuantizer(vectors)
+
+	for _, node := range h.nodes {
+		node.code = quantizer.Encode(node.vector)
+		node.vector = nil
+	}
+	h.quantizer = quantizer
+}
+
+// Compact reconnects the neighbours of tombstoned nodes and physically removes them,
+// insertions and searches are blocked meanwhile, it returns the number of removed nodes
+func (h *HNSW) Compact() int {
+	h.qmu.Lock()
+	defer h.qmu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.tombstones == 0 {
+		return 0
+	}
+
+	for _, node := range h.nodes {
+		if node.deleted.Load() {
+			continue
+		}
+		vector := h.nodeVector(node)
+		for l := range node.connections {
+			h.repair(node, vector, l)
+		}
+	}
+
+	removed := h.tombstones
+	nodes := make([]*Node, 0, len(h.nodes)-removed)
+	nodesidx := cmap.New[int]()
+	ep := h.entrypoint.Load()
+	if ep.deleted.Load() {
+		ep = nil
+	}
+	for _, node := range h.nodes {
+		if node.deleted.Load() {
+			continue
+		}
+		nodesidx.Set(node.id, len(nodes))
+		nodes = append(nodes, node)
+		if ep == nil || node.level > ep.level {
+			ep = node
+		}
+	}
+
+	h.nodes = nodes
+	h.nodesidx = nodesidx
+	h.tombstones = 0
+	h.entrypoint.Store(ep)
+	if ep != nil {
+		h.maxlevel.Store(int32(ep.level))
+	} else {
+		h.maxlevel.Store(0)
+	}
+
+	return removed
+}
+
+// repair replaces the tombstoned connections of a node at the level with their live neighbours
+func (h *HNSW) repair(node *Node, vector []float32, level int) {
+	repaired := false
+	candidates := make(map[string]*Node)
+	for _, neighbourID := range node.connections[level] {
+		idx, _ := h.nodesidx.Get(neighbourID)
+		neighbour := h.nodes[idx]
+		if !neighbour.deleted.Load() {
+			candidates[neighbourID] = neighbour
+			continue
+		}
+
+		repaired = true
+		for _, id := range neighbour.connections[level] {
+			idx, _ := h.nodesidx.Get(id)
+			if n := h.nodes[idx]; n != node && !n.deleted.Load() {
+				candidates[id] = n
+			}
+		}
+	}
+	if !repaired {
+		return
+	}
+
+	resultspq := pkg.NewMaxPQ()
+	heap.Init(resultspq)
+	for _, candidate := range candidates {
+		heap.Push(resultspq, pkg.NewItem(candidate, h.distance(vector, candidate)))
+	}
+
+	mm := h.mmax
+	if level == 0 {
+		mm = h.mmax0
+	}
+	if h.heuristic {
+		resultspq = h.selectNeighboursHeuristic(vector, resultspq, mm, level, false, true)
+	} else {
+		resultspq = h.selectNeighboursSimple(resultspq, mm)
+	}
+
+	connections := make([]string, 0, resultspq.Len())
+	for _, item := range resultspq.Items {
+		connections = append(connections, item.Node.(*Node).id)
+	}
+	node.connections[level] = connections
+}
+
+func alive(node *Node) bool {
+	return !node.deleted.Load()
+}
@@ -1,8 +1,10 @@
 package hnsw
 
 import (
+	"bytes"
 	"fmt"
 	"math/rand/v2"
+	"sort"
 	"sync"
 	"testing"
 	"vectordb/model"
@@ -96,6 +98,283 @@ func TestHNSWEdgeCases(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestHNSWSaveLoad(t *testing.T) {
+	params := &model.HNSWParams{
+		EfConstruction: 16,
+		MMax:           5,
+		Heuristic:      true,
+		MaxSize:        1000,
+	}
+
+	index, err := NewHNSW(params, "cosine")
+	assert.NoError(t, err)
+
+	for i := 0; i < 1000; i++ {
+		err := index.Insert(fmt.Sprintf("vec%d", i), []float32{rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32()})
+		assert.NoError(t, err)
+	}
+
+	buf := new(bytes.Buffer)
+	err = index.Save(buf)
+	assert.NoError(t, err)
+
+	loaded, err := NewHNSW(params, "cosine")
+	assert.NoError(t, err)
+	err = loaded.Load(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, len(index.nodes), len(loaded.nodes))
+	assert.Equal(t, index.entrypoint.Load().id, loaded.entrypoint.Load().id)
+	assert.Equal(t, index.maxlevel.Load(), loaded.maxlevel.Load())
+
+	// loaded graph gives the same results
+	query := []float32{0.05, 0.61, 0.76, 0.74}
+	expected, err := index.Search(query, 5, map[string]any{"ef": 32})
+	assert.NoError(t, err)
+	results, err := loaded.Search(query, 5, map[string]any{"ef": 32})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, results)
+
+	// loaded graph accepts further insertions
+	err = loaded.Delete("vec0")
+	assert.NoError(t, err)
+	err = loaded.Insert("vec0", []float32{0.05, 0.61, 0.76, 0.74})
+	assert.NoError(t, err)
+	results, err = loaded.Search(query, 1, map[string]any{"ef": 32})
+	assert.NoError(t, err)
+	assert.Equal(t, "vec0", results[0].ID)
+}
+
+func TestHNSWInt8Quantization(t *testing.T) {
+	params := &model.HNSWParams{
+		EfConstruction: 32,
+		MMax:           8,
+		Heuristic:      true,
+		MaxSize:        3000,
+		Quantization:   "int8",
+	}
+
+	index, err := NewHNSW(params, "euclidean")
+	assert.NoError(t, err)
+
+	vectors := make([][]float32, 2000)
+	for i := range vectors {
+		vectors[i] = []float32{rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32()}
+		err := index.Insert(fmt.Sprintf("vec%d", i), vectors[i])
+		assert.NoError(t, err)
+	}
+
+	// nodes are quantized once the quantizer is trained
+	assert.NotNil(t, index.quantizer)
+	for _, node := range index.nodes {
+		assert.Nil(t, node.vector)
+		assert.Len(t, node.code, 8)
+	}
+
+	// search the same vector
+	results, err := index.Search(vectors[42], 3, map[string]any{"ef": 64})
+	assert.NoError(t, err)
+	assert.Equal(t, "vec42", results[0].ID)
+	assert.InDelta(t, 0, results[0].Score, 0.01)
+
+	// snapshot keeps the quantized nodes
+	buf := new(bytes.Buffer)
+	assert.NoError(t, index.Save(buf))
+	loaded, err := NewHNSW(params, "euclidean")
+	assert.NoError(t, err)
+	assert.NoError(t, loaded.Load(buf))
+	assert.Equal(t, index.quantizer, loaded.quantizer)
+	results, err = loaded.Search(vectors[42], 3, map[string]any{"ef": 64})
+	assert.NoError(t, err)
+	assert.Equal(t, "vec42", results[0].ID)
+}
+
+func TestHNSWTombstones(t *testing.T) {
+	params := &model.HNSWParams{
+		EfConstruction: 32,
+		MMax:           8,
+		Heuristic:      true,
+		MaxSize:        1000,
+		// compact explicitly below
+		CompactThreshold: 1,
+	}
+
+	index, err := NewHNSW(params, "euclidean")
+	assert.NoError(t, err)
+
+	vectors := make([][]float32, 1000)
+	for i := range vectors {
+		vectors[i] = []float32{rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32()}
+		assert.NoError(t, index.Insert(fmt.Sprintf("vec%d", i), vectors[i]))
+	}
+
+	// deleted nodes are tombstoned and skipped by search
+	for i := 0; i < 300; i++ {
+		assert.NoError(t, index.Delete(fmt.Sprintf("vec%d", i)))
+	}
+	assert.Error(t, index.Delete("vec0"))
+	assert.Error(t, index.Update("vec0", vectors[0]))
+	assert.Len(t, index.nodes, 1000)
+	assert.Equal(t, 300, index.tombstones)
+
+	for i := 0; i < 300; i += 10 {
+		results, err := index.Search(vectors[i], 10, map[string]any{"ef": 32})
+		assert.NoError(t, err)
+		assert.Len(t, results, 10)
+		for _, result := range results {
+			id := 0
+			fmt.Sscanf(result.ID, "vec%d", &id)
+			assert.GreaterOrEqual(t, id, 300)
+		}
+	}
+
+	// the index is full again once the deleted ids are revived
+	for i := 0; i < 300; i++ {
+		assert.NoError(t, index.Insert(fmt.Sprintf("vec%d", i), vectors[i]))
+	}
+	assert.Error(t, index.Insert("vec1000", vectors[0]))
+	assert.Equal(t, 0, index.tombstones)
+	results, err := index.Search(vectors[5], 1, map[string]any{"ef": 32})
+	assert.NoError(t, err)
+	assert.Equal(t, "vec5", results[0].ID)
+
+	// compaction reconnects the neighbours and removes the tombstoned nodes
+	for i := 0; i < 300; i++ {
+		assert.NoError(t, index.Delete(fmt.Sprintf("vec%d", i)))
+	}
+	assert.Equal(t, 300, index.Compact())
+	assert.Len(t, index.nodes, 700)
+	assert.Equal(t, 0, index.tombstones)
+	assert.False(t, index.entrypoint.Load().deleted.Load())
+	for i, node := range index.nodes {
+		idx, _ := index.nodesidx.Get(node.id)
+		assert.Equal(t, i, idx)
+		for _, connections := range node.connections {
+			for _, id := range connections {
+				_, exists := index.nodesidx.Get(id)
+				assert.True(t, exists)
+			}
+		}
+	}
+
+	found := 0
+	for i := 300; i < 1000; i++ {
+		results, err := index.Search(vectors[i], 1, map[string]any{"ef": 32})
+		assert.NoError(t, err)
+		if results[0].ID == fmt.Sprintf("vec%d", i) {
+			found++
+		}
+	}
+	assert.GreaterOrEqual(t, found, 690)
+
+	// tombstones survive a snapshot
+	assert.NoError(t, index.Delete("vec500"))
+	buf := new(bytes.Buffer)
+	assert.NoError(t, index.Save(buf))
+	loaded, err := NewHNSW(params, "euclidean")
+	assert.NoError(t, err)
+	assert.NoError(t, loaded.Load(buf))
+	assert.Equal(t, 1, loaded.tombstones)
+	results, err = loaded.Search(vectors[500], 1, map[string]any{"ef": 32})
+	assert.NoError(t, err)
+	assert.NotEqual(t, "vec500", results[0].ID)
+}
+
+func TestHNSWBackgroundCompaction(t *testing.T) {
+	params := &model.HNSWParams{
+		EfConstruction:   32,
+		MMax:             8,
+		Heuristic:        true,
+		MaxSize:          1000,
+		CompactThreshold: 0.1,
+	}
+
+	index, err := NewHNSW(params, "cosine")
+	assert.NoError(t, err)
+
+	vectors := make([][]float32, 1000)
+	for i := range vectors {
+		vectors[i] = []float32{rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32()}
+		assert.NoError(t, index.Insert(fmt.Sprintf("vec%d", i), vectors[i]))
+	}
+
+	// searches keep running while nodes are deleted and compacted
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			_, err := index.Search(vectors[i], 5, nil)
+			assert.NoError(t, err)
+		}
+	}()
+	for i := 0; i < 150; i++ {
+		assert.NoError(t, index.Delete(fmt.Sprintf("vec%d", i)))
+	}
+	wg.Wait()
+
+	// Close waits for the background compaction
+	assert.NoError(t, index.Close())
+	assert.False(t, index.compacting.Load())
+	assert.Less(t, len(index.nodes), 1000)
+
+	index.Compact()
+	assert.Len(t, index.nodes, 850)
+}
+
+func TestHNSWFilter(t *testing.T) {
+	params := &model.HNSWParams{
+		EfConstruction: 32,
+		MMax:           8,
+		Heuristic:      true,
+		MaxSize:        2000,
+	}
+
+	index, err := NewHNSW(params, "euclidean")
+	assert.NoError(t, err)
+
+	vectors := make([][]float32, 2000)
+	for i := range vectors {
+		vectors[i] = []float32{rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32()}
+		assert.NoError(t, index.Insert(fmt.Sprintf("vec%d", i), vectors[i]))
+	}
+	assert.NoError(t, index.Delete("vec0"))
+
+	// only every 20th node is accepted, the traversal goes on until ef accepted nodes are found
+	accept := func(id string) bool {
+		n := 0
+		fmt.Sscanf(id, "vec%d", &n)
+		return n%20 == 0
+	}
+	query := []float32{0.5, 0.5, 0.5, 0.5}
+	results, err := index.SearchWithFilter(query, 10, map[string]any{"ef": 32}, accept)
+	assert.NoError(t, err)
+	assert.Len(t, results, 10)
+
+	exact := []model.SearchResult{}
+	for i := 20; i < len(vectors); i += 20 {
+		exact = append(exact, model.SearchResult{ID: fmt.Sprintf("vec%d", i), Score: index.distfunc(query, vectors[i])})
+	}
+	sort.Slice(exact, func(i, j int) bool {
+		return exact[i].Score < exact[j].Score
+	})
+	hits := 0
+	for _, result := range results {
+		assert.True(t, accept(result.ID))
+		assert.NotEqual(t, "vec0", result.ID)
+		for _, e := range exact[:10] {
+			if e.ID == result.ID {
+				hits++
+			}
+		}
+	}
+	assert.GreaterOrEqual(t, hits, 8)
+
+	// no node accepted
+	results, err = index.SearchWithFilter(query, 10, nil, func(string) bool { return false })
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
+
 // RUN: go test -timeout 60m -count 50 -v -run ^TestConcurreny$ vectordb/db/index/hnsw
 func TestConcurreny(t *testing.T) {
 	params := &model.HNSWParams{
@@ -2,26 +2,62 @@ package index
 
 import (
 	"fmt"
+	"io"
+	"vectordb/db/index/binary"
 	"vectordb/db/index/flat"
 	"vectordb/db/index/hnsw"
+	"vectordb/db/index/ivf"
+	"vectordb/db/index/pq"
+	"vectordb/db/index/vamana"
 	"vectordb/model"
 )
 
-// todo: snapshot to load and save index?
 type Indexer interface {
 	Insert(id string, vector []float32) error
 	Delete(id string) error
 	Update(id string, vector []float32) error
 	Search(vector []float32, topk int, xparams map[string]interface{}) ([]model.SearchResult, error)
+	Save(w io.Writer) error // write a snapshot of the index state
+	Load(r io.Reader) error // replace the index state with a snapshot
 }
 
-func NewIndexer(cfg *model.CfgCollection) (Indexer, error) {
+// Reranker is implemented by indexes whose scores are not in the collection's dist_type,
+// their results are always rescored against the full precision vectors
+type Reranker interface {
+	RequiresRerank() bool
+}
+
+// Filterer is implemented by indexes that skip the ids rejected by a filter while searching,
+// so that filtered searches still find topk results instead of filtering them afterwards
+type Filterer interface {
+	SearchWithFilter(vector []float32, topk int, xparams map[string]interface{}, filter func(id string) bool) ([]model.SearchResult, error)
+}
+
+// Lister is implemented by indexes that can enumerate their ids, it lets fsck find vectors
+// whose objects are gone
+type Lister interface {
+	IDs() []string
+}
+
+// NewIndexer creates the index of a collection, path is where on-disk indexes keep their data file
+func NewIndexer(cfg *model.CfgCollection, path string) (Indexer, error) {
+	switch cfg.Quantization {
+	case "", "none":
+	case "int8":
+		if cfg.IndexType != "flat" && cfg.IndexType != "hnsw" {
+			return nil, fmt.Errorf("quantization '%s' is not supported by index type '%s'", cfg.Quantization, cfg.IndexType)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported quantization: '%s'", cfg.Quantization)
+	}
+
 	switch cfg.IndexType {
 	case "flat":
 		params, err := model.ValidateAndConvert(cfg.IndexType, cfg.IndexParams)
 		if err != nil {
 			return nil, err
 		}
+		params.(*model.FlatParams).Quantization = cfg.Quantization
 		idx, err := flat.NewFlat(params.(*model.FlatParams), cfg.Distance)
 		if err != nil {
 			return nil, err
@@ -32,11 +68,62 @@ func NewIndexer(cfg *model.CfgCollection) (Indexer, error) {
 		if err != nil {
 			return nil, err
 		}
+		params.(*model.HNSWParams).Quantization = cfg.Quantization
 		idx, err := hnsw.NewHNSW(params.(*model.HNSWParams), cfg.Distance)
 		if err != nil {
 			return nil, err
 		}
 		return idx, nil
+	case "ivf":
+		params, err := model.ValidateAndConvert(cfg.IndexType, cfg.IndexParams)
+		if err != nil {
+			return nil, err
+		}
+		idx, err := ivf.NewIVF(params.(*model.IVFParams), cfg.Distance)
+		if err != nil {
+			return nil, err
+		}
+		return idx, nil
+	case "binary":
+		params, err := model.ValidateAndConvert(cfg.IndexType, cfg.IndexParams)
+		if err != nil {
+			return nil, err
+		}
+		idx, err := binary.NewBinary(params.(*model.BinaryParams), cfg.Distance)
+		if err != nil {
+			return nil, err
+		}
+		return idx, nil
+	case "pq":
+		params, err := model.ValidateAndConvert(cfg.IndexType, cfg.IndexParams)
+		if err != nil {
+			return nil, err
+		}
+		idx, err := pq.NewPQ(params.(*model.PQParams), cfg.Dimension, cfg.Distance)
+		if err != nil {
+			return nil, err
+		}
+		return idx, nil
+	case "ivfpq":
+		params, err := model.ValidateAndConvert(cfg.IndexType, cfg.IndexParams)
+		if err != nil {
+			return nil, err
+		}
+		idx, err := pq.NewIVFPQ(params.(*model.IVFPQParams), cfg.Dimension, cfg.Distance)
+		if err != nil {
+			return nil, err
+		}
+		return idx, nil
+	case "vamana":
+		params, err := model.ValidateAndConvert(cfg.IndexType, cfg.IndexParams)
+		if err != nil {
+			return nil, err
+		}
+		idx, err := vamana.NewVamana(params.(*model.VamanaParams), cfg.Dimension, cfg.Distance, path)
+		if err != nil {
+			return nil, err
+		}
+		return idx, nil
 	default:
 		return nil, fmt.Errorf("unsupported index type: '%s'", cfg.IndexType)
 	}
@@ -0,0 +1,298 @@
+package ivf
+
+import (
+	"container/heap"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"vectordb/model"
+	"vectordb/pkg"
+)
+
+type IVF struct {
+	distfunc  func([]float32, []float32) float32
+	maxSize   int
+	nlist     int                    // number of inverted lists, each one belongs to a centroid
+	trainSize int                    // number of vectors to collect before training the centroids
+	centroids [][]float32            // k-means centroids, empty until trained
+	lists     []map[string][]float32 // inverted lists, a single list holds everything until trained
+	assign    map[string]int         // map from id to inverted list
+	mu        sync.RWMutex
+}
+
+// ivfSnapshot is the gob encoded state of an ivf index
+type ivfSnapshot struct {
+	Centroids [][]float32
+	Lists     []map[string][]float32
+}
+
+// set default parameters
+var defaultParams = map[string]interface{}{
+	"nlist":      100,
+	"nprobe":     8,
+	"iterations": 20,
+	"trainratio": 39, // vectors per centroid used for training
+}
+
+func NewIVF(params *model.IVFParams, distance string) (*IVF, error) {
+	nlist := params.NList
+	if nlist == 0 {
+		nlist = defaultParams["nlist"].(int)
+	}
+	if nlist < 0 {
+		return nil, fmt.Errorf("nlist must be positive")
+	}
+	trainSize := params.TrainSize
+	if trainSize == 0 {
+		trainSize = nlist * defaultParams["trainratio"].(int)
+	}
+	if trainSize < nlist {
+		return nil, fmt.Errorf("trainsize must be at least nlist")
+	}
+
+	ivf := &IVF{
+		maxSize:   params.MaxSize,
+		nlist:     nlist,
+		trainSize: trainSize,
+		lists:     []map[string][]float32{make(map[string][]float32)},
+		assign:    make(map[string]int),
+	}
+	switch distance {
+	case "dot":
+		ivf.distfunc = pkg.DotDistance
+	case "cosine":
+		ivf.distfunc = pkg.CosineDistance
+	case "euclidean":
+		ivf.distfunc = pkg.EuclideanDistance
+	default:
+		return nil, fmt.Errorf("invalid distance metric")
+	}
+
+	return ivf, nil
+}
+
+func (f *IVF) Insert(id string, vector []float32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.assign) >= f.maxSize {
+		return fmt.Errorf("ivf index is full")
+	}
+	if _, exists := f.assign[id]; exists {
+		return fmt.Errorf("id %s already exists in index", id)
+	}
+
+	f.add(id, vector)
+
+	// train once enough vectors are collected, this blocks the index for a while
+	if len(f.centroids) == 0 && len(f.assign) >= f.trainSize {
+		f.train()
+	}
+
+	return nil
+}
+
+func (f *IVF) Delete(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	list, exists := f.assign[id]
+	if !exists {
+		return fmt.Errorf("id %s not found in index", id)
+	}
+
+	delete(f.lists[list], id)
+	delete(f.assign, id)
+	return nil
+}
+
+func (f *IVF) Update(id string, vector []float32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	list, exists := f.assign[id]
+	if !exists {
+		return fmt.Errorf("id %s not found in index", id)
+	}
+
+	// the vector may belong to another centroid now
+	delete(f.lists[list], id)
+	f.add(id, vector)
+	return nil
+}
+
+// IDs returns the ids of the vectors in the index
+func (f *IVF) IDs() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	ids := make([]string, 0, len(f.assign))
+	for id := range f.assign {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (f *IVF) Search(vector []float32, topk int, xparams map[string]interface{}) ([]model.SearchResult, error) {
+	return f.SearchWithFilter(vector, topk, xparams, nil)
+}
+
+// SearchWithFilter skips the vectors rejected by the filter, lists beyond nprobe
+// are probed until topk accepted vectors are found
+func (f *IVF) SearchWithFilter(vector []float32, topk int, xparams map[string]interface{}, filter func(id string) bool) ([]model.SearchResult, error) {
+	nprobe := defaultParams["nprobe"].(int)
+	if value, exists := xparams["nprobe"]; exists {
+		switch v := value.(type) {
+		case float64:
+			nprobe = int(v)
+		case int:
+			nprobe = v
+		default:
+			return nil, fmt.Errorf("nprobe parameter must be a number")
+		}
+	}
+	if nprobe <= 0 {
+		return nil, fmt.Errorf("nprobe parameter must be positive")
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	resultspq := pkg.NewMaxPQ()
+	heap.Init(resultspq)
+
+	for i, list := range f.probe(vector, len(f.lists)) {
+		if i >= nprobe && (filter == nil || resultspq.Len() >= topk) {
+			break
+		}
+		for id, storedVector := range f.lists[list] {
+			if filter != nil && !filter(id) {
+				continue
+			}
+			dist := f.distfunc(vector, storedVector)
+			if resultspq.Len() < topk {
+				heap.Push(resultspq, pkg.NewItem(id, dist))
+			} else if dist < resultspq.Top().(*pkg.Item).Distance {
+				heap.Pop(resultspq)
+				heap.Push(resultspq, pkg.NewItem(id, dist))
+			}
+		}
+	}
+
+	resultspq.SwitchOrder() // switch to minpq
+
+	results := make([]model.SearchResult, 0, resultspq.Len())
+	for resultspq.Len() > 0 {
+		item := heap.Pop(resultspq).(*pkg.Item)
+		results = append(results, model.SearchResult{
+			ID:    item.Node.(string),
+			Score: item.Distance,
+		})
+	}
+
+	return results, nil
+}
+
+func (f *IVF) Save(w io.Writer) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	snap := ivfSnapshot{
+		Centroids: f.centroids,
+		Lists:     f.lists,
+	}
+	if err := gob.NewEncoder(w).Encode(snap); err != nil {
+		return fmt.Errorf("failed to encode ivf index: %w", err)
+	}
+	return nil
+}
+
+func (f *IVF) Load(r io.Reader) error {
+	snap := ivfSnapshot{}
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("failed to decode ivf index: %w", err)
+	}
+
+	// untrained indexes hold a single list
+	if len(snap.Centroids) == 0 && len(snap.Lists) != 1 || len(snap.Centroids) > 0 && len(snap.Centroids) != len(snap.Lists) {
+		return fmt.Errorf("ivf index snapshot is inconsistent")
+	}
+
+	assign := make(map[string]int)
+	for i := range snap.Lists {
+		if snap.Lists[i] == nil {
+			snap.Lists[i] = make(map[string][]float32)
+		}
+		for id := range snap.Lists[i] {
+			assign[id] = i
+		}
+	}
+	if len(assign) > f.maxSize {
+		return fmt.Errorf("ivf index snapshot exceeds max size")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.centroids = snap.Centroids
+	f.lists = snap.Lists
+	f.assign = assign
+	return nil
+}
+
+// add puts the vector into the inverted list of its nearest centroid
+func (f *IVF) add(id string, vector []float32) {
+	list := 0
+	if len(f.centroids) > 0 {
+		list = pkg.NearestCentroid(vector, f.centroids, f.distfunc)
+	}
+	f.lists[list][id] = vector
+	f.assign[id] = list
+}
+
+// train clusters the collected vectors and redistributes them into the inverted lists
+func (f *IVF) train() {
+	vectors := make([][]float32, 0, len(f.assign))
+	for _, list := range f.lists {
+		for _, vector := range list {
+			vectors = append(vectors, vector)
+		}
+	}
+
+	f.centroids = pkg.KMeans(vectors, f.nlist, defaultParams["iterations"].(int), f.distfunc)
+
+	old := f.lists
+	f.lists = make([]map[string][]float32, len(f.centroids))
+	for i := range f.lists {
+		f.lists[i] = make(map[string][]float32)
+	}
+	for _, list := range old {
+		for id, vector := range list {
+			f.add(id, vector)
+		}
+	}
+}
+
+// probe returns the inverted lists of the nprobe nearest centroids
+func (f *IVF) probe(vector []float32, nprobe int) []int {
+	if len(f.centroids) == 0 {
+		return []int{0}
+	}
+
+	lists := make([]int, len(f.centroids))
+	dists := make([]float32, len(f.centroids))
+	for i, c := range f.centroids {
+		lists[i] = i
+		dists[i] = f.distfunc(vector, c)
+	}
+	sort.Slice(lists, func(i, j int) bool {
+		return dists[lists[i]] < dists[lists[j]]
+	})
+
+	if nprobe > len(lists) {
+		nprobe = len(lists)
+	}
+	return lists[:nprobe]
+}
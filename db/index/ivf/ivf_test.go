@@ -0,0 +1,170 @@
+package ivf
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIVFOperations(t *testing.T) {
+	params := &model.IVFParams{
+		NList:     2,
+		TrainSize: 4,
+		MaxSize:   500,
+	}
+
+	index, err := NewIVF(params, "cosine")
+	assert.NoError(t, err)
+	assert.NotNil(t, index)
+
+	vectors := make(map[string][]float32)
+	vectors["vec0"] = []float32{0.05, 0.61, 0.76, 0.74}
+	vectors["vec1"] = []float32{0.19, 0.81, 0.75, 0.11}
+	vectors["vec2"] = []float32{0.36, 0.55, 0.47, 0.94}
+	vectors["vec3"] = []float32{0.18, 0.01, 0.85, 0.80}
+	vectors["vec4"] = []float32{0.24, 0.18, 0.22, 0.44}
+	vectors["vec5"] = []float32{0.35, 0.08, 0.11, 0.44}
+
+	// insert, centroids are trained on the way
+	for id, vec := range vectors {
+		err := index.Insert(id, vec)
+		assert.NoError(t, err)
+	}
+	assert.Len(t, index.centroids, 2)
+
+	// search the same vector
+	testID := "vec4"
+	testVector := vectors[testID]
+	results, err := index.Search(testVector, 3, map[string]any{"nprobe": 2})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, results)
+	assert.Equal(t, testID, results[0].ID)
+
+	// update
+	updatedVector := []float32{0.25, 0.18, 0.27, 0.45}
+	err = index.Update(testID, updatedVector)
+	assert.NoError(t, err)
+
+	// search
+	results, err = index.Search([]float32{0.27, 0.17, 0.26, 0.45}, 3, map[string]any{"nprobe": 2})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, results)
+	assert.Equal(t, testID, results[0].ID)
+
+	// delete
+	err = index.Delete(testID)
+	assert.NoError(t, err)
+	results, err = index.Search(updatedVector, 3, map[string]any{"nprobe": 2})
+	assert.NoError(t, err)
+	for _, result := range results {
+		assert.NotEqual(t, testID, result.ID)
+	}
+}
+
+func TestIVFEdgeCases(t *testing.T) {
+	params := &model.IVFParams{
+		NList:   16,
+		MaxSize: 3000,
+	}
+
+	index, err := NewIVF(params, "euclidean")
+	assert.NoError(t, err)
+
+	// search before training
+	results, err := index.Search([]float32{0.05, 0.61, 0.76, 0.74}, 5, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	// test max size limit
+	for i := 0; i <= 3000; i++ {
+		err := index.Insert(fmt.Sprintf("vec%d", i), []float32{rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32()})
+		if i < 3000 {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+
+	// test default nprobe
+	results, err = index.Search([]float32{0.05, 0.61, 0.76, 0.74}, 5, nil)
+	assert.NoError(t, err)
+	assert.Len(t, results, 5)
+
+	// probing every list is exact
+	results, err = index.Search([]float32{0.05, 0.61, 0.76, 0.74}, 5, map[string]any{"nprobe": 16})
+	assert.NoError(t, err)
+	for i := 1; i < len(results); i++ {
+		assert.LessOrEqual(t, results[i-1].Score, results[i].Score)
+	}
+
+	// invalid nprobe
+	_, err = index.Search([]float32{0.05, 0.61, 0.76, 0.74}, 5, map[string]any{"nprobe": "all"})
+	assert.Error(t, err)
+
+	// duplicate and non-existent vector
+	err = index.Insert("vec0", []float32{0.05, 0.61, 0.76, 0.74})
+	assert.Error(t, err)
+	err = index.Delete("nonexistent")
+	assert.Error(t, err)
+}
+
+func TestIVFSaveLoad(t *testing.T) {
+	params := &model.IVFParams{
+		NList:   8,
+		MaxSize: 1000,
+	}
+
+	index, err := NewIVF(params, "cosine")
+	assert.NoError(t, err)
+
+	for i := 0; i < 1000; i++ {
+		err := index.Insert(fmt.Sprintf("vec%d", i), []float32{rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32()})
+		assert.NoError(t, err)
+	}
+
+	buf := new(bytes.Buffer)
+	err = index.Save(buf)
+	assert.NoError(t, err)
+
+	loaded, err := NewIVF(params, "cosine")
+	assert.NoError(t, err)
+	err = loaded.Load(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, index.centroids, loaded.centroids)
+	assert.Equal(t, index.assign, loaded.assign)
+
+	// loaded index gives the same results
+	query := []float32{0.05, 0.61, 0.76, 0.74}
+	expected, err := index.Search(query, 5, nil)
+	assert.NoError(t, err)
+	results, err := loaded.Search(query, 5, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, results)
+}
+
+func TestIVFFilter(t *testing.T) {
+	index, err := NewIVF(&model.IVFParams{NList: 16, TrainSize: 500, MaxSize: 2000}, "euclidean")
+	assert.NoError(t, err)
+
+	for i := 0; i < 2000; i++ {
+		err := index.Insert(fmt.Sprintf("vec%d", i), []float32{rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32()})
+		assert.NoError(t, err)
+	}
+
+	// a single probed list rarely holds 10 accepted vectors, more lists are probed
+	accept := func(id string) bool {
+		n := 0
+		fmt.Sscanf(id, "vec%d", &n)
+		return n%100 == 0
+	}
+	results, err := index.SearchWithFilter([]float32{0.5, 0.5, 0.5, 0.5}, 10, map[string]any{"nprobe": 1}, accept)
+	assert.NoError(t, err)
+	assert.Len(t, results, 10)
+	for _, result := range results {
+		assert.True(t, accept(result.ID))
+	}
+}
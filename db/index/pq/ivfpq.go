@@ -0,0 +1,320 @@
+package pq
+
+import (
+	"container/heap"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"vectordb/model"
+	"vectordb/pkg"
+)
+
+// IVFPQ partitions the vectors into inverted lists like ivf and stores pq codes in the lists,
+// codes encode the raw vectors so a single distance table is shared by all probed lists
+type IVFPQ struct {
+	distance  string
+	distfunc  func([]float32, []float32) float32
+	maxSize   int
+	nlist     int                  // number of inverted lists, each one belongs to a centroid
+	trainSize int                  // number of vectors to collect before training
+	centroids [][]float32          // k-means centroids, empty until trained
+	quantizer *Quantizer           // codebooks, untrained until trainSize vectors are collected
+	lists     []map[string]code    // inverted lists of encoded vectors
+	assign    map[string]int       // map from id to inverted list
+	pending   map[string][]float32 // full precision vectors collected before training
+	mu        sync.RWMutex
+}
+
+// ivfpqSnapshot is the gob encoded state of an ivfpq index
+type ivfpqSnapshot struct {
+	Centroids [][]float32
+	Codebooks [][][]float32
+	Lists     []map[string]code
+	Pending   map[string][]float32
+}
+
+func NewIVFPQ(params *model.IVFPQParams, dimension int, distance string) (*IVFPQ, error) {
+	nlist, m, nbits := params.NList, params.M, params.NBits
+	if nlist == 0 {
+		nlist = defaultParams["nlist"].(int)
+	}
+	if nlist < 0 {
+		return nil, fmt.Errorf("nlist must be positive")
+	}
+	if m == 0 {
+		m = defaultParams["m"].(int)
+	}
+	if nbits == 0 {
+		nbits = defaultParams["nbits"].(int)
+	}
+	quantizer, err := NewQuantizer(dimension, m, nbits)
+	if err != nil {
+		return nil, err
+	}
+
+	trainSize := params.TrainSize
+	if trainSize == 0 {
+		trainSize = max(nlist, quantizer.Ksub) * defaultParams["trainratio"].(int)
+	}
+	if trainSize < nlist || trainSize < quantizer.Ksub {
+		return nil, fmt.Errorf("trainsize must be at least nlist and 2^nbits")
+	}
+
+	distfunc, err := pkg.GetDistanceFunc(distance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IVFPQ{
+		distance:  distance,
+		distfunc:  distfunc,
+		maxSize:   params.MaxSize,
+		nlist:     nlist,
+		trainSize: trainSize,
+		quantizer: quantizer,
+		assign:    make(map[string]int),
+		pending:   make(map[string][]float32),
+	}, nil
+}
+
+func (f *IVFPQ) Insert(id string, vector []float32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.size() >= f.maxSize {
+		return fmt.Errorf("ivfpq index is full")
+	}
+	if f.exists(id) {
+		return fmt.Errorf("id %s already exists in index", id)
+	}
+
+	f.add(id, vector)
+
+	// train once enough vectors are collected, this blocks the index for a while
+	if len(f.centroids) == 0 && len(f.pending) >= f.trainSize {
+		f.train()
+	}
+
+	return nil
+}
+
+func (f *IVFPQ) Delete(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.exists(id) {
+		return fmt.Errorf("id %s not found in index", id)
+	}
+
+	f.remove(id)
+	return nil
+}
+
+func (f *IVFPQ) Update(id string, vector []float32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.exists(id) {
+		return fmt.Errorf("id %s not found in index", id)
+	}
+
+	// the vector may belong to another centroid now
+	f.remove(id)
+	f.add(id, vector)
+	return nil
+}
+
+// IDs returns the ids of the vectors in the index
+func (f *IVFPQ) IDs() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	ids := make([]string, 0, len(f.assign)+len(f.pending))
+	for id := range f.assign {
+		ids = append(ids, id)
+	}
+	for id := range f.pending {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (f *IVFPQ) Search(vector []float32, topk int, xparams map[string]interface{}) ([]model.SearchResult, error) {
+	return f.SearchWithFilter(vector, topk, xparams, nil)
+}
+
+// SearchWithFilter skips the codes rejected by the filter, lists beyond nprobe
+// are probed until topk accepted vectors are found
+func (f *IVFPQ) SearchWithFilter(vector []float32, topk int, xparams map[string]interface{}, filter func(id string) bool) ([]model.SearchResult, error) {
+	nprobe := defaultParams["nprobe"].(int)
+	if value, exists := xparams["nprobe"]; exists {
+		switch v := value.(type) {
+		case float64:
+			nprobe = int(v)
+		case int:
+			nprobe = v
+		default:
+			return nil, fmt.Errorf("nprobe parameter must be a number")
+		}
+	}
+	if nprobe <= 0 {
+		return nil, fmt.Errorf("nprobe parameter must be positive")
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	resultspq := pkg.NewMaxPQ()
+	heap.Init(resultspq)
+
+	for id, storedVector := range f.pending {
+		if filter != nil && !filter(id) {
+			continue
+		}
+		pushResult(resultspq, id, f.distfunc(vector, storedVector), topk)
+	}
+
+	if len(f.centroids) > 0 {
+		table := f.quantizer.Table(vector, f.distance)
+		qnorm := magnitude(vector)
+		for i, list := range f.probe(vector, len(f.lists)) {
+			if i >= nprobe && (filter == nil || resultspq.Len() >= topk) {
+				break
+			}
+			for id, c := range f.lists[list] {
+				if filter != nil && !filter(id) {
+					continue
+				}
+				pushResult(resultspq, id, f.quantizer.ADC(table, c.Code, f.distance, qnorm, c.Norm), topk)
+			}
+		}
+	}
+
+	return popResults(resultspq), nil
+}
+
+func (f *IVFPQ) Save(w io.Writer) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	snap := ivfpqSnapshot{
+		Centroids: f.centroids,
+		Codebooks: f.quantizer.Codebooks,
+		Lists:     f.lists,
+		Pending:   f.pending,
+	}
+	if err := gob.NewEncoder(w).Encode(snap); err != nil {
+		return fmt.Errorf("failed to encode ivfpq index: %w", err)
+	}
+	return nil
+}
+
+func (f *IVFPQ) Load(r io.Reader) error {
+	snap := ivfpqSnapshot{}
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("failed to decode ivfpq index: %w", err)
+	}
+	if len(snap.Centroids) != len(snap.Lists) || len(snap.Centroids) > 0 && len(snap.Codebooks) != f.quantizer.M {
+		return fmt.Errorf("ivfpq index snapshot is inconsistent")
+	}
+
+	assign := make(map[string]int)
+	for i := range snap.Lists {
+		if snap.Lists[i] == nil {
+			snap.Lists[i] = make(map[string]code)
+		}
+		for id := range snap.Lists[i] {
+			assign[id] = i
+		}
+	}
+	if snap.Pending == nil {
+		snap.Pending = make(map[string][]float32)
+	}
+	if len(assign)+len(snap.Pending) > f.maxSize {
+		return fmt.Errorf("ivfpq index snapshot exceeds max size")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.centroids = snap.Centroids
+	f.quantizer.Codebooks = snap.Codebooks
+	f.lists = snap.Lists
+	f.assign = assign
+	f.pending = snap.Pending
+	return nil
+}
+
+func (f *IVFPQ) size() int {
+	return len(f.assign) + len(f.pending)
+}
+
+func (f *IVFPQ) exists(id string) bool {
+	if _, ok := f.assign[id]; ok {
+		return true
+	}
+	_, ok := f.pending[id]
+	return ok
+}
+
+func (f *IVFPQ) remove(id string) {
+	if list, ok := f.assign[id]; ok {
+		delete(f.lists[list], id)
+		delete(f.assign, id)
+	}
+	delete(f.pending, id)
+}
+
+// add encodes the vector into the inverted list of its nearest centroid if trained, otherwise keeps it for training
+func (f *IVFPQ) add(id string, vector []float32) {
+	if len(f.centroids) == 0 {
+		f.pending[id] = vector
+		return
+	}
+
+	list := pkg.NearestCentroid(vector, f.centroids, f.distfunc)
+	f.lists[list][id] = encode(f.quantizer, vector)
+	f.assign[id] = list
+}
+
+// train learns the centroids and codebooks from the collected vectors and encodes all of them
+func (f *IVFPQ) train() {
+	vectors := make([][]float32, 0, len(f.pending))
+	for _, vector := range f.pending {
+		vectors = append(vectors, vector)
+	}
+
+	iterations := defaultParams["iterations"].(int)
+	f.centroids = pkg.KMeans(vectors, f.nlist, iterations, f.distfunc)
+	f.quantizer.Train(vectors, iterations)
+
+	f.lists = make([]map[string]code, len(f.centroids))
+	for i := range f.lists {
+		f.lists[i] = make(map[string]code)
+	}
+	pending := f.pending
+	f.pending = make(map[string][]float32)
+	for id, vector := range pending {
+		f.add(id, vector)
+	}
+}
+
+// probe returns the inverted lists of the nprobe nearest centroids
+func (f *IVFPQ) probe(vector []float32, nprobe int) []int {
+	lists := make([]int, len(f.centroids))
+	dists := make([]float32, len(f.centroids))
+	for i, c := range f.centroids {
+		lists[i] = i
+		dists[i] = f.distfunc(vector, c)
+	}
+	sort.Slice(lists, func(i, j int) bool {
+		return dists[lists[i]] < dists[lists[j]]
+	})
+
+	if nprobe > len(lists) {
+		nprobe = len(lists)
+	}
+	return lists[:nprobe]
+}
@@ -0,0 +1,287 @@
+package pq
+
+import (
+	"container/heap"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"sync"
+	"vectordb/model"
+	"vectordb/pkg"
+)
+
+// PQ keeps only the product quantization codes of the vectors in memory,
+// vectors are kept in full precision until enough of them are collected to train the codebooks
+type PQ struct {
+	distance  string
+	distfunc  func([]float32, []float32) float32
+	maxSize   int
+	trainSize int                  // number of vectors to collect before training the codebooks
+	quantizer *Quantizer           // codebooks, untrained until trainSize vectors are collected
+	codes     map[string]code      // encoded vectors
+	pending   map[string][]float32 // full precision vectors collected before training
+	mu        sync.RWMutex
+}
+
+type code struct {
+	Code []uint8
+	Norm float32 // magnitude of the decoded vector, used by cosine
+}
+
+// pqSnapshot is the gob encoded state of a pq index
+type pqSnapshot struct {
+	Codebooks [][][]float32
+	Codes     map[string]code
+	Pending   map[string][]float32
+}
+
+// set default parameters
+var defaultParams = map[string]interface{}{
+	"m":          8,
+	"nbits":      8,
+	"nlist":      100,
+	"nprobe":     8,
+	"iterations": 20,
+	"trainratio": 39, // vectors per centroid used for training
+}
+
+func NewPQ(params *model.PQParams, dimension int, distance string) (*PQ, error) {
+	m, nbits := params.M, params.NBits
+	if m == 0 {
+		m = defaultParams["m"].(int)
+	}
+	if nbits == 0 {
+		nbits = defaultParams["nbits"].(int)
+	}
+	quantizer, err := NewQuantizer(dimension, m, nbits)
+	if err != nil {
+		return nil, err
+	}
+
+	trainSize := params.TrainSize
+	if trainSize == 0 {
+		trainSize = quantizer.Ksub * defaultParams["trainratio"].(int)
+	}
+	if trainSize < quantizer.Ksub {
+		return nil, fmt.Errorf("trainsize must be at least 2^nbits")
+	}
+
+	distfunc, err := pkg.GetDistanceFunc(distance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PQ{
+		distance:  distance,
+		distfunc:  distfunc,
+		maxSize:   params.MaxSize,
+		trainSize: trainSize,
+		quantizer: quantizer,
+		codes:     make(map[string]code),
+		pending:   make(map[string][]float32),
+	}, nil
+}
+
+func (p *PQ) Insert(id string, vector []float32) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.size() >= p.maxSize {
+		return fmt.Errorf("pq index is full")
+	}
+	if p.exists(id) {
+		return fmt.Errorf("id %s already exists in index", id)
+	}
+
+	p.add(id, vector)
+
+	// train once enough vectors are collected, this blocks the index for a while
+	if !p.quantizer.Trained() && len(p.pending) >= p.trainSize {
+		p.train()
+	}
+
+	return nil
+}
+
+func (p *PQ) Delete(id string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.exists(id) {
+		return fmt.Errorf("id %s not found in index", id)
+	}
+
+	delete(p.codes, id)
+	delete(p.pending, id)
+	return nil
+}
+
+func (p *PQ) Update(id string, vector []float32) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.exists(id) {
+		return fmt.Errorf("id %s not found in index", id)
+	}
+
+	p.add(id, vector)
+	return nil
+}
+
+// IDs returns the ids of the vectors in the index
+func (p *PQ) IDs() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	ids := make([]string, 0, len(p.codes)+len(p.pending))
+	for id := range p.codes {
+		ids = append(ids, id)
+	}
+	for id := range p.pending {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (p *PQ) Search(vector []float32, topk int, xparams map[string]interface{}) ([]model.SearchResult, error) {
+	return p.SearchWithFilter(vector, topk, xparams, nil)
+}
+
+// SearchWithFilter scans only the codes accepted by the filter
+func (p *PQ) SearchWithFilter(vector []float32, topk int, xparams map[string]interface{}, filter func(id string) bool) ([]model.SearchResult, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	resultspq := pkg.NewMaxPQ()
+	heap.Init(resultspq)
+
+	for id, storedVector := range p.pending {
+		if filter != nil && !filter(id) {
+			continue
+		}
+		pushResult(resultspq, id, p.distfunc(vector, storedVector), topk)
+	}
+
+	if len(p.codes) > 0 {
+		table := p.quantizer.Table(vector, p.distance)
+		qnorm := magnitude(vector)
+		for id, c := range p.codes {
+			if filter != nil && !filter(id) {
+				continue
+			}
+			pushResult(resultspq, id, p.quantizer.ADC(table, c.Code, p.distance, qnorm, c.Norm), topk)
+		}
+	}
+
+	return popResults(resultspq), nil
+}
+
+func (p *PQ) Save(w io.Writer) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	snap := pqSnapshot{
+		Codebooks: p.quantizer.Codebooks,
+		Codes:     p.codes,
+		Pending:   p.pending,
+	}
+	if err := gob.NewEncoder(w).Encode(snap); err != nil {
+		return fmt.Errorf("failed to encode pq index: %w", err)
+	}
+	return nil
+}
+
+func (p *PQ) Load(r io.Reader) error {
+	snap := pqSnapshot{}
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("failed to decode pq index: %w", err)
+	}
+	if len(snap.Codebooks) > 0 && len(snap.Codebooks) != p.quantizer.M {
+		return fmt.Errorf("pq index snapshot has %d sub-quantizers, expected %d", len(snap.Codebooks), p.quantizer.M)
+	}
+	if len(snap.Codes)+len(snap.Pending) > p.maxSize {
+		return fmt.Errorf("pq index snapshot exceeds max size")
+	}
+	if snap.Codes == nil {
+		snap.Codes = make(map[string]code)
+	}
+	if snap.Pending == nil {
+		snap.Pending = make(map[string][]float32)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.quantizer.Codebooks = snap.Codebooks
+	p.codes = snap.Codes
+	p.pending = snap.Pending
+	return nil
+}
+
+func (p *PQ) size() int {
+	return len(p.codes) + len(p.pending)
+}
+
+func (p *PQ) exists(id string) bool {
+	if _, ok := p.codes[id]; ok {
+		return true
+	}
+	_, ok := p.pending[id]
+	return ok
+}
+
+// add encodes the vector if the codebooks are trained, otherwise keeps it for training
+func (p *PQ) add(id string, vector []float32) {
+	if !p.quantizer.Trained() {
+		p.pending[id] = vector
+		return
+	}
+	p.codes[id] = encode(p.quantizer, vector)
+}
+
+// Train learns the codebooks from the collected vectors and encodes all of them
+func (p *PQ) train() {
+	vectors := make([][]float32, 0, len(p.pending))
+	for _, vector := range p.pending {
+		vectors = append(vectors, vector)
+	}
+
+	p.quantizer.Train(vectors, defaultParams["iterations"].(int))
+
+	for id, vector := range p.pending {
+		p.codes[id] = encode(p.quantizer, vector)
+	}
+	p.pending = make(map[string][]float32)
+}
+
+func encode(q *Quantizer, vector []float32) code {
+	c := q.Encode(vector)
+	return code{
+		Code: c,
+		Norm: magnitude(q.Decode(c)),
+	}
+}
+
+// pushResult keeps the topk closest results in a maxpq
+func pushResult(resultspq *pkg.PriorityQueue, id string, dist float32, topk int) {
+	if resultspq.Len() < topk {
+		heap.Push(resultspq, pkg.NewItem(id, dist))
+	} else if resultspq.Len() > 0 && dist < resultspq.Top().(*pkg.Item).Distance {
+		heap.Pop(resultspq)
+		heap.Push(resultspq, pkg.NewItem(id, dist))
+	}
+}
+
+func popResults(resultspq *pkg.PriorityQueue) []model.SearchResult {
+	resultspq.SwitchOrder() // switch to minpq
+
+	results := make([]model.SearchResult, 0, resultspq.Len())
+	for resultspq.Len() > 0 {
+		item := heap.Pop(resultspq).(*pkg.Item)
+		results = append(results, model.SearchResult{
+			ID:    item.Node.(string),
+			Score: item.Distance,
+		})
+	}
+	return results
+}
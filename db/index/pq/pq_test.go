@@ -0,0 +1,242 @@
+package pq
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"testing"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomVector(dim int) []float32 {
+	v := make([]float32, dim)
+	for i := range v {
+		v[i] = rand.Float32()
+	}
+	return v
+}
+
+func TestPQOperations(t *testing.T) {
+	params := &model.PQParams{
+		M:         2,
+		NBits:     2,
+		TrainSize: 4,
+		MaxSize:   500,
+	}
+
+	index, err := NewPQ(params, 4, "euclidean")
+	assert.NoError(t, err)
+	assert.NotNil(t, index)
+
+	vectors := make(map[string][]float32)
+	vectors["vec0"] = []float32{0.05, 0.61, 0.76, 0.74}
+	vectors["vec1"] = []float32{0.19, 0.81, 0.75, 0.11}
+	vectors["vec2"] = []float32{0.36, 0.55, 0.47, 0.94}
+	vectors["vec3"] = []float32{0.18, 0.01, 0.85, 0.80}
+	vectors["vec4"] = []float32{0.24, 0.18, 0.22, 0.44}
+	vectors["vec5"] = []float32{0.35, 0.08, 0.11, 0.44}
+
+	// insert, codebooks are trained on the way
+	for id, vec := range vectors {
+		err := index.Insert(id, vec)
+		assert.NoError(t, err)
+	}
+	assert.True(t, index.quantizer.Trained())
+	assert.Empty(t, index.pending)
+	assert.Len(t, index.codes, 6)
+
+	// search returns every vector ordered by approximate distance
+	results, err := index.Search(vectors["vec4"], 6, nil)
+	assert.NoError(t, err)
+	assert.Len(t, results, 6)
+	for i := 1; i < len(results); i++ {
+		assert.LessOrEqual(t, results[i-1].Score, results[i].Score)
+	}
+
+	// update
+	err = index.Update("vec4", []float32{0.25, 0.18, 0.27, 0.45})
+	assert.NoError(t, err)
+
+	// delete
+	err = index.Delete("vec4")
+	assert.NoError(t, err)
+	results, err = index.Search(vectors["vec4"], 6, nil)
+	assert.NoError(t, err)
+	assert.Len(t, results, 5)
+	for _, result := range results {
+		assert.NotEqual(t, "vec4", result.ID)
+	}
+
+	// non-existent vector
+	err = index.Delete("nonexistent")
+	assert.Error(t, err)
+}
+
+func TestPQEdgeCases(t *testing.T) {
+	// dimension not divisible by m
+	_, err := NewPQ(&model.PQParams{M: 3, MaxSize: 10}, 4, "cosine")
+	assert.Error(t, err)
+
+	// too many bits
+	_, err = NewPQ(&model.PQParams{M: 2, NBits: 9, MaxSize: 10}, 4, "cosine")
+	assert.Error(t, err)
+
+	// test max size limit
+	index, err := NewPQ(&model.PQParams{M: 4, NBits: 4, MaxSize: 1000}, 8, "cosine")
+	assert.NoError(t, err)
+	for i := 0; i <= 1000; i++ {
+		err := index.Insert(fmt.Sprintf("vec%d", i), randomVector(8))
+		if i < 1000 {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
+
+func TestPQQuantization(t *testing.T) {
+	dim := 16
+	vectors := make([][]float32, 2000)
+	for i := range vectors {
+		vectors[i] = randomVector(dim)
+	}
+
+	for _, distance := range []string{"euclidean", "dot", "cosine"} {
+		index, err := NewPQ(&model.PQParams{M: 8, NBits: 8, TrainSize: 1000, MaxSize: 2000}, dim, distance)
+		assert.NoError(t, err)
+		for i, vec := range vectors {
+			assert.NoError(t, index.Insert(fmt.Sprintf("vec%d", i), vec))
+		}
+
+		// approximate results mostly agree with the exact ones
+		query := randomVector(dim)
+		results, err := index.Search(query, 10, nil)
+		assert.NoError(t, err)
+		assert.Len(t, results, 10)
+
+		exact := make([]model.SearchResult, len(vectors))
+		for i, vec := range vectors {
+			exact[i] = model.SearchResult{ID: fmt.Sprintf("vec%d", i), Score: index.distfunc(query, vec)}
+		}
+		sort.Slice(exact, func(i, j int) bool {
+			return exact[i].Score < exact[j].Score
+		})
+		groundTruth := make(map[string]struct{})
+		for _, result := range exact[:10] {
+			groundTruth[result.ID] = struct{}{}
+		}
+		hits := 0
+		for _, result := range results {
+			if _, ok := groundTruth[result.ID]; ok {
+				hits++
+			}
+		}
+		assert.GreaterOrEqual(t, hits, 5, distance)
+	}
+}
+
+func TestIVFPQOperations(t *testing.T) {
+	dim := 8
+	params := &model.IVFPQParams{
+		NList:     4,
+		M:         4,
+		NBits:     4,
+		TrainSize: 200,
+		MaxSize:   1000,
+	}
+
+	index, err := NewIVFPQ(params, dim, "euclidean")
+	assert.NoError(t, err)
+
+	vectors := make([][]float32, 1000)
+	for i := range vectors {
+		vectors[i] = randomVector(dim)
+		err := index.Insert(fmt.Sprintf("vec%d", i), vectors[i])
+		assert.NoError(t, err)
+	}
+	assert.Len(t, index.centroids, 4)
+	assert.Empty(t, index.pending)
+	assert.Len(t, index.assign, 1000)
+
+	// probing every list finds the vector itself
+	results, err := index.Search(vectors[7], 10, map[string]any{"nprobe": 4})
+	assert.NoError(t, err)
+	assert.Len(t, results, 10)
+	found := false
+	for _, result := range results {
+		if result.ID == "vec7" {
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	// update moves the vector to its new list
+	err = index.Update("vec7", []float32{0, 0, 0, 0, 0, 0, 0, 0})
+	assert.NoError(t, err)
+	assert.Len(t, index.assign, 1000)
+
+	// delete
+	err = index.Delete("vec7")
+	assert.NoError(t, err)
+	results, err = index.Search(vectors[7], 10, map[string]any{"nprobe": 4})
+	assert.NoError(t, err)
+	for _, result := range results {
+		assert.NotEqual(t, "vec7", result.ID)
+	}
+
+	// invalid nprobe
+	_, err = index.Search(vectors[7], 10, map[string]any{"nprobe": "all"})
+	assert.Error(t, err)
+}
+
+func TestPQSaveLoad(t *testing.T) {
+	dim := 8
+	params := &model.IVFPQParams{
+		NList:     4,
+		M:         4,
+		NBits:     4,
+		TrainSize: 200,
+		MaxSize:   1000,
+	}
+
+	index, err := NewIVFPQ(params, dim, "cosine")
+	assert.NoError(t, err)
+	flat, err := NewPQ(&model.PQParams{M: 4, NBits: 4, TrainSize: 200, MaxSize: 1000}, dim, "cosine")
+	assert.NoError(t, err)
+
+	for i := 0; i < 500; i++ {
+		vec := randomVector(dim)
+		assert.NoError(t, index.Insert(fmt.Sprintf("vec%d", i), vec))
+		assert.NoError(t, flat.Insert(fmt.Sprintf("vec%d", i), vec))
+	}
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, index.Save(buf))
+	loaded, err := NewIVFPQ(params, dim, "cosine")
+	assert.NoError(t, err)
+	assert.NoError(t, loaded.Load(buf))
+	assert.Equal(t, index.assign, loaded.assign)
+
+	query := randomVector(dim)
+	expected, err := index.Search(query, 5, nil)
+	assert.NoError(t, err)
+	results, err := loaded.Search(query, 5, nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, expected, results) // codes may tie
+
+	buf.Reset()
+	assert.NoError(t, flat.Save(buf))
+	loadedFlat, err := NewPQ(&model.PQParams{M: 4, NBits: 4, TrainSize: 200, MaxSize: 1000}, dim, "cosine")
+	assert.NoError(t, err)
+	assert.NoError(t, loadedFlat.Load(buf))
+	assert.Equal(t, flat.codes, loadedFlat.codes)
+
+	expected, err = flat.Search(query, 5, nil)
+	assert.NoError(t, err)
+	results, err = loadedFlat.Search(query, 5, nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, expected, results)
+}
@@ -0,0 +1,112 @@
+package pq
+
+import (
+	"fmt"
+	"math"
+	"vectordb/pkg"
+)
+
+// Quantizer splits a vector into m subvectors and encodes each one as the id of its nearest codeword
+type Quantizer struct {
+	M         int           // number of sub-quantizers
+	NBits     int           // bits per code, at most 8
+	Dsub      int           // dimension of each subvector
+	Ksub      int           // number of codewords per sub-quantizer
+	Codebooks [][][]float32 // codewords per sub-quantizer, [m][ksub][dsub]
+}
+
+func NewQuantizer(dimension int, m int, nbits int) (*Quantizer, error) {
+	if m <= 0 || dimension%m != 0 {
+		return nil, fmt.Errorf("dimension %d is not divisible by m %d", dimension, m)
+	}
+	if nbits <= 0 || nbits > 8 {
+		return nil, fmt.Errorf("nbits must be between 1 and 8")
+	}
+
+	return &Quantizer{
+		M:     m,
+		NBits: nbits,
+		Dsub:  dimension / m,
+		Ksub:  1 << nbits,
+	}, nil
+}
+
+func (q *Quantizer) Trained() bool {
+	return len(q.Codebooks) > 0
+}
+
+// Train learns the codebook of every subspace with k-means
+func (q *Quantizer) Train(vectors [][]float32, iterations int) {
+	q.Codebooks = make([][][]float32, q.M)
+	subvectors := make([][]float32, len(vectors))
+	for m := 0; m < q.M; m++ {
+		for i, v := range vectors {
+			subvectors[i] = v[m*q.Dsub : (m+1)*q.Dsub]
+		}
+		q.Codebooks[m] = pkg.KMeans(subvectors, q.Ksub, iterations, pkg.EuclideanDistance)
+	}
+}
+
+func (q *Quantizer) Encode(vector []float32) []uint8 {
+	code := make([]uint8, q.M)
+	for m := 0; m < q.M; m++ {
+		code[m] = uint8(pkg.NearestCentroid(vector[m*q.Dsub:(m+1)*q.Dsub], q.Codebooks[m], pkg.EuclideanDistance))
+	}
+	return code
+}
+
+func (q *Quantizer) Decode(code []uint8) []float32 {
+	vector := make([]float32, 0, q.M*q.Dsub)
+	for m, c := range code {
+		vector = append(vector, q.Codebooks[m][c]...)
+	}
+	return vector
+}
+
+// Table precomputes the partial distances between the query subvectors and every codeword,
+// squared differences for euclidean and inner products for dot and cosine
+func (q *Quantizer) Table(query []float32, distance string) []float32 {
+	table := make([]float32, q.M*q.Ksub)
+	for m := 0; m < q.M; m++ {
+		sub := query[m*q.Dsub : (m+1)*q.Dsub]
+		for k, codeword := range q.Codebooks[m] {
+			var sum float32
+			for d := range sub {
+				if distance == "euclidean" {
+					diff := sub[d] - codeword[d]
+					sum += diff * diff
+				} else {
+					sum += sub[d] * codeword[d]
+				}
+			}
+			table[m*q.Ksub+k] = sum
+		}
+	}
+	return table
+}
+
+// ADC computes the asymmetric distance between the query of the table and an encoded vector,
+// qnorm and norm are the magnitudes of the query and the decoded vector, only used by cosine
+func (q *Quantizer) ADC(table []float32, code []uint8, distance string, qnorm float32, norm float32) float32 {
+	var sum float32
+	for m, c := range code {
+		sum += table[m*q.Ksub+int(c)]
+	}
+
+	switch distance {
+	case "euclidean":
+		return float32(math.Sqrt(float64(sum)))
+	case "dot":
+		return -sum
+	default:
+		return 1 - sum/(qnorm*norm)
+	}
+}
+
+func magnitude(v []float32) float32 {
+	var sum float32
+	for _, val := range v {
+		sum += val * val
+	}
+	return float32(math.Sqrt(float64(sum)))
+}
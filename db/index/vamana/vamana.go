@@ -0,0 +1,824 @@
+package vamana
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"vectordb/db/index/pq"
+	"vectordb/model"
+	"vectordb/pkg"
+)
+
+// Vamana keeps a DiskANN style graph on disk, every node is a fixed size record of its full precision
+// vector and out-neighbours, only the id mapping and the pq codes used to navigate the graph stay in memory
+type Vamana struct {
+	distance  string
+	distfunc  func([]float32, []float32) float32
+	prunefunc func([]float32, []float32) float32 // distance used by robust prune, must not be negative
+	dimension int
+	r         int     // maximum out-degree of a node
+	l         int     // size of the candidate list when inserting
+	alpha     float32 // pruning factor, larger keeps more long range edges
+	maxSize   int
+	trainSize int           // number of vectors to insert before training the pq codebooks
+	quantizer *pq.Quantizer // untrained until trainSize vectors are inserted, exact distances are read from disk meanwhile
+	codes     [][]uint8     // pq code of every slot
+	norms     []float32     // magnitude of the decoded pq code of every slot, used by cosine
+	file      *os.File
+	record    int64               // size of a node record
+	slots     []string            // map from slot to id
+	ids       map[string]uint32   // map from id to its live slot
+	deleted   map[uint32]struct{} // tombstoned slots, still used to navigate the graph
+	entry     uint32              // entry point of the graph, the first slot
+	compact   float64             // fraction of tombstoned slots that triggers a consolidation
+	free      []uint32            // consolidated slots no node links to, reused by inserts
+	released  []uint32            // slots consolidated since the last Save, the snapshot on disk may still route through them
+	mu        sync.RWMutex
+}
+
+// vamanaSnapshot is the gob encoded in-memory state of a vamana index. It is followed by the
+// neighbour list of every slot, records are rewritten in place after a snapshot so Load writes
+// them back and truncates the records appended since
+type vamanaSnapshot struct {
+	Slots     []string
+	Deleted   []uint32
+	Free      []uint32
+	Codebooks [][][]float32
+	Codes     [][]uint8
+	Norms     []float32
+}
+
+type candidate struct {
+	slot uint32
+	dist float32
+}
+
+// set default parameters
+var defaultParams = map[string]interface{}{
+	"r":                32,
+	"l":                64,
+	"alpha":            1.2,
+	"m":                8,
+	"nbits":            8,
+	"iterations":       20,
+	"trainsize":        10000,
+	"compactthreshold": 0.2,
+}
+
+func NewVamana(params *model.VamanaParams, dimension int, distance string, path string) (*Vamana, error) {
+	r, l, alpha := params.R, params.L, float32(params.Alpha)
+	if r == 0 {
+		r = defaultParams["r"].(int)
+	}
+	if l == 0 {
+		l = defaultParams["l"].(int)
+	}
+	if alpha == 0 {
+		alpha = float32(defaultParams["alpha"].(float64))
+	}
+	if r <= 0 || l < r || alpha < 1 {
+		return nil, fmt.Errorf("invalid vamana parameters, require r > 0, l >= r and alpha >= 1")
+	}
+	compact := params.CompactThreshold
+	if compact == 0 {
+		compact = defaultParams["compactthreshold"].(float64)
+	}
+	if compact < 0 || compact > 1 {
+		return nil, fmt.Errorf("compactthreshold must be between 0 and 1")
+	}
+
+	m, nbits := params.M, params.NBits
+	if m == 0 {
+		m = defaultParams["m"].(int)
+	}
+	if nbits == 0 {
+		nbits = defaultParams["nbits"].(int)
+	}
+	quantizer, err := pq.NewQuantizer(dimension, m, nbits)
+	if err != nil {
+		return nil, err
+	}
+	trainSize := params.TrainSize
+	if trainSize == 0 {
+		trainSize = defaultParams["trainsize"].(int)
+	}
+	if trainSize < quantizer.Ksub {
+		return nil, fmt.Errorf("trainsize must be at least 2^nbits")
+	}
+
+	distfunc, err := pkg.GetDistanceFunc(distance)
+	if err != nil {
+		return nil, err
+	}
+	prunefunc := distfunc
+	if distance == "dot" {
+		prunefunc = pkg.EuclideanDistance
+	}
+
+	// records are rewritten from the first slot on, so an existing file is not truncated
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open vamana index file: %w", err)
+	}
+
+	return &Vamana{
+		distance:  distance,
+		distfunc:  distfunc,
+		prunefunc: prunefunc,
+		dimension: dimension,
+		r:         r,
+		l:         l,
+		alpha:     alpha,
+		maxSize:   params.MaxSize,
+		trainSize: trainSize,
+		quantizer: quantizer,
+		file:      file,
+		record:    int64(dimension*4 + 4 + r*4),
+		ids:       make(map[string]uint32),
+		deleted:   make(map[uint32]struct{}),
+		compact:   compact,
+	}, nil
+}
+
+func (v *Vamana) Insert(id string, vector []float32) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if len(v.ids) >= v.maxSize {
+		return fmt.Errorf("vamana index is full")
+	}
+	if _, exists := v.ids[id]; exists {
+		return fmt.Errorf("id %s already exists in index", id)
+	}
+	if len(vector) != v.dimension {
+		return fmt.Errorf("vector dimension mismatch")
+	}
+
+	if err := v.insert(id, vector); err != nil {
+		return err
+	}
+
+	// train once enough vectors are inserted, this blocks the index for a while
+	if !v.quantizer.Trained() && len(v.ids) >= v.trainSize {
+		if err := v.train(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete tombstones the node, it keeps routing searches but is never returned. Once the
+// tombstoned slots reach the compaction threshold they are consolidated and their slots reused
+func (v *Vamana) Delete(id string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	slot, exists := v.ids[id]
+	if !exists {
+		return fmt.Errorf("id %s not found in index", id)
+	}
+
+	delete(v.ids, id)
+	v.deleted[slot] = struct{}{}
+	return v.maybeConsolidate()
+}
+
+func (v *Vamana) Update(id string, vector []float32) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	slot, exists := v.ids[id]
+	if !exists {
+		return fmt.Errorf("id %s not found in index", id)
+	}
+	if len(vector) != v.dimension {
+		return fmt.Errorf("vector dimension mismatch")
+	}
+
+	// the new slot is linked first, the old one is only tombstoned once it succeeded
+	if err := v.insert(id, vector); err != nil {
+		if inserted := v.ids[id]; inserted != slot {
+			v.deleted[inserted] = struct{}{}
+		}
+		v.ids[id] = slot
+		return err
+	}
+	v.deleted[slot] = struct{}{}
+	return v.maybeConsolidate()
+}
+
+// IDs returns the ids of the vectors in the index
+func (v *Vamana) IDs() []string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	ids := make([]string, 0, len(v.ids))
+	for id := range v.ids {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (v *Vamana) Search(vector []float32, topk int, xparams map[string]interface{}) ([]model.SearchResult, error) {
+	return v.SearchWithFilter(vector, topk, xparams, nil)
+}
+
+// SearchWithFilter only reranks the visited nodes accepted by the filter, the walk goes past l
+// until topk of them are found
+func (v *Vamana) SearchWithFilter(vector []float32, topk int, xparams map[string]interface{}, filter func(id string) bool) ([]model.SearchResult, error) {
+	l := defaultParams["l"].(int)
+	if value, exists := xparams["l"]; exists {
+		switch val := value.(type) {
+		case float64:
+			l = int(val)
+		case int:
+			l = val
+		default:
+			return nil, fmt.Errorf("l parameter must be a number")
+		}
+	}
+	l = max(l, topk)
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if len(v.slots) == 0 {
+		return nil, nil
+	}
+
+	accept := func(slot uint32) bool {
+		_, deleted := v.deleted[slot]
+		return !deleted && (filter == nil || filter(v.slots[slot]))
+	}
+	_, _, list, err := v.greedySearch(vector, l, topk, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	// rerank the candidates with the full precision vectors on disk
+	results := make([]model.SearchResult, 0, len(list))
+	for _, c := range list {
+		stored, err := v.readVector(c.slot)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, model.SearchResult{
+			ID:    v.slots[c.slot],
+			Score: v.distfunc(vector, stored),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Score < results[j].Score
+	})
+	if topk < len(results) {
+		results = results[:topk]
+	}
+
+	return results, nil
+}
+
+// Save flushes the index file and writes the in-memory state followed by the neighbour lists,
+// the slots released by consolidations are reused once they are free in a snapshot
+func (v *Vamana) Save(w io.Writer) error {
+	released, err := v.save(w)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	released = min(released, len(v.released))
+	v.free = append(v.free, v.released[:released]...)
+	v.released = v.released[released:]
+	return nil
+}
+
+func (v *Vamana) save(w io.Writer) (int, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if err := v.file.Sync(); err != nil {
+		return 0, fmt.Errorf("failed to sync vamana index file: %w", err)
+	}
+
+	snap := vamanaSnapshot{
+		Slots:     v.slots,
+		Deleted:   make([]uint32, 0, len(v.deleted)),
+		Free:      slices.Concat(v.free, v.released),
+		Codebooks: v.quantizer.Codebooks,
+		Codes:     v.codes,
+		Norms:     v.norms,
+	}
+	for slot := range v.deleted {
+		snap.Deleted = append(snap.Deleted, slot)
+	}
+
+	if err := gob.NewEncoder(w).Encode(snap); err != nil {
+		return 0, fmt.Errorf("failed to encode vamana index: %w", err)
+	}
+	buf := make([]byte, 4+v.r*4)
+	for slot := range v.slots {
+		if _, err := v.file.ReadAt(buf, int64(slot)*v.record+int64(v.dimension*4)); err != nil {
+			return 0, fmt.Errorf("failed to read vamana neighbours: %w", err)
+		}
+		if _, err := w.Write(buf); err != nil {
+			return 0, fmt.Errorf("failed to write vamana neighbours: %w", err)
+		}
+	}
+	return len(v.released), nil
+}
+
+// Load restores the in-memory state and brings the index file back to the snapshot, r must not
+// read ahead of the gob encoded state, like a bufio.Reader
+func (v *Vamana) Load(r io.Reader) error {
+	snap := vamanaSnapshot{}
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("failed to decode vamana index: %w", err)
+	}
+	if len(snap.Codebooks) > 0 && (len(snap.Codebooks) != v.quantizer.M || len(snap.Codes) != len(snap.Slots)) {
+		return fmt.Errorf("vamana index snapshot is inconsistent")
+	}
+
+	info, err := v.file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat vamana index file: %w", err)
+	}
+	size := int64(len(snap.Slots)) * v.record
+	if info.Size() < size {
+		return fmt.Errorf("vamana index file is shorter than the snapshot")
+	}
+
+	deleted := make(map[uint32]struct{}, len(snap.Deleted))
+	for _, slot := range snap.Deleted {
+		deleted[slot] = struct{}{}
+	}
+	free := make(map[uint32]struct{}, len(snap.Free))
+	for _, slot := range snap.Free {
+		free[slot] = struct{}{}
+	}
+	ids := make(map[string]uint32, len(snap.Slots))
+	for slot, id := range snap.Slots {
+		_, isDeleted := deleted[uint32(slot)]
+		_, isFree := free[uint32(slot)]
+		if !isDeleted && !isFree {
+			ids[id] = uint32(slot)
+		}
+	}
+	if len(ids) > v.maxSize {
+		return fmt.Errorf("vamana index snapshot exceeds max size")
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	// the vectors of the snapshot slots are never rewritten, their neighbour lists may be
+	buf := make([]byte, 4+v.r*4)
+	for slot := range snap.Slots {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return fmt.Errorf("failed to read vamana neighbours: %w", err)
+		}
+		if _, err := v.file.WriteAt(buf, int64(slot)*v.record+int64(v.dimension*4)); err != nil {
+			return fmt.Errorf("failed to write vamana neighbours: %w", err)
+		}
+	}
+	if err := v.file.Truncate(size); err != nil {
+		return fmt.Errorf("failed to truncate vamana index file: %w", err)
+	}
+
+	v.slots = snap.Slots
+	v.ids = ids
+	v.deleted = deleted
+	v.free = snap.Free
+	v.released = nil
+	v.quantizer.Codebooks = snap.Codebooks
+	v.codes = snap.Codes
+	v.norms = snap.Norms
+	return nil
+}
+
+func (v *Vamana) Close() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if err := v.file.Close(); err != nil {
+		return fmt.Errorf("failed to close vamana index file: %w", err)
+	}
+	return nil
+}
+
+// insert writes a node record to a free slot or appends it, links it with the nodes visited
+// while searching for it and adds the reverse edges, pruning the neighbours that exceed the
+// maximum degree
+func (v *Vamana) insert(id string, vector []float32) error {
+	slot := uint32(len(v.slots))
+	if n := len(v.free); n > 0 {
+		slot = v.free[n-1]
+	}
+	if err := v.writeRecord(slot, vector, nil); err != nil {
+		return err
+	}
+	var code []uint8
+	var norm float32
+	if v.quantizer.Trained() {
+		code, norm = v.encode(vector)
+	}
+	if int(slot) < len(v.slots) {
+		v.free = v.free[:len(v.free)-1]
+		v.slots[slot] = id
+		if code != nil {
+			v.codes[slot], v.norms[slot] = code, norm
+		}
+	} else {
+		v.slots = append(v.slots, id)
+		if code != nil {
+			v.codes = append(v.codes, code)
+			v.norms = append(v.norms, norm)
+		}
+	}
+	v.ids[id] = slot
+
+	if slot == v.entry {
+		return nil
+	}
+
+	list, visited, _, err := v.greedySearch(vector, v.l, 0, nil)
+	if err != nil {
+		return err
+	}
+	for _, c := range list {
+		visited = append(visited, c.slot)
+	}
+
+	cache := map[uint32][]float32{slot: vector}
+	neighbours, err := v.robustPrune(slot, visited, cache)
+	if err != nil {
+		return err
+	}
+	if err := v.writeNeighbours(slot, neighbours); err != nil {
+		return err
+	}
+
+	for _, n := range neighbours {
+		nneighbours, err := v.readNeighbours(n)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(nneighbours, slot) {
+			continue
+		}
+		nneighbours = append(nneighbours, slot)
+		if len(nneighbours) > v.r {
+			if nneighbours, err = v.robustPrune(n, nneighbours, cache); err != nil {
+				return err
+			}
+		}
+		if err := v.writeNeighbours(n, nneighbours); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// maybeConsolidate consolidates the tombstoned slots once they reach the compaction threshold
+// of the graph
+func (v *Vamana) maybeConsolidate() error {
+	if len(v.deleted) == 0 || float64(len(v.deleted)) < v.compact*float64(len(v.ids)+len(v.deleted)) {
+		return nil
+	}
+	return v.consolidate()
+}
+
+// consolidate reconnects the nodes linking to tombstoned slots with the neighbours of those
+// slots, then releases them. The entry point stays tombstoned since searches start from it
+func (v *Vamana) consolidate() error {
+	removed := make(map[uint32][]uint32, len(v.deleted))
+	for slot := range v.deleted {
+		if slot == v.entry {
+			continue
+		}
+		neighbours, err := v.readNeighbours(slot)
+		if err != nil {
+			return err
+		}
+		removed[slot] = neighbours
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	unused := make(map[uint32]struct{}, len(v.free)+len(v.released))
+	for _, slot := range slices.Concat(v.free, v.released) {
+		unused[slot] = struct{}{}
+	}
+	for i := range v.slots {
+		slot := uint32(i)
+		if _, ok := removed[slot]; ok {
+			continue
+		}
+		if _, ok := unused[slot]; ok {
+			continue
+		}
+
+		neighbours, err := v.readNeighbours(slot)
+		if err != nil {
+			return err
+		}
+		candidates := make([]uint32, 0, len(neighbours))
+		changed := false
+		for _, n := range neighbours {
+			hops, ok := removed[n]
+			if !ok {
+				candidates = append(candidates, n)
+				continue
+			}
+			changed = true
+			for _, hop := range hops {
+				if _, ok := removed[hop]; !ok && hop != slot {
+					candidates = append(candidates, hop)
+				}
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		if neighbours, err = v.robustPrune(slot, candidates, map[uint32][]float32{}); err != nil {
+			return err
+		}
+		if err := v.writeNeighbours(slot, neighbours); err != nil {
+			return err
+		}
+	}
+
+	for slot := range removed {
+		delete(v.deleted, slot)
+		v.slots[slot] = ""
+		v.released = append(v.released, slot)
+	}
+	return nil
+}
+
+// greedySearch walks the graph from the entry point keeping the l closest candidates, it returns
+// the candidates, the expanded slots and the closest seen slots accepted by the filter if any. The
+// walk goes on past l until want slots are accepted or every reachable slot is expanded
+func (v *Vamana) greedySearch(q []float32, l int, want int, accept func(uint32) bool) ([]candidate, []uint32, []candidate, error) {
+	dist, err := v.queryDistance(q)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	d, err := dist(v.entry)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// every seen slot sorted by distance, only the first l are expanded
+	list := []candidate{{slot: v.entry, dist: d}}
+	matched := []candidate{}
+	if accept != nil && accept(v.entry) {
+		matched = append(matched, list[0])
+	}
+	seen := map[uint32]struct{}{v.entry: {}}
+	expanded := map[uint32]struct{}{}
+	visited := []uint32{}
+
+	for {
+		// closest candidate not expanded yet
+		next := -1
+		for i := 0; i < min(l, len(list)); i++ {
+			if _, ok := expanded[list[i].slot]; !ok {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			if len(matched) >= want || l >= len(list) {
+				break
+			}
+			// too few accepted slots among the l closest, widen the walk
+			l = min(2*l, len(list))
+			continue
+		}
+
+		p := list[next].slot
+		expanded[p] = struct{}{}
+		visited = append(visited, p)
+
+		neighbours, err := v.readNeighbours(p)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for _, n := range neighbours {
+			if _, ok := seen[n]; ok {
+				continue
+			}
+			seen[n] = struct{}{}
+			d, err := dist(n)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			i := sort.Search(len(list), func(i int) bool { return list[i].dist > d })
+			list = slices.Insert(list, i, candidate{slot: n, dist: d})
+			if accept != nil && accept(n) {
+				matched = append(matched, candidate{slot: n, dist: d})
+			}
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].dist < matched[j].dist
+	})
+	if len(matched) > l {
+		matched = matched[:l]
+	}
+
+	return list[:min(l, len(list))], visited, matched, nil
+}
+
+// queryDistance returns the distance from the query to a slot, pq codes are used once trained
+func (v *Vamana) queryDistance(q []float32) (func(uint32) (float32, error), error) {
+	if v.quantizer.Trained() {
+		table := v.quantizer.Table(q, v.distance)
+		qnorm := magnitude(q)
+		return func(slot uint32) (float32, error) {
+			return v.quantizer.ADC(table, v.codes[slot], v.distance, qnorm, v.norms[slot]), nil
+		}, nil
+	}
+
+	return func(slot uint32) (float32, error) {
+		stored, err := v.readVector(slot)
+		if err != nil {
+			return 0, err
+		}
+		return v.distfunc(q, stored), nil
+	}, nil
+}
+
+// robustPrune picks at most r out-neighbours of p from the candidates, a candidate is skipped
+// if it is alpha times closer to an already picked neighbour than to p
+func (v *Vamana) robustPrune(p uint32, candidates []uint32, cache map[uint32][]float32) ([]uint32, error) {
+	vector := func(slot uint32) ([]float32, error) {
+		if vec, ok := cache[slot]; ok {
+			return vec, nil
+		}
+		vec, err := v.readVector(slot)
+		if err != nil {
+			return nil, err
+		}
+		cache[slot] = vec
+		return vec, nil
+	}
+
+	pv, err := vector(p)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := []candidate{}
+	seen := map[uint32]struct{}{p: {}}
+	for _, c := range candidates {
+		if _, ok := seen[c]; ok {
+			continue
+		}
+		seen[c] = struct{}{}
+		cv, err := vector(c)
+		if err != nil {
+			return nil, err
+		}
+		pool = append(pool, candidate{slot: c, dist: v.prunefunc(pv, cv)})
+	}
+	sort.Slice(pool, func(i, j int) bool {
+		return pool[i].dist < pool[j].dist
+	})
+
+	neighbours := []uint32{}
+	for len(pool) > 0 && len(neighbours) < v.r {
+		closest := pool[0]
+		neighbours = append(neighbours, closest.slot)
+
+		cv, err := vector(closest.slot)
+		if err != nil {
+			return nil, err
+		}
+		rest := pool[:0]
+		for _, c := range pool[1:] {
+			ov, err := vector(c.slot)
+			if err != nil {
+				return nil, err
+			}
+			if v.alpha*v.prunefunc(cv, ov) > c.dist {
+				rest = append(rest, c)
+			}
+		}
+		pool = rest
+	}
+
+	return neighbours, nil
+}
+
+// train learns the pq codebooks from the inserted vectors and encodes all slots
+func (v *Vamana) train() error {
+	vectors := make([][]float32, 0, len(v.ids))
+	for _, slot := range v.ids {
+		vec, err := v.readVector(slot)
+		if err != nil {
+			return err
+		}
+		vectors = append(vectors, vec)
+	}
+	v.quantizer.Train(vectors, defaultParams["iterations"].(int))
+
+	v.codes = make([][]uint8, len(v.slots))
+	v.norms = make([]float32, len(v.slots))
+	for slot := range v.slots {
+		vec, err := v.readVector(uint32(slot))
+		if err != nil {
+			return err
+		}
+		v.codes[slot], v.norms[slot] = v.encode(vec)
+	}
+	return nil
+}
+
+func (v *Vamana) encode(vector []float32) ([]uint8, float32) {
+	code := v.quantizer.Encode(vector)
+	return code, magnitude(v.quantizer.Decode(code))
+}
+
+// node record layout: vector (dimension float32) | degree (uint32) | neighbours (r uint32)
+func (v *Vamana) writeRecord(slot uint32, vector []float32, neighbours []uint32) error {
+	buf := make([]byte, v.record)
+	for i, val := range vector {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(val))
+	}
+	putNeighbours(buf[v.dimension*4:], neighbours)
+
+	if _, err := v.file.WriteAt(buf, int64(slot)*v.record); err != nil {
+		return fmt.Errorf("failed to write vamana node: %w", err)
+	}
+	return nil
+}
+
+func (v *Vamana) writeNeighbours(slot uint32, neighbours []uint32) error {
+	buf := make([]byte, 4+v.r*4)
+	putNeighbours(buf, neighbours)
+
+	if _, err := v.file.WriteAt(buf, int64(slot)*v.record+int64(v.dimension*4)); err != nil {
+		return fmt.Errorf("failed to write vamana neighbours: %w", err)
+	}
+	return nil
+}
+
+func (v *Vamana) readVector(slot uint32) ([]float32, error) {
+	buf := make([]byte, v.dimension*4)
+	if _, err := v.file.ReadAt(buf, int64(slot)*v.record); err != nil {
+		return nil, fmt.Errorf("failed to read vamana node: %w", err)
+	}
+
+	vector := make([]float32, v.dimension)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[i*4:]))
+	}
+	return vector, nil
+}
+
+// readNeighbours skips edges to slots past the end of the index
+func (v *Vamana) readNeighbours(slot uint32) ([]uint32, error) {
+	buf := make([]byte, 4+v.r*4)
+	if _, err := v.file.ReadAt(buf, int64(slot)*v.record+int64(v.dimension*4)); err != nil {
+		return nil, fmt.Errorf("failed to read vamana neighbours: %w", err)
+	}
+
+	degree := min(int(binary.LittleEndian.Uint32(buf)), v.r)
+	neighbours := make([]uint32, 0, degree)
+	for i := 0; i < degree; i++ {
+		n := binary.LittleEndian.Uint32(buf[4+i*4:])
+		if int(n) < len(v.slots) && n != slot {
+			neighbours = append(neighbours, n)
+		}
+	}
+	return neighbours, nil
+}
+
+func putNeighbours(buf []byte, neighbours []uint32) {
+	binary.LittleEndian.PutUint32(buf, uint32(len(neighbours)))
+	for i, n := range neighbours {
+		binary.LittleEndian.PutUint32(buf[4+i*4:], n)
+	}
+}
+
+func magnitude(v []float32) float32 {
+	var sum float32
+	for _, val := range v {
+		sum += val * val
+	}
+	return float32(math.Sqrt(float64(sum)))
+}
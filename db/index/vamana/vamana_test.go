@@ -0,0 +1,369 @@
+package vamana
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"path/filepath"
+	"slices"
+	"sort"
+	"testing"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomVector(dim int) []float32 {
+	v := make([]float32, dim)
+	for i := range v {
+		v[i] = rand.Float32()
+	}
+	return v
+}
+
+func recall(t *testing.T, index *Vamana, vectors map[string][]float32, query []float32, k int) int {
+	results, err := index.Search(query, k, nil)
+	assert.NoError(t, err)
+	assert.Len(t, results, k)
+	for i := 1; i < len(results); i++ {
+		assert.LessOrEqual(t, results[i-1].Score, results[i].Score)
+	}
+
+	exact := make([]model.SearchResult, 0, len(vectors))
+	for id, vec := range vectors {
+		exact = append(exact, model.SearchResult{ID: id, Score: index.distfunc(query, vec)})
+	}
+	sort.Slice(exact, func(i, j int) bool {
+		return exact[i].Score < exact[j].Score
+	})
+	groundTruth := make(map[string]struct{})
+	for _, result := range exact[:k] {
+		groundTruth[result.ID] = struct{}{}
+	}
+	hits := 0
+	for _, result := range results {
+		if _, ok := groundTruth[result.ID]; ok {
+			hits++
+		}
+	}
+	return hits
+}
+
+func TestVamanaOperations(t *testing.T) {
+	dim := 8
+	params := &model.VamanaParams{
+		R:         16,
+		L:         32,
+		M:         4,
+		NBits:     4,
+		TrainSize: 200,
+		MaxSize:   1000,
+	}
+
+	index, err := NewVamana(params, dim, "euclidean", filepath.Join(t.TempDir(), "test.index"))
+	assert.NoError(t, err)
+	defer index.Close()
+
+	vectors := make(map[string][]float32)
+	for i := 0; i < 500; i++ {
+		id := fmt.Sprintf("vec%d", i)
+		vectors[id] = randomVector(dim)
+		assert.NoError(t, index.Insert(id, vectors[id]))
+	}
+	assert.True(t, index.quantizer.Trained())
+	assert.Len(t, index.codes, 500)
+
+	// no node exceeds the maximum degree
+	for slot := range index.slots {
+		neighbours, err := index.readNeighbours(uint32(slot))
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(neighbours), params.R)
+	}
+
+	// results are reranked with the exact distances
+	assert.GreaterOrEqual(t, recall(t, index, vectors, randomVector(dim), 10), 8)
+
+	results, err := index.Search(vectors["vec7"], 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "vec7", results[0].ID)
+	assert.Zero(t, results[0].Score)
+
+	// update
+	updated := []float32{0, 0, 0, 0, 0, 0, 0, 0}
+	assert.NoError(t, index.Update("vec7", updated))
+	results, err = index.Search(updated, 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "vec7", results[0].ID)
+	assert.Zero(t, results[0].Score)
+
+	// delete
+	assert.NoError(t, index.Delete("vec7"))
+	results, err = index.Search(updated, 10, nil)
+	assert.NoError(t, err)
+	for _, result := range results {
+		assert.NotEqual(t, "vec7", result.ID)
+	}
+
+	// non-existent vector
+	assert.Error(t, index.Delete("vec7"))
+	assert.Error(t, index.Update("vec7", updated))
+
+	// duplicate id
+	assert.Error(t, index.Insert("vec8", updated))
+
+	// invalid l
+	_, err = index.Search(updated, 10, map[string]any{"l": "all"})
+	assert.Error(t, err)
+}
+
+func TestVamanaEdgeCases(t *testing.T) {
+	dir := t.TempDir()
+
+	// l smaller than r
+	_, err := NewVamana(&model.VamanaParams{R: 32, L: 16, MaxSize: 10}, 8, "cosine", filepath.Join(dir, "a.index"))
+	assert.Error(t, err)
+
+	// alpha below 1
+	_, err = NewVamana(&model.VamanaParams{Alpha: 0.5, MaxSize: 10}, 8, "cosine", filepath.Join(dir, "b.index"))
+	assert.Error(t, err)
+
+	// dimension not divisible by m
+	_, err = NewVamana(&model.VamanaParams{M: 3, MaxSize: 10}, 8, "cosine", filepath.Join(dir, "c.index"))
+	assert.Error(t, err)
+
+	// empty index
+	index, err := NewVamana(&model.VamanaParams{M: 4, MaxSize: 100}, 8, "cosine", filepath.Join(dir, "d.index"))
+	assert.NoError(t, err)
+	defer index.Close()
+	results, err := index.Search(randomVector(8), 10, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	// test max size limit
+	for i := 0; i <= 100; i++ {
+		err := index.Insert(fmt.Sprintf("vec%d", i), randomVector(8))
+		if i < 100 {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+
+	// a failed update leaves the node in place
+	slot := index.ids["vec7"]
+	failing, err := NewVamana(&model.VamanaParams{M: 4, MaxSize: 100}, 8, "cosine", filepath.Join(dir, "e.index"))
+	assert.NoError(t, err)
+	file := index.file
+	index.file = failing.file
+	assert.NoError(t, failing.Close())
+	assert.Error(t, index.Update("vec7", randomVector(8)))
+	index.file = file
+	assert.Equal(t, slot, index.ids["vec7"])
+	assert.NotContains(t, index.deleted, slot)
+	assert.Len(t, index.IDs(), 100)
+}
+
+func TestVamanaSaveLoad(t *testing.T) {
+	dim := 8
+	path := filepath.Join(t.TempDir(), "test.index")
+	params := &model.VamanaParams{M: 4, NBits: 4, TrainSize: 200, MaxSize: 1000}
+
+	for _, distance := range []string{"cosine", "dot"} {
+		index, err := NewVamana(params, dim, distance, path)
+		assert.NoError(t, err)
+
+		vectors := make(map[string][]float32)
+		for i := 0; i < 300; i++ {
+			id := fmt.Sprintf("vec%d", i)
+			vectors[id] = randomVector(dim)
+			assert.NoError(t, index.Insert(id, vectors[id]))
+		}
+		assert.NoError(t, index.Delete("vec3"))
+		delete(vectors, "vec3")
+
+		buf := new(bytes.Buffer)
+		assert.NoError(t, index.Save(buf))
+
+		query := randomVector(dim)
+		expected, err := index.Search(query, 5, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, index.Close())
+
+		// reopen the index file
+		loaded, err := NewVamana(params, dim, distance, path)
+		assert.NoError(t, err)
+		assert.NoError(t, loaded.Load(buf))
+		assert.Equal(t, index.ids, loaded.ids)
+
+		results, err := loaded.Search(query, 5, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, results)
+		assert.GreaterOrEqual(t, recall(t, loaded, vectors, query, 10), 8, distance)
+		assert.NoError(t, loaded.Close())
+	}
+}
+
+func TestVamanaFilter(t *testing.T) {
+	dim := 8
+	params := &model.VamanaParams{R: 16, L: 32, M: 4, NBits: 4, TrainSize: 200, MaxSize: 1000}
+	index, err := NewVamana(params, dim, "euclidean", filepath.Join(t.TempDir(), "test.index"))
+	assert.NoError(t, err)
+	defer index.Close()
+
+	vectors := make(map[string][]float32)
+	for i := 0; i < 1000; i++ {
+		id := fmt.Sprintf("vec%d", i)
+		vectors[id] = randomVector(dim)
+		assert.NoError(t, index.Insert(id, vectors[id]))
+	}
+
+	accept := func(id string) bool {
+		n := 0
+		fmt.Sscanf(id, "vec%d", &n)
+		return n%10 == 0
+	}
+	results, err := index.SearchWithFilter(randomVector(dim), 5, map[string]any{"l": 128}, accept)
+	assert.NoError(t, err)
+	assert.Len(t, results, 5)
+	for _, result := range results {
+		assert.True(t, accept(result.ID))
+	}
+}
+
+func TestVamanaSelectiveFilter(t *testing.T) {
+	dim := 8
+	params := &model.VamanaParams{R: 16, L: 32, M: 4, NBits: 4, TrainSize: 200, MaxSize: 3000, CompactThreshold: 1}
+	index, err := NewVamana(params, dim, "euclidean", filepath.Join(t.TempDir(), "test.index"))
+	assert.NoError(t, err)
+	defer index.Close()
+
+	for i := 0; i < 3000; i++ {
+		assert.NoError(t, index.Insert(fmt.Sprintf("vec%d", i), randomVector(dim)))
+	}
+	// tombstones keep routing the walk but are never accepted
+	for i := 1; i < 3000; i += 2 {
+		assert.NoError(t, index.Delete(fmt.Sprintf("vec%d", i)))
+	}
+
+	// 1% of the vectors match, far fewer than l are reached by the usual walk
+	accept := func(id string) bool {
+		n := 0
+		fmt.Sscanf(id, "vec%d", &n)
+		return n%100 == 0
+	}
+	for i := 0; i < 10; i++ {
+		results, err := index.SearchWithFilter(randomVector(dim), 20, nil, accept)
+		assert.NoError(t, err)
+		assert.Len(t, results, 20)
+		for _, result := range results {
+			assert.True(t, accept(result.ID))
+		}
+	}
+
+	// the walk stops once the graph is exhausted
+	results, err := index.SearchWithFilter(randomVector(dim), 50, nil, accept)
+	assert.NoError(t, err)
+	assert.Len(t, results, 30)
+}
+
+func TestVamanaSlotReuse(t *testing.T) {
+	dim := 8
+	params := &model.VamanaParams{R: 16, L: 32, M: 4, NBits: 4, TrainSize: 200, MaxSize: 1000}
+	index, err := NewVamana(params, dim, "euclidean", filepath.Join(t.TempDir(), "test.index"))
+	assert.NoError(t, err)
+	defer index.Close()
+
+	vectors := make(map[string][]float32)
+	for i := 0; i < 300; i++ {
+		id := fmt.Sprintf("vec%d", i)
+		vectors[id] = randomVector(dim)
+		assert.NoError(t, index.Insert(id, vectors[id]))
+	}
+
+	// deletes and updates are consolidated, their slots are reused after the next snapshot
+	next := 300
+	for round := 0; round < 5; round++ {
+		n := 0
+		for id := range vectors {
+			if n++; n > 50 {
+				break
+			}
+			if n%2 == 0 {
+				vectors[id] = randomVector(dim)
+				assert.NoError(t, index.Update(id, vectors[id]))
+				continue
+			}
+			assert.NoError(t, index.Delete(id))
+			delete(vectors, id)
+			id = fmt.Sprintf("vec%d", next)
+			next++
+			vectors[id] = randomVector(dim)
+			assert.NoError(t, index.Insert(id, vectors[id]))
+		}
+		assert.NoError(t, index.Save(io.Discard))
+	}
+	assert.LessOrEqual(t, len(index.slots), 450)
+	info, err := index.file.Stat()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(index.slots))*index.record, info.Size())
+
+	// no node of the graph links to a free slot
+	assert.Len(t, index.IDs(), len(vectors))
+	for slot := range index.slots {
+		if slices.Contains(index.free, uint32(slot)) {
+			continue
+		}
+		neighbours, err := index.readNeighbours(uint32(slot))
+		assert.NoError(t, err)
+		for _, n := range neighbours {
+			assert.NotContains(t, index.free, n)
+		}
+	}
+	assert.GreaterOrEqual(t, recall(t, index, vectors, randomVector(dim), 10), 8)
+}
+
+func TestVamanaLoadRestoresGraph(t *testing.T) {
+	dim := 8
+	path := filepath.Join(t.TempDir(), "test.index")
+	params := &model.VamanaParams{R: 16, L: 32, M: 4, NBits: 4, TrainSize: 200, MaxSize: 1000}
+	index, err := NewVamana(params, dim, "euclidean", path)
+	assert.NoError(t, err)
+
+	vectors := make(map[string][]float32)
+	for i := 0; i < 300; i++ {
+		id := fmt.Sprintf("vec%d", i)
+		vectors[id] = randomVector(dim)
+		assert.NoError(t, index.Insert(id, vectors[id]))
+	}
+	buf := new(bytes.Buffer)
+	assert.NoError(t, index.Save(buf))
+	queries := [][]float32{randomVector(dim), randomVector(dim), randomVector(dim)}
+	expected := [][]model.SearchResult{}
+	for _, q := range queries {
+		results, err := index.Search(q, 10, nil)
+		assert.NoError(t, err)
+		expected = append(expected, results)
+	}
+
+	// the neighbour lists of the snapshot slots are rewritten after it
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, index.Insert(fmt.Sprintf("new%d", i), randomVector(dim)))
+		assert.NoError(t, index.Delete(fmt.Sprintf("vec%d", i)))
+	}
+	assert.NoError(t, index.Close())
+
+	loaded, err := NewVamana(params, dim, "euclidean", path)
+	assert.NoError(t, err)
+	defer loaded.Close()
+	assert.NoError(t, loaded.Load(buf))
+	assert.Len(t, loaded.ids, 300)
+	info, err := loaded.file.Stat()
+	assert.NoError(t, err)
+	assert.Equal(t, 300*loaded.record, info.Size())
+	for i, q := range queries {
+		results, err := loaded.Search(q, 10, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[i], results)
+	}
+}
@@ -0,0 +1,364 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+	"vectordb/model"
+
+	"go.etcd.io/bbolt"
+)
+
+// metadata index types, integer and float values share the order preserving float64 encoding
+const (
+	metaIndexKeyword = "keyword"
+	metaIndexInteger = "integer"
+	metaIndexFloat   = "float"
+	metaIndexBool    = "bool"
+)
+
+// every indexed field has a bucket under bucketCollectionIndexes whose keys are the encoded
+// value followed by the object id, so that objects are sorted by value within a bucket
+
+func validateMetadataIndexes(indexes map[string]string, mapping []string) error {
+	for field, typ := range indexes {
+		if !slices.Contains(mapping, field) {
+			return fmt.Errorf("indexed metadata field '%s' not found in mapping", field)
+		}
+		switch typ {
+		case metaIndexKeyword, metaIndexInteger, metaIndexFloat, metaIndexBool:
+		default:
+			return fmt.Errorf("unsupported metadata index type '%s' for field '%s'", typ, field)
+		}
+	}
+	return nil
+}
+
+// metaIndexPrefix encodes a metadata value as the prefix of its index keys,
+// ok is false if the value doesn't have the type of the index
+func metaIndexPrefix(typ string, value interface{}) ([]byte, bool) {
+	switch typ {
+	case metaIndexKeyword:
+		s, ok := value.(string)
+		if !ok || bytes.IndexByte([]byte(s), 0) >= 0 {
+			return nil, false
+		}
+		return append([]byte(s), 0), true
+	case metaIndexInteger, metaIndexFloat:
+		f, ok := toFloat(value)
+		if !ok || typ == metaIndexInteger && math.Trunc(f) != f {
+			return nil, false
+		}
+		if f == 0 {
+			f = 0 // -0 and 0 share a key
+		}
+		bits := math.Float64bits(f)
+		if bits>>63 == 0 {
+			bits |= 1 << 63
+		} else {
+			bits = ^bits
+		}
+		return binary.BigEndian.AppendUint64(nil, bits), true
+	case metaIndexBool:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, false
+		}
+		if b {
+			return []byte{1}, true
+		}
+		return []byte{0}, true
+	}
+	return nil, false
+}
+
+// decodeMetaIndexKey returns the value and the object id of an index key
+func decodeMetaIndexKey(typ string, key []byte) (interface{}, string) {
+	switch typ {
+	case metaIndexKeyword:
+		i := bytes.IndexByte(key, 0)
+		return string(key[:i]), string(key[i+1:])
+	case metaIndexInteger, metaIndexFloat:
+		bits := binary.BigEndian.Uint64(key)
+		if bits>>63 == 1 {
+			bits &^= 1 << 63
+		} else {
+			bits = ^bits
+		}
+		return math.Float64frombits(bits), string(key[8:])
+	default:
+		return key[0] == 1, string(key[1:])
+	}
+}
+
+// validateMetadataTypes checks that the indexed fields of an object have the type of their index,
+// missing optional fields aren't indexed
+func (c *Collection) validateMetadataTypes(metadata map[string]interface{}) error {
+	for field, typ := range c.config.MetadataIndexes {
+		if metadata[field] == nil {
+			continue
+		}
+		if _, ok := metaIndexPrefix(typ, metadata[field]); !ok {
+			return fmt.Errorf("metadata field '%s' must be a %s", field, typ)
+		}
+	}
+	return nil
+}
+
+// updateMetadataIndexes replaces the index entries of an object, old is nil for new objects
+// and new is nil for deleted ones
+func (c *Collection) updateMetadataIndexes(tx *bbolt.Tx, id string, old, new map[string]interface{}) error {
+	if len(c.config.MetadataIndexes) == 0 {
+		return nil
+	}
+	idxBucket := tx.Bucket([]byte(c.name)).Bucket([]byte(bucketCollectionIndexes))
+
+	for field, typ := range c.config.MetadataIndexes {
+		fieldBucket := idxBucket.Bucket([]byte(field))
+		if old != nil {
+			if prefix, ok := metaIndexPrefix(typ, old[field]); ok {
+				if err := fieldBucket.Delete(append(prefix, id...)); err != nil {
+					return fmt.Errorf("failed to delete metadata index of field '%s': %w", field, err)
+				}
+			}
+		}
+		if new != nil && new[field] != nil {
+			prefix, ok := metaIndexPrefix(typ, new[field])
+			if !ok {
+				return fmt.Errorf("metadata field '%s' must be a %s", field, typ)
+			}
+			if err := fieldBucket.Put(append(prefix, id...), []byte{}); err != nil {
+				return fmt.Errorf("failed to put metadata index of field '%s': %w", field, err)
+			}
+		}
+	}
+	return nil
+}
+
+// indexedCandidates resolves as much of a filter as possible with the metadata indexes, it returns
+// nil if the filter can't be narrowed down, exact reports whether every returned id matches the filter
+func (c *Collection) indexedCandidates(tx *bbolt.Tx, f *model.Filter) (map[string]struct{}, bool) {
+	switch {
+	case f.And != nil:
+		var ids map[string]struct{}
+		exact := true
+		for i := range f.And {
+			sub, subexact := c.indexedCandidates(tx, &f.And[i])
+			if sub == nil {
+				exact = false
+				continue
+			}
+			exact = exact && subexact
+			if ids == nil {
+				ids = sub
+				continue
+			}
+			for id := range ids {
+				if _, ok := sub[id]; !ok {
+					delete(ids, id)
+				}
+			}
+		}
+		return ids, ids != nil && exact
+	case f.Or != nil:
+		ids := make(map[string]struct{})
+		exact := true
+		for i := range f.Or {
+			sub, subexact := c.indexedCandidates(tx, &f.Or[i])
+			if sub == nil {
+				return nil, false
+			}
+			exact = exact && subexact
+			for id := range sub {
+				ids[id] = struct{}{}
+			}
+		}
+		return ids, exact
+	case f.Not != nil:
+		return nil, false
+	}
+
+	typ, ok := c.config.MetadataIndexes[f.Field]
+	if !ok {
+		return nil, false
+	}
+	fieldBucket := tx.Bucket([]byte(c.name)).Bucket([]byte(bucketCollectionIndexes)).Bucket([]byte(f.Field))
+
+	ids := make(map[string]struct{})
+	switch f.Op {
+	case "eq":
+		scanMetaIndexPrefix(fieldBucket, typ, f.Value, ids)
+	case "in":
+		for _, v := range f.Values {
+			scanMetaIndexPrefix(fieldBucket, typ, v, ids)
+		}
+	case "range":
+		scanMetaIndexRange(fieldBucket, typ, f, ids)
+	default:
+		return nil, false
+	}
+	return ids, true
+}
+
+// indexedIDs walks the ids matching a filter in id order, after the given id, with the cursors of
+// the metadata indexes. Ids are only sorted within a value, so eq and in filters can be walked but
+// range filters can't, it returns nil then. exact reports whether every id matches the filter
+func (c *Collection) indexedIDs(tx *bbolt.Tx, f *model.Filter, after string) (idMerge, bool) {
+	switch {
+	case f.And != nil:
+		// the first walkable filter drives the others, the objects are matched against them
+		for i := range f.And {
+			if ids, exact := c.indexedIDs(tx, &f.And[i], after); ids != nil {
+				return ids, exact && len(f.And) == 1
+			}
+		}
+		return nil, false
+	case f.Or != nil:
+		ids := idMerge{}
+		exact := true
+		for i := range f.Or {
+			sub, subexact := c.indexedIDs(tx, &f.Or[i], after)
+			if sub == nil {
+				return nil, false
+			}
+			exact = exact && subexact
+			ids = append(ids, sub...)
+		}
+		return ids, exact
+	case f.Not != nil:
+		return nil, false
+	}
+
+	typ, ok := c.config.MetadataIndexes[f.Field]
+	if !ok {
+		return nil, false
+	}
+	fieldBucket := tx.Bucket([]byte(c.name)).Bucket([]byte(bucketCollectionIndexes)).Bucket([]byte(f.Field))
+
+	values := f.Values
+	switch f.Op {
+	case "eq":
+		values = []interface{}{f.Value}
+	case "in":
+	default:
+		return nil, false
+	}
+	ids := idMerge{}
+	for _, v := range values {
+		if prefix, ok := metaIndexPrefix(typ, v); ok {
+			ids = append(ids, newIDCursor(fieldBucket, prefix, after))
+		}
+	}
+	return ids, true
+}
+
+// idCursor walks the ids of the objects whose field has the value encoded by prefix
+type idCursor struct {
+	cursor *bbolt.Cursor
+	prefix []byte
+	id     string
+	ok     bool // false once the ids of the value are exhausted
+}
+
+func newIDCursor(b *bbolt.Bucket, prefix []byte, after string) *idCursor {
+	ic := &idCursor{cursor: b.Cursor(), prefix: prefix}
+	ic.set(ic.cursor.Seek(append(slices.Clip(prefix), after...)))
+	if ic.ok && ic.id == after {
+		ic.set(ic.cursor.Next())
+	}
+	return ic
+}
+
+func (ic *idCursor) set(k, _ []byte) {
+	ic.ok = k != nil && bytes.HasPrefix(k, ic.prefix)
+	if ic.ok {
+		ic.id = string(k[len(ic.prefix):])
+	}
+}
+
+// idMerge walks the union of id cursors in id order
+type idMerge []*idCursor
+
+// next returns the smallest id of the cursors and moves the cursors past it
+func (m idMerge) next() (string, bool) {
+	var id string
+	found := false
+	for _, ic := range m {
+		if ic.ok && (!found || ic.id < id) {
+			id, found = ic.id, true
+		}
+	}
+	for _, ic := range m {
+		if ic.ok && ic.id == id {
+			ic.set(ic.cursor.Next())
+		}
+	}
+	return id, found
+}
+
+// scanMetaIndexPrefix adds the objects whose field equals the value
+func scanMetaIndexPrefix(b *bbolt.Bucket, typ string, value interface{}, ids map[string]struct{}) {
+	prefix, ok := metaIndexPrefix(typ, value)
+	if !ok {
+		return
+	}
+
+	cursor := b.Cursor()
+	for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+		ids[string(k[len(prefix):])] = struct{}{}
+	}
+}
+
+// scanMetaIndexRange adds the objects whose field is within the range bounds,
+// starting at the lower bound and stopping after the upper one
+func scanMetaIndexRange(b *bbolt.Bucket, typ string, f *model.Filter, ids map[string]struct{}) {
+	if typ == metaIndexBool {
+		return
+	}
+
+	// bounds needn't be whole numbers for integer fields
+	boundTyp := typ
+	if typ == metaIndexInteger {
+		boundTyp = metaIndexFloat
+	}
+
+	cursor := b.Cursor()
+	k, _ := cursor.First()
+	for _, lower := range []interface{}{f.Gte, f.Gt} {
+		if lower == nil {
+			continue
+		}
+		if prefix, ok := metaIndexPrefix(boundTyp, lower); ok {
+			k, _ = cursor.Seek(prefix)
+		} else if _, isString := lower.(string); !isString || typ != metaIndexKeyword {
+			return // the bound has another type than the field, nothing matches
+		}
+	}
+
+	for ; k != nil; k, _ = cursor.Next() {
+		value, id := decodeMetaIndexKey(typ, k)
+		if f.Lt != nil {
+			if c, ok := compareValues(value, f.Lt); !ok || c >= 0 {
+				return
+			}
+		}
+		if f.Lte != nil {
+			if c, ok := compareValues(value, f.Lte); !ok || c > 0 {
+				return
+			}
+		}
+		if f.Gt != nil {
+			if c, ok := compareValues(value, f.Gt); !ok || c <= 0 {
+				continue
+			}
+		}
+		if f.Gte != nil {
+			if c, ok := compareValues(value, f.Gte); !ok || c < 0 {
+				continue
+			}
+		}
+		ids[id] = struct{}{}
+	}
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func TestMetadataIndexes(t *testing.T) {
+	db := openDB(t, t.TempDir(), CheckpointPolicy{})
+	defer db.Close()
+
+	assert.Error(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "invalid", Dimension: 4, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 100.0},
+		Distance: "euclidean", Mapping: []string{"category"}, MetadataIndexes: map[string]string{"price": "float"},
+	}))
+	assert.NoError(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "test", Dimension: 4, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 1000.0},
+		Distance: "euclidean", Mapping: []string{"category", "price", "stock", "sale", "note"},
+		MetadataIndexes: map[string]string{"category": "keyword", "price": "float", "stock": "integer", "sale": "bool"},
+	}))
+
+	categories := []string{"shoes", "hats", "bags", "belts"}
+	ids := []string{}
+	for i := 0; i < 200; i++ {
+		id, err := collection(t, db, "test").InsertObject(&model.ReqInsertObject{
+			Metadata: map[string]interface{}{
+				"category": categories[i%4],
+				"price":    float64(i) - 50.5,
+				"stock":    float64(i % 7),
+				"sale":     i%3 == 0,
+				"note":     fmt.Sprintf("note%d", i%5),
+			},
+			Vector: []float32{rand.Float32(), rand.Float32(), rand.Float32(), rand.Float32()},
+		})
+		assert.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	// indexed fields must have the type of their index
+	_, err := collection(t, db, "test").InsertObject(&model.ReqInsertObject{
+		Metadata: map[string]interface{}{"category": "shoes", "price": 1.0, "stock": 1.5, "sale": true, "note": ""},
+		Vector:   []float32{0, 0, 0, 0},
+	})
+	assert.Error(t, err)
+
+	// counts from the indexes agree with a full scan
+	filters := []string{
+		`{"field": "category", "op": "eq", "value": "shoes"}`,
+		`{"field": "category", "op": "in", "values": ["hats", "bags", "socks"]}`,
+		`{"field": "category", "op": "range", "gte": "bags", "lt": "hats"}`,
+		`{"field": "price", "op": "range", "gt": -10, "lte": 20.5}`,
+		`{"field": "price", "op": "range", "lt": -40}`,
+		`{"field": "stock", "op": "eq", "value": 3}`,
+		`{"field": "stock", "op": "range", "gt": 2.5, "lt": 5}`,
+		`{"field": "stock", "op": "range", "gt": "a"}`,
+		`{"field": "sale", "op": "eq", "value": true}`,
+		`{"field": "sale", "op": "ne", "value": true}`,
+		`{"and": [{"field": "category", "op": "eq", "value": "shoes"}, {"field": "price", "op": "range", "lt": 100}]}`,
+		`{"and": [{"field": "category", "op": "eq", "value": "shoes"}, {"field": "note", "op": "eq", "value": "note1"}]}`,
+		`{"or": [{"field": "sale", "op": "eq", "value": false}, {"field": "stock", "op": "in", "values": [0, 1]}]}`,
+		`{"or": [{"field": "sale", "op": "eq", "value": false}, {"field": "note", "op": "eq", "value": "note1"}]}`,
+		`{"not": {"field": "category", "op": "eq", "value": "shoes"}}`,
+	}
+	col, err := db.Collection("test")
+	assert.NoError(t, err)
+	expectedCount := func(f *model.Filter) int {
+		cnt := 0
+		for _, id := range ids {
+			obj, err := col.GetObjectInfo(id)
+			if err == nil && matchFilter(f, obj.Metadata) {
+				cnt++
+			}
+		}
+		return cnt
+	}
+	for _, data := range filters {
+		f := parseFilter(t, data)
+		res, err := collection(t, db, "test").CountObjects(&model.ReqCountObjects{Filter: f})
+		assert.NoError(t, err, data)
+		assert.Equal(t, expectedCount(f), res.Count, data)
+	}
+	res, err := collection(t, db, "test").CountObjects(&model.ReqCountObjects{})
+	assert.NoError(t, err)
+	assert.Equal(t, 200, res.Count)
+
+	// updates and deletes keep the indexes in sync
+	shoes := parseFilter(t, `{"field": "category", "op": "eq", "value": "shoes"}`)
+	assert.NoError(t, collection(t, db, "test").UpdateObject(&model.ReqUpdateObject{
+		ID:       ids[1],
+		Metadata: map[string]interface{}{"category": "shoes", "price": 1.0, "stock": 1.0, "sale": true, "note": ""},
+		Vector:   []float32{0, 0, 0, 0},
+	}))
+	assert.NoError(t, collection(t, db, "test").DeleteObject(ids[0]))
+	res, err = collection(t, db, "test").CountObjects(&model.ReqCountObjects{Filter: shoes})
+	assert.NoError(t, err)
+	assert.Equal(t, 50, res.Count)
+
+	entries := 0
+	assert.NoError(t, db.kv.View(func(tx *bbolt.Tx) error {
+		fieldBucket := tx.Bucket([]byte("test")).Bucket([]byte(bucketCollectionIndexes)).Bucket([]byte("category"))
+		entries = fieldBucket.Stats().KeyN
+		return nil
+	}))
+	assert.Equal(t, 199, entries)
+
+	// filtered search only reads the candidates from the indexes
+	results, err := collection(t, db, "test").SearchObjects(&model.ReqSearchObject{Vector: []float32{0, 0, 0, 0}, TopK: 10, Filter: shoes})
+	assert.NoError(t, err)
+	assert.Len(t, results, 10)
+	assert.Equal(t, ids[1], results[0].ID)
+	for _, result := range results {
+		assert.Equal(t, "shoes", result.Metadata["category"])
+	}
+}
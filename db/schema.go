@@ -0,0 +1,183 @@
+package db
+
+import (
+	"fmt"
+	"math"
+	"time"
+	"vectordb/model"
+)
+
+// schema field types
+const (
+	fieldString      = "string"
+	fieldInt         = "int"
+	fieldFloat       = "float"
+	fieldBool        = "bool"
+	fieldDatetime    = "datetime" // RFC 3339 strings, stored as UTC time
+	fieldStringArray = "string_array"
+	fieldObject      = "object" // nested fields
+)
+
+// validateSchema checks the field definitions and normalizes their default values
+func validateSchema(fields []model.Field) error {
+	names := make(map[string]struct{}, len(fields))
+	for i := range fields {
+		field := &fields[i]
+		if field.Name == "" {
+			return fmt.Errorf("schema field name must not be empty")
+		}
+		if _, ok := names[field.Name]; ok {
+			return fmt.Errorf("duplicate schema field '%s'", field.Name)
+		}
+		names[field.Name] = struct{}{}
+
+		switch field.Type {
+		case fieldString, fieldInt, fieldFloat, fieldBool, fieldDatetime, fieldStringArray:
+			if len(field.Fields) > 0 {
+				return fmt.Errorf("schema field '%s' of type %s can't have nested fields", field.Name, field.Type)
+			}
+		case fieldObject:
+			if err := validateSchema(field.Fields); err != nil {
+				return fmt.Errorf("%w in '%s'", err, field.Name)
+			}
+		default:
+			return fmt.Errorf("unsupported type '%s' of schema field '%s'", field.Type, field.Name)
+		}
+
+		if field.Default != nil {
+			if field.Required {
+				return fmt.Errorf("required schema field '%s' can't have a default value", field.Name)
+			}
+			value, err := normalizeValue(field, field.Default)
+			if err != nil {
+				return fmt.Errorf("invalid default value: %w", err)
+			}
+			field.Default = value
+		}
+	}
+	return nil
+}
+
+// applySchema validates metadata against the schema and returns it with normalized values,
+// missing optional fields get their default value if they have one
+func applySchema(fields []model.Field, metadata map[string]interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(fields))
+	known := make(map[string]struct{}, len(fields))
+
+	for i := range fields {
+		field := &fields[i]
+		known[field.Name] = struct{}{}
+
+		value, ok := metadata[field.Name]
+		if !ok || value == nil {
+			switch {
+			case field.Default != nil:
+				result[field.Name] = field.Default
+			case field.Required:
+				return nil, fmt.Errorf("required metadata field '%s' is missing", field.Name)
+			}
+			continue
+		}
+
+		value, err := normalizeValue(field, value)
+		if err != nil {
+			return nil, err
+		}
+		result[field.Name] = value
+	}
+
+	for key := range metadata {
+		if _, ok := known[key]; !ok {
+			return nil, fmt.Errorf("metadata field '%s' not found in schema", key)
+		}
+	}
+
+	return result, nil
+}
+
+// normalizeValue converts a decoded JSON value to the type of the field:
+// int64, float64, string, bool, time.Time, []string or map[string]interface{}
+func normalizeValue(field *model.Field, value interface{}) (interface{}, error) {
+	switch field.Type {
+	case fieldString:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case fieldInt:
+		if f, ok := toFloat(value); ok && math.Trunc(f) == f && math.Abs(f) < 1<<63 {
+			return int64(f), nil
+		}
+	case fieldFloat:
+		if f, ok := toFloat(value); ok {
+			return f, nil
+		}
+	case fieldBool:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case fieldDatetime:
+		switch v := value.(type) {
+		case time.Time:
+			return v.UTC(), nil
+		case string:
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return nil, fmt.Errorf("metadata field '%s' must be an RFC 3339 datetime", field.Name)
+			}
+			return t.UTC(), nil
+		}
+	case fieldStringArray:
+		switch v := value.(type) {
+		case []string:
+			return v, nil
+		case []interface{}:
+			arr := make([]string, 0, len(v))
+			for _, e := range v {
+				s, ok := e.(string)
+				if !ok {
+					return nil, fmt.Errorf("metadata field '%s' must be an array of strings", field.Name)
+				}
+				arr = append(arr, s)
+			}
+			return arr, nil
+		}
+	case fieldObject:
+		if m, ok := value.(map[string]interface{}); ok {
+			obj, err := applySchema(field.Fields, m)
+			if err != nil {
+				return nil, fmt.Errorf("%w in '%s'", err, field.Name)
+			}
+			return obj, nil
+		}
+	}
+
+	return nil, fmt.Errorf("metadata field '%s' must be a %s", field.Name, field.Type)
+}
+
+// validateSchemaIndexes checks that indexed schema fields have a type the index can hold
+func validateSchemaIndexes(indexes map[string]string, fields []model.Field) error {
+	if fields == nil {
+		return nil
+	}
+	for _, field := range fields {
+		typ, ok := indexes[field.Name]
+		if !ok {
+			continue
+		}
+		var compatible bool
+		switch typ {
+		case metaIndexKeyword:
+			compatible = field.Type == fieldString
+		case metaIndexInteger:
+			compatible = field.Type == fieldInt
+		case metaIndexFloat:
+			compatible = field.Type == fieldInt || field.Type == fieldFloat
+		case metaIndexBool:
+			compatible = field.Type == fieldBool
+		}
+		if !compatible {
+			return fmt.Errorf("schema field '%s' of type %s can't have a %s index", field.Name, field.Type, typ)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseSchema(t *testing.T, data string) []model.Field {
+	var fields []model.Field
+	assert.NoError(t, json.Unmarshal([]byte(data), &fields))
+	return fields
+}
+
+func TestValidateSchema(t *testing.T) {
+	invalid := []string{
+		`[{"name": "", "type": "string"}]`,
+		`[{"name": "a", "type": "string"}, {"name": "a", "type": "int"}]`,
+		`[{"name": "a", "type": "uuid"}]`,
+		`[{"name": "a", "type": "int", "fields": [{"name": "b", "type": "int"}]}]`,
+		`[{"name": "a", "type": "object", "fields": [{"name": "b", "type": "map"}]}]`,
+		`[{"name": "a", "type": "int", "required": true, "default": 1}]`,
+		`[{"name": "a", "type": "int", "default": 1.5}]`,
+		`[{"name": "a", "type": "datetime", "default": "yesterday"}]`,
+	}
+	for _, data := range invalid {
+		assert.Error(t, validateSchema(parseSchema(t, data)), data)
+	}
+
+	fields := parseSchema(t, `[
+		{"name": "count", "type": "int", "default": 3},
+		{"name": "created", "type": "datetime", "default": "2024-01-02T03:04:05+02:00"}
+	]`)
+	assert.NoError(t, validateSchema(fields))
+	assert.Equal(t, int64(3), fields[0].Default)
+	assert.Equal(t, time.Date(2024, 1, 2, 1, 4, 5, 0, time.UTC), fields[1].Default)
+}
+
+func TestApplySchema(t *testing.T) {
+	fields := parseSchema(t, `[
+		{"name": "title", "type": "string", "required": true},
+		{"name": "pages", "type": "int"},
+		{"name": "rating", "type": "float", "default": 0},
+		{"name": "published", "type": "bool"},
+		{"name": "created", "type": "datetime"},
+		{"name": "tags", "type": "string_array", "default": []},
+		{"name": "author", "type": "object", "fields": [
+			{"name": "name", "type": "string", "required": true},
+			{"name": "born", "type": "int"}
+		]}
+	]`)
+	assert.NoError(t, validateSchema(fields))
+
+	metadata := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"title": "dune", "pages": 412, "rating": 4, "published": true,
+		"created": "1965-08-01T00:00:00Z", "tags": ["scifi", "classic"],
+		"author": {"name": "herbert", "born": 1920}
+	}`), &metadata))
+	result, err := applySchema(fields, metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"title":     "dune",
+		"pages":     int64(412),
+		"rating":    4.0,
+		"published": true,
+		"created":   time.Date(1965, 8, 1, 0, 0, 0, 0, time.UTC),
+		"tags":      []string{"scifi", "classic"},
+		"author":    map[string]interface{}{"name": "herbert", "born": int64(1920)},
+	}, result)
+
+	// defaults fill missing optional fields, others stay missing
+	result, err = applySchema(fields, map[string]interface{}{"title": "emma", "pages": nil})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"title": "emma", "rating": 0.0, "tags": []string{}}, result)
+
+	invalid := []map[string]interface{}{
+		{},
+		{"title": 1.0},
+		{"title": "a", "pages": 1.5},
+		{"title": "a", "rating": "high"},
+		{"title": "a", "published": "yes"},
+		{"title": "a", "created": "monday"},
+		{"title": "a", "tags": []interface{}{"a", 1.0}},
+		{"title": "a", "author": map[string]interface{}{"born": 1.0}},
+		{"title": "a", "author": map[string]interface{}{"name": "b", "died": 1.0}},
+		{"title": "a", "isbn": "123"},
+	}
+	for _, metadata := range invalid {
+		_, err := applySchema(fields, metadata)
+		assert.Error(t, err, metadata)
+	}
+}
+
+func TestSchemaCollection(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+	defer func() { db.Close() }()
+
+	schema := parseSchema(t, `[
+		{"name": "title", "type": "string", "required": true},
+		{"name": "year", "type": "int"},
+		{"name": "tags", "type": "string_array"},
+		{"name": "created", "type": "datetime", "default": "2024-01-01T00:00:00Z"},
+		{"name": "author", "type": "object", "fields": [{"name": "name", "type": "string"}]}
+	]`)
+
+	// index types must fit the field types
+	assert.Error(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "invalid", Dimension: 2, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 100.0},
+		Distance: "euclidean", Schema: schema, MetadataIndexes: map[string]string{"title": "integer"},
+	}))
+	assert.NoError(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "books", Dimension: 2, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 100.0},
+		Distance: "euclidean", Schema: schema, MetadataIndexes: map[string]string{"year": "integer"},
+	}))
+
+	info, err := db.GetCollectionInfo("books")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"title", "year", "tags", "created", "author"}, info.Mapping)
+	assert.Equal(t, schema, info.Schema)
+
+	insert := func(data string) (string, error) {
+		obj := new(model.ReqInsertObject)
+		assert.NoError(t, json.Unmarshal([]byte(data), obj))
+		return collection(t, db, "books").InsertObject(obj)
+	}
+
+	id, err := insert(`{"metadata": {"title": "dune", "year": 1965, "tags": ["scifi"], "author": {"name": "herbert"}}, "vector": [1, 0]}`)
+	assert.NoError(t, err)
+	_, err = insert(`{"metadata": {"title": "emma", "tags": ["romance", "classic"], "created": "2024-06-01T00:00:00Z"}, "vector": [0, 1]}`)
+	assert.NoError(t, err)
+	_, err = insert(`{"metadata": {"year": 2000}, "vector": [0, 1]}`)
+	assert.Error(t, err)
+	_, err = insert(`{"metadata": {"title": "x", "year": "2000"}, "vector": [0, 1]}`)
+	assert.Error(t, err)
+
+	obj, err := collection(t, db, "books").GetObjectInfo(id)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1965), obj.Metadata["year"])
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), obj.Metadata["created"])
+
+	// updates are validated as well
+	assert.Error(t, collection(t, db, "books").UpdateObject(&model.ReqUpdateObject{
+		ID: id, Metadata: map[string]interface{}{"title": "dune", "year": 1965.5}, Vector: []float32{1, 0},
+	}))
+	assert.NoError(t, collection(t, db, "books").UpdateObject(&model.ReqUpdateObject{
+		ID: id, Metadata: map[string]interface{}{
+			"title": "dune", "year": 1966.0, "tags": []interface{}{"scifi"}, "author": map[string]interface{}{"name": "herbert"},
+		}, Vector: []float32{1, 0},
+	}))
+	obj, err = collection(t, db, "books").GetObjectInfo(id)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1966), obj.Metadata["year"])
+
+	filters := map[string]int{
+		`{"field": "year", "op": "eq", "value": 1966}`:                           1,
+		`{"field": "tags", "op": "eq", "value": "classic"}`:                      1,
+		`{"field": "tags", "op": "ne", "value": "scifi"}`:                        1,
+		`{"field": "author.name", "op": "eq", "value": "herbert"}`:               1,
+		`{"field": "created", "op": "range", "gt": "2024-03-01T00:00:00+01:00"}`: 1,
+		`{"field": "created", "op": "range", "lte": "2024-06-01T00:00:00Z"}`:     2,
+	}
+	for data, expected := range filters {
+		res, err := collection(t, db, "books").CountObjects(&model.ReqCountObjects{Filter: parseFilter(t, data)})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, res.Count, data)
+	}
+
+	// the schema and normalized values survive a restart
+	db.Close()
+	db = openDB(t, dir, CheckpointPolicy{})
+	info, err = db.GetCollectionInfo("books")
+	assert.NoError(t, err)
+	assert.Equal(t, schema, info.Schema)
+	obj, err = collection(t, db, "books").GetObjectInfo(id)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"scifi"}, obj.Metadata["tags"])
+}
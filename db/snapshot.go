@@ -0,0 +1,118 @@
+package db
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// snapshot file layout: magic | version | wal sequence | index payload
+const (
+	snapshotMagic   uint32 = 0x56444253 // "VDBS"
+	snapshotVersion uint32 = 1
+)
+
+type snapshotHeader struct {
+	Magic   uint32
+	Version uint32
+	Seq     uint64 // last WAL sequence already applied to the index payload
+}
+
+func (db *DB) snapshotPath(colname string) string {
+	return filepath.Join(db.path, colname+".snapshot")
+}
+
+// indexPath is the data file of on-disk indexes, the snapshot only holds their in-memory state
+func (db *DB) indexPath(colname string) string {
+	return filepath.Join(db.path, colname+".index")
+}
+
+func (db *DB) walPath(colname string) string {
+	return filepath.Join(db.path, colname+".wal")
+}
+
+// saveSnapshot expects the caller to hold c.mu, so that the index state and c.seq agree
+func (c *Collection) saveSnapshot() error {
+	// a snapshot past the entries replay couldn't apply would hide them from the next load and Fsck
+	if len(c.walErrors) > 0 {
+		return fmt.Errorf("collection '%s' has WAL errors, repair it with fsck first", c.name)
+	}
+
+	path := c.db.snapshotPath(c.name)
+	tmppath := path + ".tmp"
+
+	f, err := os.OpenFile(tmppath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer os.Remove(tmppath) // no-op once renamed
+
+	w := bufio.NewWriter(f)
+	header := snapshotHeader{
+		Magic:   snapshotMagic,
+		Version: snapshotVersion,
+		Seq:     c.seq,
+	}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+	if err := c.index.Save(w); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to save index: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to flush snapshot file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to sync snapshot file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close snapshot file: %w", err)
+	}
+
+	// atomically replace the previous snapshot
+	if err := os.Rename(tmppath, path); err != nil {
+		return fmt.Errorf("failed to rename snapshot file: %w", err)
+	}
+	c.snapshotSeq = header.Seq
+
+	return nil
+}
+
+// loadSnapshot restores the index from the latest snapshot if one exists, and returns
+// the WAL sequence it covers, 0 means there is no snapshot and the whole WAL must be replayed
+func (c *Collection) loadSnapshot() (uint64, error) {
+	f, err := os.Open(c.db.snapshotPath(c.name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	header := snapshotHeader{}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return 0, fmt.Errorf("failed to read snapshot header: %w", err)
+	}
+	if header.Magic != snapshotMagic {
+		return 0, fmt.Errorf("invalid snapshot file")
+	}
+	if header.Version != snapshotVersion {
+		return 0, fmt.Errorf("unsupported snapshot version %d", header.Version)
+	}
+
+	if err := c.index.Load(r); err != nil {
+		return 0, fmt.Errorf("failed to load index: %w", err)
+	}
+	c.snapshotSeq = header.Seq
+
+	return header.Seq, nil
+}
@@ -0,0 +1,301 @@
+package db
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"vectordb/db/index"
+	"vectordb/model"
+	"vectordb/pkg"
+
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+// Write path: the objects are written to bbolt first, then the WAL entry carrying the full objects
+// is appended as the last step of the same bbolt transaction, which also stores its sequence
+// under walSeqKey. The index is mutated around the commit and reverted if the transaction fails.
+//
+// On replay the entries up to walSeqKey are applied to the index only, the entry after it was
+// synced to the WAL but the crash hit before the commit and is redone in bbolt as well. Committed
+// entries that can't be read or applied are only reported, replay doesn't write anything but the
+// redone entries: the index snapshot stays behind them until Fsck rebuilds the index, so they
+// are found again by every load. An entry that can't be redone fails the replay so that
+// walSeqKey never moves past it.
+
+type WALEntryType int
+
+const (
+	WALInsert WALEntryType = iota
+	WALDelete
+	WALUpdate
+	WALBatch
+)
+
+type WALEntry struct {
+	Type     WALEntryType
+	ID       string
+	Vector   []float32
+	Metadata map[string]interface{}
+	Batch    []WALEntry // entries of a WALBatch record
+}
+
+// key of the last WAL sequence committed to the collection bucket, collections written before
+// it existed have all their WAL entries committed
+const walSeqKey = "wal_seq"
+
+func (c *Collection) replayWAL() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	first, err := c.wal.FirstIndex()
+	if err != nil {
+		return err
+	}
+	last, err := c.wal.LastIndex()
+	if err != nil {
+		return err
+	}
+
+	// an empty WAL can't be truncated back after a failed commit, start it with an empty batch
+	if last == 0 {
+		if err := c.writeWAL(WALEntry{Type: WALBatch}); err != nil {
+			return err
+		}
+		return nil
+	}
+	c.seq = last
+
+	committed := last
+	if err := c.db.kv.View(func(tx *bbolt.Tx) error {
+		if colBucket := tx.Bucket([]byte(c.name)); colBucket != nil {
+			if v := colBucket.Get([]byte(walSeqKey)); v != nil {
+				committed = binary.BigEndian.Uint64(v)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if first <= c.snapshotSeq {
+		first = c.snapshotSeq + 1
+	}
+
+	c.walErrors = nil
+	for i := first; i <= last; i++ {
+		entry, err := c.readWAL(i)
+		if err != nil && i > committed {
+			return fmt.Errorf("entry %d can't be redone: %w", i, err)
+		}
+		if err != nil {
+			// the index misses the entry until Fsck repairs it
+			c.reportWALError(i, err)
+			continue
+		}
+
+		if i > committed {
+			if err := c.db.kv.Update(func(tx *bbolt.Tx) error {
+				if err := c.redoEntry(tx, entry); err != nil {
+					return err
+				}
+				return putWALSeq(tx, c.name, i)
+			}); err != nil {
+				return fmt.Errorf("entry %d can't be redone: %w", i, err)
+			}
+		}
+
+		if err := c.applyIndexEntry(entry); err != nil {
+			c.reportWALError(i, fmt.Errorf("failed to apply entry to index: %w", err))
+		}
+	}
+
+	return nil
+}
+
+func (c *Collection) readWAL(seq uint64) (WALEntry, error) {
+	var entry WALEntry
+	data, err := c.wal.Read(seq)
+	if err != nil {
+		return entry, fmt.Errorf("failed to read entry: %w", err)
+	}
+	if err := pkg.Deserialize(data, &entry); err != nil {
+		return entry, fmt.Errorf("failed to deserialize entry: %w", err)
+	}
+	return entry, nil
+}
+
+func (c *Collection) reportWALError(seq uint64, err error) {
+	zap.L().Error("WAL replay", zap.String("collection", c.name), zap.Uint64("seq", seq), zap.Error(err))
+	c.walErrors = append(c.walErrors, fmt.Sprintf("entry %d: %v", seq, err))
+}
+
+// redoEntry writes an entry whose transaction didn't commit to bbolt
+func (c *Collection) redoEntry(tx *bbolt.Tx, entry WALEntry) error {
+	switch entry.Type {
+	case WALInsert, WALUpdate:
+		objBucket := tx.Bucket([]byte(c.name)).Bucket([]byte(bucketCollectionObjects))
+
+		var oldMetadata map[string]interface{}
+		if exist := objBucket.Get([]byte(entry.ID)); exist != nil {
+			old := new(model.ReqInsertObject)
+			if err := pkg.Deserialize(exist, old); err != nil {
+				return fmt.Errorf("failed to deserialize object: %w", err)
+			}
+			oldMetadata = old.Metadata
+		}
+
+		objBytes, err := pkg.Serialize(&model.ReqInsertObject{
+			ID:       entry.ID,
+			Metadata: entry.Metadata,
+			Vector:   entry.Vector,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to serialize object: %w", err)
+		}
+		if err := objBucket.Put([]byte(entry.ID), objBytes); err != nil {
+			return fmt.Errorf("failed to put object under collection '%s': %w", c.name, err)
+		}
+		return c.updateMetadataIndexes(tx, entry.ID, oldMetadata, entry.Metadata)
+	case WALDelete:
+		return c.deleteObject(tx, entry.ID)
+	case WALBatch:
+		for _, e := range entry.Batch {
+			if err := c.redoEntry(tx, e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyIndexEntry applies an entry to the index, entries may already be applied partially
+// so inserts and updates fall back to each other and deleting a missing vector is fine
+func (c *Collection) applyIndexEntry(entry WALEntry) error {
+	switch entry.Type {
+	case WALInsert:
+		if err := c.index.Insert(entry.ID, entry.Vector); err != nil {
+			return c.index.Update(entry.ID, entry.Vector)
+		}
+	case WALUpdate:
+		if err := c.index.Update(entry.ID, entry.Vector); err != nil {
+			return c.index.Insert(entry.ID, entry.Vector)
+		}
+	case WALDelete:
+		c.index.Delete(entry.ID)
+	case WALBatch:
+		for _, e := range entry.Batch {
+			if err := c.applyIndexEntry(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Collection) writeWAL(entry WALEntry) error {
+	walData, err := pkg.Serialize(entry)
+	if err != nil {
+		return fmt.Errorf("failed to serialize WAL entry: %w", err)
+	}
+
+	if err := c.wal.Write(c.seq+1, walData); err != nil {
+		return fmt.Errorf("failed to write to WAL: %w", err)
+	}
+	c.seq++
+
+	c.walBytes.Add(int64(len(walData)))
+	c.maybeCheckpoint()
+
+	return nil
+}
+
+// writeWALBatch writes the entries as a single WAL record so that replay applies all or none of them
+func (c *Collection) writeWALBatch(entries []WALEntry) error {
+	if len(entries) == 1 {
+		return c.writeWAL(entries[0])
+	}
+
+	return c.writeWAL(WALEntry{
+		Type:  WALBatch,
+		Batch: entries,
+	})
+}
+
+// commit runs fn in a bbolt transaction and logs the entries it returns to the WAL before the
+// transaction commits, the WAL is truncated back if the commit fails. The caller holds c.mu
+func (c *Collection) commit(fn func(tx *bbolt.Tx) ([]WALEntry, error)) error {
+	seq := c.seq
+	err := c.db.kv.Update(func(tx *bbolt.Tx) error {
+		entries, err := fn(tx)
+		if err != nil {
+			return err
+		}
+		if err := c.writeWALBatch(entries); err != nil {
+			return err
+		}
+		return putWALSeq(tx, c.name, c.seq)
+	})
+	if err != nil && c.seq != seq {
+		if terr := c.wal.TruncateBack(seq); terr != nil {
+			return fmt.Errorf("%w, failed to truncate WAL: %v", err, terr)
+		}
+		c.seq = seq
+	}
+	return err
+}
+
+func putWALSeq(tx *bbolt.Tx, colname string, seq uint64) error {
+	colBucket := tx.Bucket([]byte(colname))
+	if colBucket == nil {
+		return fmt.Errorf("bucket for collection '%s' not found", colname)
+	}
+	if err := colBucket.Put([]byte(walSeqKey), binary.BigEndian.AppendUint64(nil, seq)); err != nil {
+		return fmt.Errorf("failed to put WAL sequence: %w", err)
+	}
+	return nil
+}
+
+// rebuildIndex replaces the index with a new one holding the vectors of the object bucket and
+// returns the ids of the objects that couldn't be decoded, the caller holds c.mu
+func (c *Collection) rebuildIndex() ([]string, error) {
+	if closer, ok := c.index.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return nil, fmt.Errorf("failed to close index: %w", err)
+		}
+	}
+	if err := os.Truncate(c.db.indexPath(c.name), 0); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to truncate index file: %w", err)
+	}
+
+	idx, err := index.NewIndexer(&c.config, c.db.indexPath(c.name))
+	if err != nil {
+		return nil, err
+	}
+	c.index = idx
+
+	var corrupt []string
+	if err := c.db.kv.View(func(tx *bbolt.Tx) error {
+		colBucket := tx.Bucket([]byte(c.name))
+		if colBucket == nil {
+			return nil
+		}
+		return colBucket.Bucket([]byte(bucketCollectionObjects)).ForEach(func(k, v []byte) error {
+			obj := new(model.ReqInsertObject)
+			if err := pkg.Deserialize(v, obj); err != nil {
+				corrupt = append(corrupt, string(k))
+				return nil
+			}
+			if err := c.index.Insert(string(k), obj.Vector); err != nil {
+				return fmt.Errorf("failed to insert object %s: %w", k, err)
+			}
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return corrupt, nil
+}
@@ -0,0 +1,214 @@
+package db
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+	"vectordb/model"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+// crash closes the data files without the shutdown snapshot, as if the process died
+func crash(t *testing.T, db *DB) {
+	db.stopCheckpointer()
+	for _, col := range db.collections {
+		if closer, ok := col.index.(io.Closer); ok {
+			assert.NoError(t, closer.Close())
+		}
+		assert.NoError(t, col.wal.Close())
+	}
+	assert.NoError(t, db.kv.Close())
+}
+
+func createWALTestCollection(t *testing.T, db *DB) {
+	assert.NoError(t, db.CreateCollection(&model.ReqCreateCollection{
+		Name: "test", Dimension: 2, IndexType: "flat", IndexParams: map[string]interface{}{"maxsize": 100.0},
+		Distance: "euclidean", Mapping: []string{"category"}, MetadataIndexes: map[string]string{"category": "keyword"},
+	}))
+}
+
+func insertWALTestObject(t *testing.T, db *DB, id, category string, vector []float32) {
+	_, err := collection(t, db, "test").InsertObject(&model.ReqInsertObject{
+		ID: id, Metadata: map[string]interface{}{"category": category}, Vector: vector,
+	})
+	assert.NoError(t, err)
+}
+
+// assertConsistent checks that the index, the object bucket and the metadata index agree
+func assertConsistent(t *testing.T, db *DB, expected map[string][]float32) {
+	res, err := collection(t, db, "test").CountObjects(&model.ReqCountObjects{})
+	assert.NoError(t, err)
+	assert.Equal(t, len(expected), res.Count)
+
+	for id, vector := range expected {
+		obj, err := collection(t, db, "test").GetObjectInfo(id)
+		assert.NoError(t, err, id)
+		assert.Equal(t, vector, obj.Vector, id)
+
+		results, err := collection(t, db, "test").SearchObjects(&model.ReqSearchObject{
+			Vector: vector, TopK: 1, Filter: &model.Filter{Field: "category", Op: "eq", Value: obj.Metadata["category"]},
+		})
+		assert.NoError(t, err, id)
+		if assert.Len(t, results, 1, id) {
+			assert.Equal(t, id, results[0].ID)
+		}
+	}
+
+	results, err := collection(t, db, "test").SearchObjects(&model.ReqSearchObject{Vector: []float32{0, 0}, TopK: 100})
+	assert.NoError(t, err)
+	assert.Len(t, results, len(expected))
+}
+
+func TestWALReplayAfterCrash(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+	createWALTestCollection(t, db)
+
+	insertWALTestObject(t, db, "a", "x", []float32{1, 0})
+	insertWALTestObject(t, db, "b", "y", []float32{2, 0})
+	_, err := db.CheckpointCollection("test")
+	assert.NoError(t, err)
+
+	// committed after the snapshot, only the WAL brings them back into the index
+	insertWALTestObject(t, db, "c", "x", []float32{3, 0})
+	assert.NoError(t, collection(t, db, "test").UpdateObject(&model.ReqUpdateObject{
+		ID: "a", Metadata: map[string]interface{}{"category": "y"}, Vector: []float32{1, 1},
+	}))
+	assert.NoError(t, collection(t, db, "test").DeleteObject("b"))
+	crash(t, db)
+
+	db = openDB(t, dir, CheckpointPolicy{})
+	defer db.Close()
+	assertConsistent(t, db, map[string][]float32{"a": {1, 1}, "c": {3, 0}})
+
+	info, err := db.GetCollectionInfo("test")
+	assert.NoError(t, err)
+	assert.Empty(t, info.WALErrors)
+}
+
+func TestWALRedoUncommittedEntry(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+	createWALTestCollection(t, db)
+
+	insertWALTestObject(t, db, "a", "x", []float32{1, 0})
+	insertWALTestObject(t, db, "b", "y", []float32{2, 0})
+
+	// the WAL entry is synced but the process dies before the bbolt transaction commits
+	col := db.collections["test"]
+	assert.NoError(t, col.writeWALBatch([]WALEntry{
+		{Type: WALInsert, ID: "c", Vector: []float32{3, 0}, Metadata: map[string]interface{}{"category": "z"}},
+		{Type: WALUpdate, ID: "a", Vector: []float32{1, 1}, Metadata: map[string]interface{}{"category": "z"}},
+		{Type: WALDelete, ID: "b"},
+	}))
+	crash(t, db)
+
+	db = openDB(t, dir, CheckpointPolicy{})
+	assertConsistent(t, db, map[string][]float32{"a": {1, 1}, "c": {3, 0}})
+	res, err := collection(t, db, "test").CountObjects(&model.ReqCountObjects{Filter: &model.Filter{Field: "category", Op: "eq", Value: "z"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.Count)
+
+	// the redone entry counts as committed from now on
+	insertWALTestObject(t, db, "d", "x", []float32{4, 0})
+	crash(t, db)
+
+	db = openDB(t, dir, CheckpointPolicy{})
+	defer db.Close()
+	assertConsistent(t, db, map[string][]float32{"a": {1, 1}, "c": {3, 0}, "d": {4, 0}})
+}
+
+func TestWALFailedCommitIsTruncated(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+	createWALTestCollection(t, db)
+	insertWALTestObject(t, db, "a", "x", []float32{1, 0})
+
+	// the entry is written to the WAL, then the transaction fails
+	col := db.collections["test"]
+	seq := col.seq
+	col.mu.Lock()
+	err := col.commit(func(tx *bbolt.Tx) ([]WALEntry, error) {
+		if _, err := col.insertObject(tx, &model.ReqInsertObject{
+			ID: "b", Metadata: map[string]interface{}{"category": "x"}, Vector: []float32{2, 0},
+		}); err != nil {
+			return nil, err
+		}
+		assert.NoError(t, tx.DeleteBucket([]byte(col.name)))
+		return []WALEntry{{Type: WALInsert, ID: "b", Vector: []float32{2, 0}}}, nil
+	})
+	col.mu.Unlock()
+	assert.Error(t, err)
+	assert.Equal(t, seq, col.seq)
+	last, err := col.wal.LastIndex()
+	assert.NoError(t, err)
+	assert.Equal(t, seq, last)
+
+	insertWALTestObject(t, db, "c", "x", []float32{3, 0})
+	crash(t, db)
+
+	db = openDB(t, dir, CheckpointPolicy{})
+	defer db.Close()
+	assertConsistent(t, db, map[string][]float32{"a": {1, 0}, "c": {3, 0}})
+}
+
+func TestWALCorruptEntry(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+	createWALTestCollection(t, db)
+
+	insertWALTestObject(t, db, "a", "x", []float32{1, 0})
+	col := db.collections["test"]
+	assert.NoError(t, col.wal.Write(col.seq+1, []byte("not a gob entry")))
+	col.seq++
+	corrupt := col.seq
+	insertWALTestObject(t, db, "b", "y", []float32{2, 0})
+	crash(t, db)
+
+	// the corrupt entry is reported by every load until fsck repairs the collection
+	for i := 0; i < 2; i++ {
+		db = openDB(t, dir, CheckpointPolicy{})
+		info, err := db.GetCollectionInfo("test")
+		assert.NoError(t, err)
+		if assert.Len(t, info.WALErrors, 1) {
+			assert.Contains(t, info.WALErrors[0], fmt.Sprintf("entry %d:", corrupt))
+		}
+		assertConsistent(t, db, map[string][]float32{"a": {1, 0}, "b": {2, 0}})
+		assert.NoError(t, db.Close())
+	}
+}
+
+func TestWALRedoFailureFailsOpen(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, dir, CheckpointPolicy{})
+	createWALTestCollection(t, db)
+	insertWALTestObject(t, db, "a", "x", []float32{1, 0})
+
+	// an uncommitted entry that can't be redone, followed by one that can
+	col := db.collections["test"]
+	committed := col.seq
+	assert.NoError(t, col.wal.Write(col.seq+1, []byte("not a gob entry")))
+	col.seq++
+	assert.NoError(t, col.writeWALBatch([]WALEntry{
+		{Type: WALInsert, ID: "b", Vector: []float32{2, 0}, Metadata: map[string]interface{}{"category": "x"}},
+	}))
+	crash(t, db)
+
+	_, err := Open(dir, CheckpointPolicy{})
+	assert.ErrorContains(t, err, fmt.Sprintf("entry %d can't be redone", committed+1))
+
+	// the entries after the failed one are not redone either
+	kv, err := bbolt.Open(filepath.Join(dir, "vectordb.db"), 0600, nil)
+	assert.NoError(t, err)
+	defer kv.Close()
+	assert.NoError(t, kv.View(func(tx *bbolt.Tx) error {
+		colBucket := tx.Bucket([]byte("test"))
+		assert.Equal(t, committed, binary.BigEndian.Uint64(colBucket.Get([]byte(walSeqKey))))
+		assert.Nil(t, colBucket.Bucket([]byte(bucketCollectionObjects)).Get([]byte("b")))
+		return nil
+	}))
+}
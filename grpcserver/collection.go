@@ -0,0 +1,215 @@
+package grpcserver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"vectordb/model"
+	"vectordb/pb"
+)
+
+// size of the chunks backups and collection snapshots are streamed in
+const chunkSize = 64 << 10
+
+func (s *Server) GetDBInfo(ctx context.Context, req *pb.GetDBInfoRequest) (*pb.DBInfo, error) {
+	res, err := s.db.GetDBInfo()
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	return &pb.DBInfo{Collections: res.Collections, CollectionCount: int64(res.CollectionCount)}, nil
+}
+
+func (s *Server) Fsck(ctx context.Context, req *pb.FsckRequest) (*pb.FsckResponse, error) {
+	res, err := s.db.Fsck(&model.ReqFsck{Collections: req.GetCollections(), Repair: req.GetRepair()})
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	reports := make([]*pb.FsckReport, len(res))
+	for i, r := range res {
+		reports[i] = &pb.FsckReport{
+			Name:            r.Name,
+			ObjectCount:     int64(r.ObjectCount),
+			IndexCount:      int64(r.IndexCount),
+			OrphanedVectors: r.OrphanedVectors,
+			MissingVectors:  r.MissingVectors,
+			CorruptObjects:  r.CorruptObjects,
+			CorruptWal:      r.CorruptWAL,
+			Repaired:        r.Repaired,
+		}
+	}
+	return &pb.FsckResponse{Reports: reports}, nil
+}
+
+func (s *Server) Backup(req *pb.BackupRequest, stream pb.VectorDB_BackupServer) error {
+	w := bufio.NewWriterSize(chunkWriter{stream.Send}, chunkSize)
+	if err := s.db.Backup(w); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Server) CreateCollection(ctx context.Context, req *pb.CreateCollectionRequest) (*pb.Empty, error) {
+	col := &model.ReqCreateCollection{
+		Name:            req.GetName(),
+		Dimension:       int(req.GetDimension()),
+		IndexType:       req.GetIndexType(),
+		IndexParams:     fromStruct(req.GetIndexParams()),
+		Distance:        req.GetDistType(),
+		Mapping:         req.GetMapping(),
+		Quantization:    req.GetQuantization(),
+		MetadataIndexes: req.GetMetadataIndexes(),
+		Schema:          fromFields(req.GetSchema()),
+	}
+	if err := validate(col); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.CreateCollection(col); err != nil {
+		return nil, invalid(err)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) DeleteCollection(ctx context.Context, req *pb.CollectionRequest) (*pb.Empty, error) {
+	if err := s.db.DeleteCollection(req.GetCollection()); err != nil {
+		return nil, invalid(err)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) GetCollectionInfo(ctx context.Context, req *pb.CollectionRequest) (*pb.CollectionInfo, error) {
+	res, err := s.db.GetCollectionInfo(req.GetCollection())
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	params, err := toStruct(res.IndexParams)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := toFields(res.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CollectionInfo{
+		Name:            res.Name,
+		Dimension:       int64(res.Dimension),
+		IndexType:       res.IndexType,
+		IndexParams:     params,
+		DistType:        res.Distance,
+		Mapping:         res.Mapping,
+		Quantization:    res.Quantization,
+		ObjectCount:     int64(res.ObjectCount),
+		MetadataIndexes: res.MetadataIndexes,
+		Schema:          schema,
+		WalErrors:       res.WALErrors,
+	}, nil
+}
+
+func (s *Server) AlterCollection(ctx context.Context, req *pb.AlterCollectionRequest) (*pb.Empty, error) {
+	alter := &model.ReqAlterCollection{}
+	for _, c := range req.GetChanges() {
+		change := model.FieldChange{Op: c.GetOp(), Name: c.GetName(), NewName: c.GetNewName()}
+		if c.GetField() != nil {
+			field := fromField(c.GetField())
+			change.Field = &field
+		}
+		alter.Changes = append(alter.Changes, change)
+	}
+	if err := validate(alter); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.AlterCollection(req.GetCollection(), alter); err != nil {
+		return nil, invalid(err)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) CheckpointCollection(ctx context.Context, req *pb.CollectionRequest) (*pb.CheckpointResponse, error) {
+	res, err := s.db.CheckpointCollection(req.GetCollection())
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	return &pb.CheckpointResponse{Name: res.Name, Seq: res.Seq}, nil
+}
+
+func (s *Server) SnapshotCollection(req *pb.CollectionRequest, stream pb.VectorDB_SnapshotCollectionServer) error {
+	w := bufio.NewWriterSize(chunkWriter{stream.Send}, chunkSize)
+	if err := s.db.SnapshotCollection(req.GetCollection(), w); err != nil {
+		return invalid(err)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Server) RestoreCollection(stream pb.VectorDB_RestoreCollectionServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, "no collection snapshot received")
+		}
+		return err
+	}
+
+	r := &chunkReader{buf: first.GetData(), recv: func() ([]byte, error) {
+		msg, err := stream.Recv()
+		return msg.GetData(), err
+	}}
+	req := &model.ReqRestoreCollection{Overwrite: first.GetOverwrite()}
+	if err := s.db.RestoreCollection(first.GetCollection(), r, req); err != nil {
+		return invalid(err)
+	}
+
+	return stream.SendAndClose(&pb.Empty{})
+}
+
+// chunkWriter sends every write as a chunk, gRPC serializes the message before Send returns
+type chunkWriter struct {
+	send func(*pb.Chunk) error
+}
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	if err := w.send(&pb.Chunk{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// chunkReader reads the data of a stream of chunks until recv returns io.EOF
+type chunkReader struct {
+	buf  []byte
+	recv func() ([]byte, error)
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		data, err := r.recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
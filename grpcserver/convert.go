@@ -0,0 +1,168 @@
+package grpcserver
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"vectordb/model"
+	"vectordb/pb"
+)
+
+// Metadata and parameters cross the API as JSON values, like in the REST API: stored int64,
+// []string and time.Time values are sent as numbers, lists and RFC3339 strings, received
+// numbers are float64
+
+func toStruct(m map[string]interface{}) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to encode metadata: %v", err))
+	}
+	s := new(structpb.Struct)
+	if err := protojson.Unmarshal(data, s); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to encode metadata: %v", err))
+	}
+	return s, nil
+}
+
+func fromStruct(s *structpb.Struct) map[string]interface{} {
+	if s == nil {
+		return nil
+	}
+	return s.AsMap()
+}
+
+func toValue(v interface{}) (*structpb.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to encode value: %v", err))
+	}
+	value := new(structpb.Value)
+	if err := protojson.Unmarshal(data, value); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to encode value: %v", err))
+	}
+	return value, nil
+}
+
+func fromValue(v *structpb.Value) interface{} {
+	if v == nil {
+		return nil
+	}
+	return v.AsInterface()
+}
+
+func fromField(f *pb.Field) model.Field {
+	field := model.Field{
+		Name:     f.GetName(),
+		Type:     f.GetType(),
+		Required: f.GetRequired(),
+		Default:  fromValue(f.GetDefault()),
+	}
+	for _, sub := range f.GetFields() {
+		field.Fields = append(field.Fields, fromField(sub))
+	}
+	return field
+}
+
+func fromFields(fields []*pb.Field) []model.Field {
+	if len(fields) == 0 {
+		return nil
+	}
+	res := make([]model.Field, len(fields))
+	for i, f := range fields {
+		res[i] = fromField(f)
+	}
+	return res
+}
+
+func toFields(fields []model.Field) ([]*pb.Field, error) {
+	res := make([]*pb.Field, len(fields))
+	for i, f := range fields {
+		def, err := toValue(f.Default)
+		if err != nil {
+			return nil, err
+		}
+		sub, err := toFields(f.Fields)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = &pb.Field{Name: f.Name, Type: f.Type, Required: f.Required, Default: def, Fields: sub}
+	}
+	return res, nil
+}
+
+func fromFilter(f *pb.Filter) *model.Filter {
+	if f == nil {
+		return nil
+	}
+	filter := &model.Filter{
+		Not:   fromFilter(f.GetNot()),
+		Field: f.GetField(),
+		Op:    f.GetOp(),
+		Value: fromValue(f.GetValue()),
+		Gt:    fromValue(f.GetGt()),
+		Gte:   fromValue(f.GetGte()),
+		Lt:    fromValue(f.GetLt()),
+		Lte:   fromValue(f.GetLte()),
+	}
+	for _, sub := range f.GetAnd() {
+		filter.And = append(filter.And, *fromFilter(sub))
+	}
+	for _, sub := range f.GetOr() {
+		filter.Or = append(filter.Or, *fromFilter(sub))
+	}
+	for _, v := range f.GetValues() {
+		filter.Values = append(filter.Values, fromValue(v))
+	}
+	return filter
+}
+
+func fromObject(o *pb.Object) model.ReqInsertObject {
+	return model.ReqInsertObject{
+		ID:       o.GetId(),
+		Metadata: fromStruct(o.GetMetadata()),
+		Vector:   o.GetVector(),
+	}
+}
+
+// fromObjects converts and validates the objects of a batch request
+func fromObjects(objs []*pb.Object) (*model.ReqInsertObjects, error) {
+	req := &model.ReqInsertObjects{Objects: make([]model.ReqInsertObject, len(objs))}
+	for i, o := range objs {
+		req.Objects[i] = fromObject(o)
+	}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func toObject(o model.ResObjectInfo) (*pb.Object, error) {
+	metadata, err := toStruct(o.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Object{Id: o.ID, Metadata: metadata, Vector: o.Vector}, nil
+}
+
+func toObjects(objs []model.ResObjectInfo) ([]*pb.Object, error) {
+	res := make([]*pb.Object, len(objs))
+	for i, o := range objs {
+		obj, err := toObject(o)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = obj
+	}
+	return res, nil
+}
@@ -0,0 +1,279 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"vectordb/db"
+	"vectordb/model"
+	"vectordb/pb"
+)
+
+// default number of objects per message of ScrollObjects
+const defaultScrollBatchSize = 100
+
+func (s *Server) InsertObject(ctx context.Context, req *pb.InsertObjectRequest) (*pb.InsertObjectResponse, error) {
+	obj := fromObject(req.GetObject())
+	if err := validate(&obj); err != nil {
+		return nil, err
+	}
+
+	col, err := s.collection(req.GetCollection())
+	if err != nil {
+		return nil, err
+	}
+	id, err := col.InsertObject(&obj)
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	return &pb.InsertObjectResponse{Id: id}, nil
+}
+
+func (s *Server) InsertObjects(ctx context.Context, req *pb.InsertObjectsRequest) (*pb.InsertObjectsResponse, error) {
+	objs, err := fromObjects(req.GetObjects())
+	if err != nil {
+		return nil, err
+	}
+
+	col, err := s.collection(req.GetCollection())
+	if err != nil {
+		return nil, err
+	}
+	ids, err := col.InsertObjects(objs)
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	return &pb.InsertObjectsResponse{Ids: ids}, nil
+}
+
+func (s *Server) BulkInsertObjects(stream pb.VectorDB_BulkInsertObjectsServer) error {
+	var colname string
+	var col *db.Collection
+	ids := []string{}
+	for batch := 0; ; batch++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&pb.InsertObjectsResponse{Ids: ids})
+		}
+		if err != nil {
+			return err
+		}
+
+		if batch == 0 {
+			colname = req.GetCollection()
+			if col, err = s.collection(colname); err != nil {
+				return err
+			}
+		} else if req.GetCollection() != "" && req.GetCollection() != colname {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("batch %d targets collection '%s' instead of '%s'", batch, req.GetCollection(), colname))
+		}
+
+		objs, err := fromObjects(req.GetObjects())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("batch %d: %v, %d objects inserted before", batch, status.Convert(err).Message(), len(ids)))
+		}
+		batchIDs, err := col.InsertObjects(objs)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("batch %d: %v, %d objects inserted before", batch, err, len(ids)))
+		}
+		ids = append(ids, batchIDs...)
+	}
+}
+
+func (s *Server) UpsertObject(ctx context.Context, req *pb.InsertObjectRequest) (*pb.InsertObjectResponse, error) {
+	obj := fromObject(req.GetObject())
+	if err := validate(&obj); err != nil {
+		return nil, err
+	}
+
+	col, err := s.collection(req.GetCollection())
+	if err != nil {
+		return nil, err
+	}
+	if err := col.UpsertObject(&obj); err != nil {
+		return nil, invalid(err)
+	}
+
+	return &pb.InsertObjectResponse{Id: obj.ID}, nil
+}
+
+func (s *Server) UpsertObjects(ctx context.Context, req *pb.InsertObjectsRequest) (*pb.InsertObjectsResponse, error) {
+	objs, err := fromObjects(req.GetObjects())
+	if err != nil {
+		return nil, err
+	}
+
+	col, err := s.collection(req.GetCollection())
+	if err != nil {
+		return nil, err
+	}
+	if err := col.UpsertObjects(objs); err != nil {
+		return nil, invalid(err)
+	}
+
+	ids := make([]string, len(objs.Objects))
+	for i := range objs.Objects {
+		ids[i] = objs.Objects[i].ID
+	}
+	return &pb.InsertObjectsResponse{Ids: ids}, nil
+}
+
+func (s *Server) DeleteObject(ctx context.Context, req *pb.ObjectRequest) (*pb.Empty, error) {
+	col, err := s.collection(req.GetCollection())
+	if err != nil {
+		return nil, err
+	}
+	if err := col.DeleteObject(req.GetId()); err != nil {
+		return nil, invalid(err)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) UpdateObject(ctx context.Context, req *pb.UpdateObjectRequest) (*pb.Empty, error) {
+	obj := &model.ReqUpdateObject{
+		ID:       req.GetObject().GetId(),
+		Metadata: fromStruct(req.GetObject().GetMetadata()),
+		Vector:   req.GetObject().GetVector(),
+	}
+	if err := validate(obj); err != nil {
+		return nil, err
+	}
+
+	col, err := s.collection(req.GetCollection())
+	if err != nil {
+		return nil, err
+	}
+	if err := col.UpdateObject(obj); err != nil {
+		return nil, invalid(err)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) GetObjects(ctx context.Context, req *pb.GetObjectsRequest) (*pb.GetObjectsResponse, error) {
+	page := &model.ReqGetObjects{Offset: int(req.GetOffset()), Limit: int(req.GetLimit())}
+	if err := validate(page); err != nil {
+		return nil, err
+	}
+
+	col, err := s.collection(req.GetCollection())
+	if err != nil {
+		return nil, err
+	}
+	res, err := col.GetObjects(page.Offset, page.Limit)
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	objs, err := toObjects(res)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetObjectsResponse{Objects: objs}, nil
+}
+
+func (s *Server) GetObjectInfo(ctx context.Context, req *pb.ObjectRequest) (*pb.Object, error) {
+	col, err := s.collection(req.GetCollection())
+	if err != nil {
+		return nil, err
+	}
+	res, err := col.GetObjectInfo(req.GetId())
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	return toObject(res)
+}
+
+func (s *Server) ScrollObjects(req *pb.ScrollObjectsRequest, stream pb.VectorDB_ScrollObjectsServer) error {
+	size := int(req.GetBatchSize())
+	if size <= 0 {
+		size = defaultScrollBatchSize
+	}
+
+	col, err := s.collection(req.GetCollection())
+	if err != nil {
+		return err
+	}
+
+	page := &model.ReqScrollObjects{Limit: size, WithVector: true, WithMetadata: true}
+	// a message holds a whole page, the limit of the REST API keeps it below the message size limit
+	if err := validate(page); err != nil {
+		return err
+	}
+	for {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		res, err := col.ScrollObjects(page)
+		if err != nil {
+			return invalid(err)
+		}
+		if len(res.Objects) > 0 {
+			objs, err := toObjects(res.Objects)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(&pb.GetObjectsResponse{Objects: objs}); err != nil {
+				return err
+			}
+		}
+		if res.NextPage == "" {
+			return nil
+		}
+		page.PageToken = res.NextPage
+	}
+}
+
+func (s *Server) SearchObjects(ctx context.Context, req *pb.SearchObjectsRequest) (*pb.SearchObjectsResponse, error) {
+	search := &model.ReqSearchObject{
+		Vector:  req.GetVector(),
+		TopK:    int(req.GetTopk()),
+		XParams: fromStruct(req.GetXParams()),
+		Filter:  fromFilter(req.GetFilter()),
+	}
+	if err := validate(search); err != nil {
+		return nil, err
+	}
+
+	col, err := s.collection(req.GetCollection())
+	if err != nil {
+		return nil, err
+	}
+	res, err := col.SearchObjects(search)
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	results := make([]*pb.SearchResult, len(res))
+	for i, r := range res {
+		metadata, err := toStruct(r.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = &pb.SearchResult{Id: r.ID, Metadata: metadata, Vector: r.Vector, Score: r.Score}
+	}
+	return &pb.SearchObjectsResponse{Results: results}, nil
+}
+
+func (s *Server) CountObjects(ctx context.Context, req *pb.CountObjectsRequest) (*pb.CountObjectsResponse, error) {
+	col, err := s.collection(req.GetCollection())
+	if err != nil {
+		return nil, err
+	}
+	res, err := col.CountObjects(&model.ReqCountObjects{Filter: fromFilter(req.GetFilter())})
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	return &pb.CountObjectsResponse{Count: int64(res.Count)}, nil
+}
@@ -0,0 +1,104 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"vectordb/db"
+	"vectordb/pb"
+)
+
+// Server implements pb.VectorDBServer on top of the same db.DB as the REST handlers
+type Server struct {
+	pb.UnimplementedVectorDBServer
+	db *db.DB
+}
+
+func SetupServer(database *db.DB) *grpc.Server {
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryLogger, unaryRecovery),
+		grpc.ChainStreamInterceptor(streamLogger, streamRecovery),
+	)
+	pb.RegisterVectorDBServer(s, &Server{db: database})
+
+	return s
+}
+
+// collection looks up the handle of a collection, a missing collection is an invalid argument
+func (s *Server) collection(colname string) (*db.Collection, error) {
+	col, err := s.db.Collection(colname)
+	if err != nil {
+		return nil, invalid(err)
+	}
+	return col, nil
+}
+
+// validate applies the binding rules of the REST API to a request model
+func validate(obj interface{}) error {
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// invalid maps errors of the db layer, the REST API answers them with 400
+func invalid(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+func unaryLogger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	res, err := handler(ctx, req)
+	logCall(info.FullMethod, start, err)
+	return res, err
+}
+
+func streamLogger(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	logCall(info.FullMethod, start, err)
+	return err
+}
+
+func logCall(method string, start time.Time, err error) {
+	zap.L().Info(method,
+		zap.String("code", status.Code(err).String()),
+		zap.Duration("latency", time.Since(start)),
+		zap.Error(err),
+	)
+}
+
+func unaryRecovery(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func streamRecovery(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(info.FullMethod, r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+func recovered(method string, r interface{}) error {
+	zap.L().Error("[Recovery from panic]",
+		zap.String("method", method),
+		zap.Any("error", r),
+		zap.String("stack", string(debug.Stack())),
+	)
+	return status.Error(codes.Internal, fmt.Sprint(r))
+}
@@ -0,0 +1,144 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"vectordb/db"
+	"vectordb/pb"
+)
+
+func setupClient(t *testing.T) pb.VectorDBClient {
+	database, err := db.Open(t.TempDir(), db.CheckpointPolicy{})
+	assert.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	lis := bufconn.Listen(1 << 20)
+	s := SetupServer(database)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewVectorDBClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	client := setupClient(t)
+	ctx := context.Background()
+
+	params, _ := structpb.NewStruct(map[string]interface{}{"maxsize": 100})
+	_, err := client.CreateCollection(ctx, &pb.CreateCollectionRequest{
+		Name: "test", Dimension: 2, IndexType: "flat", IndexParams: params, DistType: "euclidean",
+		Mapping: []string{"category"}, MetadataIndexes: map[string]string{"category": "keyword"},
+	})
+	assert.NoError(t, err)
+	_, err = client.CreateCollection(ctx, &pb.CreateCollectionRequest{Name: "invalid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// client streaming bulk insert, every message is a batch
+	bulk, err := client.BulkInsertObjects(ctx)
+	assert.NoError(t, err)
+	for batch := 0; batch < 3; batch++ {
+		req := &pb.InsertObjectsRequest{Collection: "test"}
+		for i := 0; i < 4; i++ {
+			n := batch*4 + i
+			metadata, _ := structpb.NewStruct(map[string]interface{}{"category": fmt.Sprint(n % 2)})
+			req.Objects = append(req.Objects, &pb.Object{Id: fmt.Sprintf("%02d", n), Metadata: metadata, Vector: []float32{float32(n), 0}})
+		}
+		assert.NoError(t, bulk.Send(req))
+	}
+	inserted, err := bulk.CloseAndRecv()
+	assert.NoError(t, err)
+	assert.Len(t, inserted.Ids, 12)
+
+	// server streaming scroll in id order
+	scroll, err := client.ScrollObjects(ctx, &pb.ScrollObjectsRequest{Collection: "test", BatchSize: 5})
+	assert.NoError(t, err)
+	ids, batches := []string{}, 0
+	for {
+		res, err := scroll.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NoError(t, err)
+		batches++
+		for _, obj := range res.Objects {
+			ids = append(ids, obj.Id)
+		}
+	}
+	assert.Equal(t, 3, batches)
+	assert.Equal(t, inserted.Ids, ids)
+	scroll, err = client.ScrollObjects(ctx, &pb.ScrollObjectsRequest{Collection: "test", BatchSize: 10001})
+	assert.NoError(t, err)
+	_, err = scroll.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	search, err := client.SearchObjects(ctx, &pb.SearchObjectsRequest{
+		Collection: "test", Vector: []float32{2.2, 0}, Topk: 2,
+		Filter: &pb.Filter{Field: "category", Op: "eq", Value: structpb.NewStringValue("0")},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, search.Results, 2) {
+		assert.Equal(t, "02", search.Results[0].Id)
+		assert.Equal(t, "0", search.Results[0].Metadata.AsMap()["category"])
+	}
+
+	count, err := client.CountObjects(ctx, &pb.CountObjectsRequest{
+		Collection: "test", Filter: &pb.Filter{Field: "category", Op: "in", Values: []*structpb.Value{structpb.NewStringValue("1")}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), count.Count)
+
+	_, err = client.DeleteObject(ctx, &pb.ObjectRequest{Collection: "test", Id: "00"})
+	assert.NoError(t, err)
+	_, err = client.GetObjectInfo(ctx, &pb.ObjectRequest{Collection: "test", Id: "00"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	info, err := client.GetCollectionInfo(ctx, &pb.CollectionRequest{Collection: "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), info.ObjectCount)
+	assert.Equal(t, float64(100), info.IndexParams.AsMap()["maxsize"])
+
+	// snapshot chunks stream back into a clone
+	snapshot, err := client.SnapshotCollection(ctx, &pb.CollectionRequest{Collection: "test"})
+	assert.NoError(t, err)
+	restore, err := client.RestoreCollection(ctx)
+	assert.NoError(t, err)
+	first := true
+	for {
+		chunk, err := snapshot.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NoError(t, err)
+		req := &pb.RestoreCollectionRequest{Data: chunk.Data}
+		if first {
+			req.Collection, first = "clone", false
+		}
+		assert.NoError(t, restore.Send(req))
+	}
+	_, err = restore.CloseAndRecv()
+	assert.NoError(t, err)
+
+	info, err = client.GetCollectionInfo(ctx, &pb.CollectionRequest{Collection: "clone"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), info.ObjectCount)
+}
@@ -1,15 +1,41 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 	"vectordb/db"
 	"vectordb/model"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
-func GetDBInfo(c *gin.Context) {
-	res, err := db.QueryGetDBInfo()
+// Handler serves the REST API of a DB
+type Handler struct {
+	db *db.DB
+}
+
+func New(database *db.DB) *Handler {
+	return &Handler{db: database}
+}
+
+// collection returns the collection of the collection_name parameter, it aborts with 400 if
+// there is none
+func (h *Handler) collection(c *gin.Context) (*db.Collection, bool) {
+	col, err := h.db.Collection(c.Param("collection_name"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return nil, false
+	}
+
+	return col, true
+}
+
+func (h *Handler) GetDBInfo(c *gin.Context) {
+	res, err := h.db.GetDBInfo()
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -24,7 +50,7 @@ func GetDBInfo(c *gin.Context) {
 	})
 }
 
-func CreateCollection(c *gin.Context) {
+func (h *Handler) CreateCollection(c *gin.Context) {
 	col := new(model.ReqCreateCollection)
 	if err := c.ShouldBindJSON(col); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
@@ -33,7 +59,7 @@ func CreateCollection(c *gin.Context) {
 		return
 	}
 
-	if err := db.QueryCreateCollection(col); err != nil {
+	if err := h.db.CreateCollection(col); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -46,10 +72,10 @@ func CreateCollection(c *gin.Context) {
 	})
 }
 
-func DeleteCollection(c *gin.Context) {
+func (h *Handler) DeleteCollection(c *gin.Context) {
 	col := c.Param("collection_name")
 
-	if err := db.QueryDeleteCollection(col); err != nil {
+	if err := h.db.DeleteCollection(col); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -62,10 +88,10 @@ func DeleteCollection(c *gin.Context) {
 	})
 }
 
-func GetCollectionInfo(c *gin.Context) {
+func (h *Handler) GetCollectionInfo(c *gin.Context) {
 	col := c.Param("collection_name")
 
-	res, err := db.QueryGetCollectionInfo(col)
+	res, err := h.db.GetCollectionInfo(col)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -79,3 +105,135 @@ func GetCollectionInfo(c *gin.Context) {
 		"data":    res,
 	})
 }
+
+func (h *Handler) AlterCollection(c *gin.Context) {
+	col := c.Param("collection_name")
+
+	req := new(model.ReqAlterCollection)
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := h.db.AlterCollection(col, req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"message": "collection altered",
+	})
+}
+
+func (h *Handler) CheckpointCollection(c *gin.Context) {
+	col := c.Param("collection_name")
+
+	res, err := h.db.CheckpointCollection(col)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"message": "collection checkpointed",
+		"data":    res,
+	})
+}
+
+func (h *Handler) SnapshotCollection(c *gin.Context) {
+	col := c.Param("collection_name")
+
+	filename := fmt.Sprintf("%s-%s.tar.gz", col, time.Now().UTC().Format("20060102T150405Z"))
+	c.Header("Content-Type", "application/gzip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := h.db.SnapshotCollection(col, c.Writer); err != nil {
+		if c.Writer.Written() {
+			zap.L().Error("collection snapshot failed", zap.String("collection", col), zap.Error(err))
+			c.Abort()
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+}
+
+func (h *Handler) RestoreCollection(c *gin.Context) {
+	col := c.Param("collection_name")
+	req := new(model.ReqRestoreCollection)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := h.db.RestoreCollection(col, c.Request.Body, req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"message": "collection restored",
+	})
+}
+
+func (h *Handler) Fsck(c *gin.Context) {
+	req := new(model.ReqFsck)
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	res, err := h.db.Fsck(req)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"message": "collections checked",
+		"data":    res,
+	})
+}
+
+func (h *Handler) Backup(c *gin.Context) {
+	filename := fmt.Sprintf("vectordb-%s.tar.gz", time.Now().UTC().Format("20060102T150405Z"))
+	c.Header("Content-Type", "application/gzip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := h.db.Backup(c.Writer); err != nil {
+		// the archive is cut short once it is streamed, the client sees a truncated gzip stream
+		if c.Writer.Written() {
+			zap.L().Error("backup failed", zap.Error(err))
+			c.Abort()
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+}
@@ -1,15 +1,22 @@
 package handler
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
-	"vectordb/db"
+	"strings"
 	"vectordb/model"
+	"vectordb/transfer"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
-func InsertObject(c *gin.Context) {
-	col := c.Param("collection_name")
+func (h *Handler) InsertObject(c *gin.Context) {
+	col, ok := h.collection(c)
+	if !ok {
+		return
+	}
 	obj := new(model.ReqInsertObject)
 	if err := c.ShouldBindJSON(obj); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
@@ -18,7 +25,7 @@ func InsertObject(c *gin.Context) {
 		return
 	}
 
-	id, err := db.QueryInsertObject(col, obj)
+	id, err := col.InsertObject(obj)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -35,8 +42,11 @@ func InsertObject(c *gin.Context) {
 	})
 }
 
-func InsertObjects(c *gin.Context) {
-	col := c.Param("collection_name")
+func (h *Handler) InsertObjects(c *gin.Context) {
+	col, ok := h.collection(c)
+	if !ok {
+		return
+	}
 	objs := new(model.ReqInsertObjects)
 	if err := c.ShouldBindJSON(objs); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
@@ -45,7 +55,7 @@ func InsertObjects(c *gin.Context) {
 		return
 	}
 
-	ids, err := db.QueryInsertObjects(col, objs)
+	ids, err := col.InsertObjects(objs)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -62,11 +72,77 @@ func InsertObjects(c *gin.Context) {
 	})
 }
 
-func DeleteObject(c *gin.Context) {
-	col := c.Param("collection_name")
+func (h *Handler) UpsertObject(c *gin.Context) {
+	col, ok := h.collection(c)
+	if !ok {
+		return
+	}
+	obj := new(model.ReqInsertObject)
+	if err := c.ShouldBindJSON(obj); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := col.UpsertObject(obj); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"message": "object upserted",
+		"data": gin.H{
+			"id": obj.ID,
+		},
+	})
+}
+
+func (h *Handler) UpsertObjects(c *gin.Context) {
+	col, ok := h.collection(c)
+	if !ok {
+		return
+	}
+	objs := new(model.ReqInsertObjects)
+	if err := c.ShouldBindJSON(objs); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := col.UpsertObjects(objs); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	ids := make([]string, len(objs.Objects))
+	for i := range objs.Objects {
+		ids[i] = objs.Objects[i].ID
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"message": "objects upserted",
+		"data": gin.H{
+			"ids": ids,
+		},
+	})
+}
+
+func (h *Handler) DeleteObject(c *gin.Context) {
+	col, ok := h.collection(c)
+	if !ok {
+		return
+	}
 	obj := c.Param("object_id")
 
-	if err := db.QueryDeleteObject(col, obj); err != nil {
+	if err := col.DeleteObject(obj); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -79,8 +155,11 @@ func DeleteObject(c *gin.Context) {
 	})
 }
 
-func UpdateObject(c *gin.Context) {
-	col := c.Param("collection_name")
+func (h *Handler) UpdateObject(c *gin.Context) {
+	col, ok := h.collection(c)
+	if !ok {
+		return
+	}
 	obj := new(model.ReqUpdateObject)
 	if err := c.ShouldBindJSON(obj); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
@@ -89,7 +168,7 @@ func UpdateObject(c *gin.Context) {
 		return
 	}
 
-	if err := db.QueryUpdateObject(col, obj); err != nil {
+	if err := col.UpdateObject(obj); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -102,35 +181,57 @@ func UpdateObject(c *gin.Context) {
 	})
 }
 
-func GetObjects(c *gin.Context) {
-	col := c.Param("collection_name")
-	req := new(model.ReqGetObjects)
-	if err := c.ShouldBindJSON(req); err != nil {
+// ScrollObjects returns a page of objects in id order, the next_page token of the response is the
+// page_token of the next request
+func (h *Handler) ScrollObjects(c *gin.Context) {
+	col, ok := h.collection(c)
+	if !ok {
+		return
+	}
+	req := new(model.ReqScrollObjects)
+	if err := c.ShouldBindQuery(req); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
 		return
 	}
+	fields := []string{}
+	for _, f := range req.Fields {
+		fields = append(fields, strings.Split(f, ",")...)
+	}
+	req.Fields = fields
+	if filter := c.Query("filter"); filter != "" {
+		if err := json.Unmarshal([]byte(filter), &req.Filter); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("invalid filter: %v", err),
+			})
+			return
+		}
+	}
 
-	res, err := db.QueryGetObjects(col, req.Offset, req.Limit)
+	res, err := col.ScrollObjects(req)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"status":  "ok",
-		"message": "selected objects got",
+		"message": "objects scrolled",
 		"data":    res,
 	})
 }
 
-func GetObjectInfo(c *gin.Context) {
-	col := c.Param("collection_name")
+func (h *Handler) GetObjectInfo(c *gin.Context) {
+	col, ok := h.collection(c)
+	if !ok {
+		return
+	}
 	obj := c.Param("object_id")
 
-	res, err := db.QueryGetObjectInfo(col, obj)
+	res, err := col.GetObjectInfo(obj)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -145,8 +246,39 @@ func GetObjectInfo(c *gin.Context) {
 	})
 }
 
-func SearchObject(c *gin.Context) {
-	col := c.Param("collection_name")
+func (h *Handler) CountObjects(c *gin.Context) {
+	col, ok := h.collection(c)
+	if !ok {
+		return
+	}
+	req := new(model.ReqCountObjects)
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	res, err := col.CountObjects(req)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"message": "objects counted",
+		"data":    res,
+	})
+}
+
+func (h *Handler) SearchObject(c *gin.Context) {
+	col, ok := h.collection(c)
+	if !ok {
+		return
+	}
 	obj := new(model.ReqSearchObject)
 	if err := c.ShouldBindJSON(obj); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
@@ -155,7 +287,7 @@ func SearchObject(c *gin.Context) {
 		return
 	}
 
-	res, err := db.QuerySearchObject(col, obj)
+	res, err := col.SearchObjects(obj)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -169,3 +301,95 @@ func SearchObject(c *gin.Context) {
 		"data":    res,
 	})
 }
+
+// ImportObjects streams a JSONL, CSV, fvecs, bvecs or npy file from the body into the collection
+// in atomic batches, the batches committed before an error stay imported
+func (h *Handler) ImportObjects(c *gin.Context) {
+	col, ok := h.collection(c)
+	if !ok {
+		return
+	}
+	req := new(model.ReqImportObjects)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	info, err := h.db.GetCollectionInfo(c.Param("collection_name"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	opts := transfer.ImportOptions{
+		Format:    req.Format,
+		Dimension: info.Dimension,
+		Schema:    info.Schema,
+		BatchSize: req.Batch,
+		Progress: func(imported int) {
+			zap.L().Info("importing objects", zap.String("collection", info.Name), zap.Int("imported", imported))
+		},
+	}
+	imported, err := transfer.Import(c.Request.Context(), c.Request.Body, opts, func(objs *model.ReqInsertObjects) error {
+		if req.Upsert {
+			return col.UpsertObjects(objs)
+		}
+		_, err := col.InsertObjects(objs)
+		return err
+	})
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"message": "objects imported",
+		"data":    model.ResImportObjects{Imported: imported},
+	})
+}
+
+// ExportObjects streams every object of the collection from one read transaction, as JSONL or as
+// a tar of a npy array of the vectors and a JSONL file of the ids and metadata
+func (h *Handler) ExportObjects(c *gin.Context) {
+	col, ok := h.collection(c)
+	if !ok {
+		return
+	}
+	req := new(model.ReqExportObjects)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	export, err := col.Export()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	defer export.Close()
+
+	colname := c.Param("collection_name")
+	if req.Format == transfer.FormatNpy {
+		c.Header("Content-Type", "application/x-tar")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", colname+".tar"))
+		err = transfer.ExportNpyTar(c.Writer, export)
+	} else {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", colname+".jsonl"))
+		err = transfer.ExportJSONL(c.Writer, export)
+	}
+	if err != nil {
+		// the status is sent with the first bytes, the client sees a truncated body
+		zap.L().Error("export failed", zap.String("collection", colname), zap.Error(err))
+		c.Abort()
+	}
+}
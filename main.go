@@ -2,8 +2,13 @@ package main
 
 import (
 	"fmt"
+	"net"
+	"os"
+	"time"
 
+	"vectordb/cli"
 	"vectordb/db"
+	"vectordb/grpcserver"
 	"vectordb/logger"
 	"vectordb/router"
 	"vectordb/settings"
@@ -11,6 +16,11 @@ import (
 
 // start app
 func main() {
+	// run a command instead of the server, like vectordb fsck
+	if len(os.Args) > 1 {
+		os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	// load config file
 	if err := settings.Init(); err != nil {
 		fmt.Printf("init settings failed, err:%v\n", err)
@@ -24,14 +34,35 @@ func main() {
 	}
 
 	// init db
-	if err := db.Init(settings.Conf.DBConfig.PersistPath); err != nil {
+	policy := db.CheckpointPolicy{
+		Interval: time.Duration(settings.Conf.DBConfig.CheckpointInterval) * time.Second,
+		WALSize:  int64(settings.Conf.DBConfig.CheckpointWALSize) << 20,
+	}
+	database, err := db.Open(settings.Conf.DBConfig.PersistPath, policy)
+	if err != nil {
 		fmt.Printf("init db failed, err:%v\n", err)
 		return
 	}
-	defer db.Close()
+	defer database.Close()
+
+	// serve the gRPC API next to the REST API
+	if settings.Conf.GRPCPort > 0 {
+		lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", settings.Conf.Host, settings.Conf.GRPCPort))
+		if err != nil {
+			fmt.Printf("listen grpc failed, err:%v\n", err)
+			return
+		}
+		s := grpcserver.SetupServer(database)
+		defer s.GracefulStop()
+		go func() {
+			if err := s.Serve(lis); err != nil {
+				fmt.Printf("run grpc server failed, err:%v\n", err)
+			}
+		}()
+	}
 
 	// register router
-	r := router.SetupRouter(settings.Conf.Mode)
+	r := router.SetupRouter(settings.Conf.Mode, database)
 	if err := r.Run(fmt.Sprintf("%s:%d", settings.Conf.Host, settings.Conf.Port)); err != nil {
 		fmt.Printf("run server failed, err:%v\n", err)
 		return
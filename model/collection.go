@@ -1,20 +1,49 @@
 package model
 
 type ReqCreateCollection struct {
-	Name        string                 `json:"name" binding:"required"`
-	Dimension   int                    `json:"dimension" binding:"required,gt=0"`
-	IndexType   string                 `json:"index_type" binding:"required"`
-	IndexParams map[string]interface{} `json:"index_params" binding:"required"`
-	Distance    string                 `json:"dist_type" binding:"required"`
-	Mapping     []string               `json:"mapping" binding:"required"`
+	Name         string                 `json:"name" binding:"required"`
+	Dimension    int                    `json:"dimension" binding:"required,gt=0"`
+	IndexType    string                 `json:"index_type" binding:"required"`
+	IndexParams  map[string]interface{} `json:"index_params" binding:"required"`
+	Distance     string                 `json:"dist_type" binding:"required"`
+	Mapping      []string               `json:"mapping" binding:"omitempty"`
+	Quantization string                 `json:"quantization" binding:"omitempty"`
+	// metadata fields indexed for filtering, mapped to keyword, integer, float or bool
+	MetadataIndexes map[string]string `json:"metadata_indexes" binding:"omitempty"`
+	// typed metadata fields, replaces mapping
+	Schema []Field `json:"schema" binding:"omitempty,dive"`
+}
+
+// Field is a typed metadata field of a collection schema
+type Field struct {
+	Name     string      `json:"name" binding:"required"`
+	Type     string      `json:"type" binding:"required"` // string, int, float, bool, datetime, string_array, object
+	Required bool        `json:"required"`
+	Default  interface{} `json:"default,omitempty"` // used when an optional field is missing
+	Fields   []Field     `json:"fields,omitempty"`  // nested fields of an object
 }
 
 type CfgCollection struct {
-	Dimension   int                    `json:"dimension"`
-	IndexType   string                 `json:"index_type"`
-	IndexParams map[string]interface{} `json:"index_params"`
-	Distance    string                 `json:"dist_type"`
-	Mapping     []string               `json:"mapping"`
+	Dimension       int                    `json:"dimension"`
+	IndexType       string                 `json:"index_type"`
+	IndexParams     map[string]interface{} `json:"index_params"`
+	Distance        string                 `json:"dist_type"`
+	Mapping         []string               `json:"mapping"`
+	Quantization    string                 `json:"quantization"`
+	MetadataIndexes map[string]string      `json:"metadata_indexes"`
+	Schema          []Field                `json:"schema"` // mapping holds the top level field names if set
+}
+
+type ReqAlterCollection struct {
+	Changes []FieldChange `json:"changes" binding:"required,min=1,dive"`
+}
+
+// FieldChange adds, drops or renames a top level metadata field
+type FieldChange struct {
+	Op      string `json:"op" binding:"required"` // add, drop, rename
+	Field   *Field `json:"field"`                 // field to add, optional with a default to backfill
+	Name    string `json:"name"`                  // field to drop or rename
+	NewName string `json:"new_name"`              // new name of a renamed field
 }
 
 // todo: extra stats
@@ -25,11 +54,50 @@ type ResDBInfo struct {
 
 // todo: extra stats
 type ResCollectionInfo struct {
-	Name        string                 `json:"name"`
-	Dimension   int                    `json:"dimension"`
-	IndexType   string                 `json:"index_type"`
-	IndexParams map[string]interface{} `json:"index_params"`
-	Distance    string                 `json:"dist_type"`
-	Mapping     []string               `json:"mapping"`
-	ObjectCount int                    `json:"object_count"`
+	Name            string                 `json:"name"`
+	Dimension       int                    `json:"dimension"`
+	IndexType       string                 `json:"index_type"`
+	IndexParams     map[string]interface{} `json:"index_params"`
+	Distance        string                 `json:"dist_type"`
+	Mapping         []string               `json:"mapping"`
+	Quantization    string                 `json:"quantization"`
+	MetadataIndexes map[string]string      `json:"metadata_indexes"`
+	Schema          []Field                `json:"schema,omitempty"`
+	ObjectCount     int                    `json:"object_count"`
+	WALErrors       []string               `json:"wal_errors,omitempty"` // entries that couldn't be replayed on load
+}
+
+type ReqCountObjects struct {
+	Filter *Filter `json:"filter" binding:"omitempty"`
+}
+
+type ResCountObjects struct {
+	Count int `json:"count"`
+}
+
+type ResCheckpoint struct {
+	Name string `json:"name"`
+	Seq  uint64 `json:"seq"`
+}
+
+type ReqFsck struct {
+	Collections []string `json:"collections" binding:"omitempty"` // all collections if empty
+	Repair      bool     `json:"repair"`                          // rebuild the index of inconsistent collections
+}
+
+// ResFsck is the integrity report of a collection
+type ResFsck struct {
+	Name            string   `json:"name"`
+	ObjectCount     int      `json:"object_count"`
+	IndexCount      int      `json:"index_count"`
+	OrphanedVectors []string `json:"orphaned_vectors"` // ids in the index without an object
+	MissingVectors  []string `json:"missing_vectors"`  // objects whose ids are not in the index
+	CorruptObjects  []string `json:"corrupt_objects"`  // ids of undecodable object records
+	CorruptWAL      []string `json:"corrupt_wal"`      // WAL entries that can't be read or decoded
+	Repaired        bool     `json:"repaired"`
+}
+
+// ReqRestoreCollection is bound from the query string, the body is the collection snapshot
+type ReqRestoreCollection struct {
+	Overwrite bool `form:"overwrite"` // replace the collection if it exists
 }
@@ -12,12 +12,53 @@ type HNSWParams struct {
 	Heuristic      bool
 	Extend         bool
 	MaxSize        int
+	// fraction of deleted nodes that triggers a background compaction
+	CompactThreshold float64
+	Quantization     string // set from the collection config
 }
 
 type FlatParams struct {
+	MaxSize      int
+	Quantization string // set from the collection config
+}
+
+type IVFParams struct {
+	NList     int // number of inverted lists (k-means centroids)
+	TrainSize int // number of vectors buffered before training the centroids
+	MaxSize   int
+}
+
+type PQParams struct {
+	M         int // number of sub-quantizers, dimension must be divisible by it
+	NBits     int // bits per sub-quantizer code, at most 8
+	TrainSize int // number of vectors buffered before training the codebooks
+	MaxSize   int
+}
+
+type IVFPQParams struct {
+	NList     int
+	M         int
+	NBits     int
+	TrainSize int
+	MaxSize   int
+}
+
+type BinaryParams struct {
 	MaxSize int
 }
 
+type VamanaParams struct {
+	R         int     // maximum out-degree of a node
+	L         int     // candidate list size when inserting
+	Alpha     float64 // robust prune factor, at least 1
+	M         int     // pq sub-quantizers used to navigate the graph
+	NBits     int
+	TrainSize int
+	MaxSize   int
+
+	CompactThreshold float64 // fraction of tombstoned slots that triggers a consolidation
+}
+
 type SearchResult struct {
 	ID    string
 	Score float32
@@ -31,6 +72,16 @@ func ValidateAndConvert(indexType string, params map[string]interface{}) (interf
 		result = &FlatParams{}
 	case "hnsw":
 		result = &HNSWParams{}
+	case "ivf":
+		result = &IVFParams{}
+	case "binary":
+		result = &BinaryParams{}
+	case "pq":
+		result = &PQParams{}
+	case "ivfpq":
+		result = &IVFPQParams{}
+	case "vamana":
+		result = &VamanaParams{}
 	default:
 		return nil, fmt.Errorf("unsupported index type: %s", indexType)
 	}
@@ -45,15 +96,36 @@ func ValidateAndConvert(indexType string, params map[string]interface{}) (interf
 			return nil, fmt.Errorf("invalid parameter key: %s", key)
 		}
 
+		// JSON numbers are float64, callers of the Go API pass any numeric kind
 		fieldValue := reflect.ValueOf(value)
-		if field.Kind() == reflect.Int {
-			field.SetInt(int64(fieldValue.Float()))
-		} else if field.Type() != fieldValue.Type() {
+		number, isNumber := numberOf(fieldValue)
+		switch {
+		case field.Kind() == reflect.Int && isNumber:
+			field.SetInt(int64(number))
+		case field.Kind() == reflect.Float64 && isNumber:
+			field.SetFloat(number)
+		case !fieldValue.IsValid():
+			return nil, fmt.Errorf("invalid type for key '%s', expected: %s but got: nil", key, field.Type())
+		case field.Type() != fieldValue.Type():
 			return nil, fmt.Errorf("invalid type for key '%s', expected: %s but got: %s", key, field.Type(), fieldValue.Type())
-		} else {
+		default:
 			field.Set(fieldValue)
 		}
 	}
 
 	return result, nil
 }
+
+func numberOf(v reflect.Value) (float64, bool) {
+	switch {
+	case !v.IsValid():
+		return 0, false
+	case v.CanFloat():
+		return v.Float(), true
+	case v.CanInt():
+		return float64(v.Int()), true
+	case v.CanUint():
+		return float64(v.Uint()), true
+	}
+	return 0, false
+}
@@ -1,6 +1,7 @@
 package model
 
 type ReqInsertObject struct {
+	ID       string                 `json:"id" binding:"omitempty"` // generated if empty, required for upserts
 	Metadata map[string]interface{} `json:"metadata" binding:"required"`
 	Vector   []float32              `json:"vector" binding:"required"`
 }
@@ -24,6 +25,23 @@ type ReqSearchObject struct {
 	Vector  []float32              `json:"vector" binding:"required"`
 	TopK    int                    `json:"topk" binding:"required"`
 	XParams map[string]interface{} `json:"x_params" binding:"omitempty"`
+	Filter  *Filter                `json:"filter" binding:"omitempty"`
+}
+
+// Filter is a metadata filter expression, either a combination of filters (and, or, not)
+// or a condition (eq, ne, range, in) on a mapping field
+type Filter struct {
+	And    []Filter      `json:"and,omitempty"`
+	Or     []Filter      `json:"or,omitempty"`
+	Not    *Filter       `json:"not,omitempty"`
+	Field  string        `json:"field,omitempty"`
+	Op     string        `json:"op,omitempty"`
+	Value  interface{}   `json:"value,omitempty"`  // eq, ne
+	Values []interface{} `json:"values,omitempty"` // in
+	Gt     interface{}   `json:"gt,omitempty"`     // range bounds, numbers or strings
+	Gte    interface{}   `json:"gte,omitempty"`
+	Lt     interface{}   `json:"lt,omitempty"`
+	Lte    interface{}   `json:"lte,omitempty"`
 }
 
 type ResObjectInfo struct {
@@ -38,3 +56,36 @@ type ResSearchObject struct {
 	Vector   []float32              `json:"vector"`
 	Score    float32                `json:"score"`
 }
+
+// ReqImportObjects is bound from the query string, the body is the file to import
+type ReqImportObjects struct {
+	Format string `form:"format" binding:"required,oneof=jsonl csv fvecs bvecs npy"`
+	Batch  int    `form:"batch" binding:"gte=0,lte=10000"` // objects per atomic insert, 1000 if 0
+	Upsert bool   `form:"upsert"`                          // replace the objects whose id exists
+}
+
+type ResImportObjects struct {
+	Imported int `json:"imported"`
+}
+
+// ReqExportObjects is bound from the query string
+type ReqExportObjects struct {
+	Format string `form:"format" binding:"omitempty,oneof=jsonl npy"` // jsonl by default, npy for a tar of vectors.npy and objects.jsonl
+}
+
+// ReqScrollObjects is bound from the query string, except the filter which the handler decodes
+// from the JSON of the filter parameter. The zero value of WithVector and WithMetadata leaves
+// them out, the query string defaults them to true.
+type ReqScrollObjects struct {
+	PageToken    string   `form:"page_token"`                      // next_page of the previous page, empty for the first one
+	Limit        int      `form:"limit" binding:"gte=0,lte=10000"` // objects per page, 100 if 0
+	Filter       *Filter  `form:"-"`                               // metadata filter like the one of searches
+	WithVector   bool     `form:"with_vector,default=true"`        // return the vectors
+	WithMetadata bool     `form:"with_metadata,default=true"`      // return the metadata
+	Fields       []string `form:"fields" binding:"omitempty"`      // metadata fields returned, all if empty, repeated or comma separated in the query string
+}
+
+type ResScrollObjects struct {
+	Objects  []ResObjectInfo `json:"objects"`
+	NextPage string          `json:"next_page,omitempty"` // page_token of the next page, empty on the last page
+}
@@ -0,0 +1,1075 @@
+// gRPC API of VectorDB, it mirrors the REST API of router.SetupRouter.
+// Regenerate the Go code from this directory with `buf generate`.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: vectordb.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	VectorDB_GetDBInfo_FullMethodName            = "/vectordb.v1.VectorDB/GetDBInfo"
+	VectorDB_Fsck_FullMethodName                 = "/vectordb.v1.VectorDB/Fsck"
+	VectorDB_Backup_FullMethodName               = "/vectordb.v1.VectorDB/Backup"
+	VectorDB_CreateCollection_FullMethodName     = "/vectordb.v1.VectorDB/CreateCollection"
+	VectorDB_DeleteCollection_FullMethodName     = "/vectordb.v1.VectorDB/DeleteCollection"
+	VectorDB_GetCollectionInfo_FullMethodName    = "/vectordb.v1.VectorDB/GetCollectionInfo"
+	VectorDB_AlterCollection_FullMethodName      = "/vectordb.v1.VectorDB/AlterCollection"
+	VectorDB_CheckpointCollection_FullMethodName = "/vectordb.v1.VectorDB/CheckpointCollection"
+	VectorDB_SnapshotCollection_FullMethodName   = "/vectordb.v1.VectorDB/SnapshotCollection"
+	VectorDB_RestoreCollection_FullMethodName    = "/vectordb.v1.VectorDB/RestoreCollection"
+	VectorDB_InsertObject_FullMethodName         = "/vectordb.v1.VectorDB/InsertObject"
+	VectorDB_InsertObjects_FullMethodName        = "/vectordb.v1.VectorDB/InsertObjects"
+	VectorDB_BulkInsertObjects_FullMethodName    = "/vectordb.v1.VectorDB/BulkInsertObjects"
+	VectorDB_UpsertObject_FullMethodName         = "/vectordb.v1.VectorDB/UpsertObject"
+	VectorDB_UpsertObjects_FullMethodName        = "/vectordb.v1.VectorDB/UpsertObjects"
+	VectorDB_DeleteObject_FullMethodName         = "/vectordb.v1.VectorDB/DeleteObject"
+	VectorDB_UpdateObject_FullMethodName         = "/vectordb.v1.VectorDB/UpdateObject"
+	VectorDB_GetObjects_FullMethodName           = "/vectordb.v1.VectorDB/GetObjects"
+	VectorDB_GetObjectInfo_FullMethodName        = "/vectordb.v1.VectorDB/GetObjectInfo"
+	VectorDB_ScrollObjects_FullMethodName        = "/vectordb.v1.VectorDB/ScrollObjects"
+	VectorDB_SearchObjects_FullMethodName        = "/vectordb.v1.VectorDB/SearchObjects"
+	VectorDB_CountObjects_FullMethodName         = "/vectordb.v1.VectorDB/CountObjects"
+)
+
+// VectorDBClient is the client API for VectorDB service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VectorDBClient interface {
+	// db
+	GetDBInfo(ctx context.Context, in *GetDBInfoRequest, opts ...grpc.CallOption) (*DBInfo, error)
+	// admin
+	Fsck(ctx context.Context, in *FsckRequest, opts ...grpc.CallOption) (*FsckResponse, error)
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (VectorDB_BackupClient, error)
+	// collection
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*Empty, error)
+	DeleteCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetCollectionInfo(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*CollectionInfo, error)
+	AlterCollection(ctx context.Context, in *AlterCollectionRequest, opts ...grpc.CallOption) (*Empty, error)
+	CheckpointCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*CheckpointResponse, error)
+	SnapshotCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (VectorDB_SnapshotCollectionClient, error)
+	// the first message names the collection, every message carries a chunk of the snapshot
+	RestoreCollection(ctx context.Context, opts ...grpc.CallOption) (VectorDB_RestoreCollectionClient, error)
+	// object
+	InsertObject(ctx context.Context, in *InsertObjectRequest, opts ...grpc.CallOption) (*InsertObjectResponse, error)
+	InsertObjects(ctx context.Context, in *InsertObjectsRequest, opts ...grpc.CallOption) (*InsertObjectsResponse, error)
+	// every message is inserted as an atomic batch, the collection is taken from the first one
+	BulkInsertObjects(ctx context.Context, opts ...grpc.CallOption) (VectorDB_BulkInsertObjectsClient, error)
+	UpsertObject(ctx context.Context, in *InsertObjectRequest, opts ...grpc.CallOption) (*InsertObjectResponse, error)
+	UpsertObjects(ctx context.Context, in *InsertObjectsRequest, opts ...grpc.CallOption) (*InsertObjectsResponse, error)
+	DeleteObject(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*Empty, error)
+	UpdateObject(ctx context.Context, in *UpdateObjectRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetObjects(ctx context.Context, in *GetObjectsRequest, opts ...grpc.CallOption) (*GetObjectsResponse, error)
+	GetObjectInfo(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*Object, error)
+	// streams every object of the collection in id order, batch_size objects per message
+	ScrollObjects(ctx context.Context, in *ScrollObjectsRequest, opts ...grpc.CallOption) (VectorDB_ScrollObjectsClient, error)
+	SearchObjects(ctx context.Context, in *SearchObjectsRequest, opts ...grpc.CallOption) (*SearchObjectsResponse, error)
+	CountObjects(ctx context.Context, in *CountObjectsRequest, opts ...grpc.CallOption) (*CountObjectsResponse, error)
+}
+
+type vectorDBClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVectorDBClient(cc grpc.ClientConnInterface) VectorDBClient {
+	return &vectorDBClient{cc}
+}
+
+func (c *vectorDBClient) GetDBInfo(ctx context.Context, in *GetDBInfoRequest, opts ...grpc.CallOption) (*DBInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DBInfo)
+	err := c.cc.Invoke(ctx, VectorDB_GetDBInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) Fsck(ctx context.Context, in *FsckRequest, opts ...grpc.CallOption) (*FsckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FsckResponse)
+	err := c.cc.Invoke(ctx, VectorDB_Fsck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (VectorDB_BackupClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VectorDB_ServiceDesc.Streams[0], VectorDB_Backup_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vectorDBBackupClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VectorDB_BackupClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type vectorDBBackupClient struct {
+	grpc.ClientStream
+}
+
+func (x *vectorDBBackupClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vectorDBClient) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, VectorDB_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) DeleteCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, VectorDB_DeleteCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) GetCollectionInfo(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*CollectionInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionInfo)
+	err := c.cc.Invoke(ctx, VectorDB_GetCollectionInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) AlterCollection(ctx context.Context, in *AlterCollectionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, VectorDB_AlterCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) CheckpointCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*CheckpointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckpointResponse)
+	err := c.cc.Invoke(ctx, VectorDB_CheckpointCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) SnapshotCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (VectorDB_SnapshotCollectionClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VectorDB_ServiceDesc.Streams[1], VectorDB_SnapshotCollection_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vectorDBSnapshotCollectionClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VectorDB_SnapshotCollectionClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type vectorDBSnapshotCollectionClient struct {
+	grpc.ClientStream
+}
+
+func (x *vectorDBSnapshotCollectionClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vectorDBClient) RestoreCollection(ctx context.Context, opts ...grpc.CallOption) (VectorDB_RestoreCollectionClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VectorDB_ServiceDesc.Streams[2], VectorDB_RestoreCollection_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vectorDBRestoreCollectionClient{ClientStream: stream}
+	return x, nil
+}
+
+type VectorDB_RestoreCollectionClient interface {
+	Send(*RestoreCollectionRequest) error
+	CloseAndRecv() (*Empty, error)
+	grpc.ClientStream
+}
+
+type vectorDBRestoreCollectionClient struct {
+	grpc.ClientStream
+}
+
+func (x *vectorDBRestoreCollectionClient) Send(m *RestoreCollectionRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *vectorDBRestoreCollectionClient) CloseAndRecv() (*Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vectorDBClient) InsertObject(ctx context.Context, in *InsertObjectRequest, opts ...grpc.CallOption) (*InsertObjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InsertObjectResponse)
+	err := c.cc.Invoke(ctx, VectorDB_InsertObject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) InsertObjects(ctx context.Context, in *InsertObjectsRequest, opts ...grpc.CallOption) (*InsertObjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InsertObjectsResponse)
+	err := c.cc.Invoke(ctx, VectorDB_InsertObjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) BulkInsertObjects(ctx context.Context, opts ...grpc.CallOption) (VectorDB_BulkInsertObjectsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VectorDB_ServiceDesc.Streams[3], VectorDB_BulkInsertObjects_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vectorDBBulkInsertObjectsClient{ClientStream: stream}
+	return x, nil
+}
+
+type VectorDB_BulkInsertObjectsClient interface {
+	Send(*InsertObjectsRequest) error
+	CloseAndRecv() (*InsertObjectsResponse, error)
+	grpc.ClientStream
+}
+
+type vectorDBBulkInsertObjectsClient struct {
+	grpc.ClientStream
+}
+
+func (x *vectorDBBulkInsertObjectsClient) Send(m *InsertObjectsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *vectorDBBulkInsertObjectsClient) CloseAndRecv() (*InsertObjectsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(InsertObjectsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vectorDBClient) UpsertObject(ctx context.Context, in *InsertObjectRequest, opts ...grpc.CallOption) (*InsertObjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InsertObjectResponse)
+	err := c.cc.Invoke(ctx, VectorDB_UpsertObject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) UpsertObjects(ctx context.Context, in *InsertObjectsRequest, opts ...grpc.CallOption) (*InsertObjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InsertObjectsResponse)
+	err := c.cc.Invoke(ctx, VectorDB_UpsertObjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) DeleteObject(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, VectorDB_DeleteObject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) UpdateObject(ctx context.Context, in *UpdateObjectRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, VectorDB_UpdateObject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) GetObjects(ctx context.Context, in *GetObjectsRequest, opts ...grpc.CallOption) (*GetObjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetObjectsResponse)
+	err := c.cc.Invoke(ctx, VectorDB_GetObjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) GetObjectInfo(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*Object, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Object)
+	err := c.cc.Invoke(ctx, VectorDB_GetObjectInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) ScrollObjects(ctx context.Context, in *ScrollObjectsRequest, opts ...grpc.CallOption) (VectorDB_ScrollObjectsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VectorDB_ServiceDesc.Streams[4], VectorDB_ScrollObjects_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vectorDBScrollObjectsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VectorDB_ScrollObjectsClient interface {
+	Recv() (*GetObjectsResponse, error)
+	grpc.ClientStream
+}
+
+type vectorDBScrollObjectsClient struct {
+	grpc.ClientStream
+}
+
+func (x *vectorDBScrollObjectsClient) Recv() (*GetObjectsResponse, error) {
+	m := new(GetObjectsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vectorDBClient) SearchObjects(ctx context.Context, in *SearchObjectsRequest, opts ...grpc.CallOption) (*SearchObjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchObjectsResponse)
+	err := c.cc.Invoke(ctx, VectorDB_SearchObjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) CountObjects(ctx context.Context, in *CountObjectsRequest, opts ...grpc.CallOption) (*CountObjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountObjectsResponse)
+	err := c.cc.Invoke(ctx, VectorDB_CountObjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VectorDBServer is the server API for VectorDB service.
+// All implementations must embed UnimplementedVectorDBServer
+// for forward compatibility
+type VectorDBServer interface {
+	// db
+	GetDBInfo(context.Context, *GetDBInfoRequest) (*DBInfo, error)
+	// admin
+	Fsck(context.Context, *FsckRequest) (*FsckResponse, error)
+	Backup(*BackupRequest, VectorDB_BackupServer) error
+	// collection
+	CreateCollection(context.Context, *CreateCollectionRequest) (*Empty, error)
+	DeleteCollection(context.Context, *CollectionRequest) (*Empty, error)
+	GetCollectionInfo(context.Context, *CollectionRequest) (*CollectionInfo, error)
+	AlterCollection(context.Context, *AlterCollectionRequest) (*Empty, error)
+	CheckpointCollection(context.Context, *CollectionRequest) (*CheckpointResponse, error)
+	SnapshotCollection(*CollectionRequest, VectorDB_SnapshotCollectionServer) error
+	// the first message names the collection, every message carries a chunk of the snapshot
+	RestoreCollection(VectorDB_RestoreCollectionServer) error
+	// object
+	InsertObject(context.Context, *InsertObjectRequest) (*InsertObjectResponse, error)
+	InsertObjects(context.Context, *InsertObjectsRequest) (*InsertObjectsResponse, error)
+	// every message is inserted as an atomic batch, the collection is taken from the first one
+	BulkInsertObjects(VectorDB_BulkInsertObjectsServer) error
+	UpsertObject(context.Context, *InsertObjectRequest) (*InsertObjectResponse, error)
+	UpsertObjects(context.Context, *InsertObjectsRequest) (*InsertObjectsResponse, error)
+	DeleteObject(context.Context, *ObjectRequest) (*Empty, error)
+	UpdateObject(context.Context, *UpdateObjectRequest) (*Empty, error)
+	GetObjects(context.Context, *GetObjectsRequest) (*GetObjectsResponse, error)
+	GetObjectInfo(context.Context, *ObjectRequest) (*Object, error)
+	// streams every object of the collection in id order, batch_size objects per message
+	ScrollObjects(*ScrollObjectsRequest, VectorDB_ScrollObjectsServer) error
+	SearchObjects(context.Context, *SearchObjectsRequest) (*SearchObjectsResponse, error)
+	CountObjects(context.Context, *CountObjectsRequest) (*CountObjectsResponse, error)
+	mustEmbedUnimplementedVectorDBServer()
+}
+
+// UnimplementedVectorDBServer must be embedded to have forward compatible implementations.
+type UnimplementedVectorDBServer struct {
+}
+
+func (UnimplementedVectorDBServer) GetDBInfo(context.Context, *GetDBInfoRequest) (*DBInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDBInfo not implemented")
+}
+func (UnimplementedVectorDBServer) Fsck(context.Context, *FsckRequest) (*FsckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fsck not implemented")
+}
+func (UnimplementedVectorDBServer) Backup(*BackupRequest, VectorDB_BackupServer) error {
+	return status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
+func (UnimplementedVectorDBServer) CreateCollection(context.Context, *CreateCollectionRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedVectorDBServer) DeleteCollection(context.Context, *CollectionRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollection not implemented")
+}
+func (UnimplementedVectorDBServer) GetCollectionInfo(context.Context, *CollectionRequest) (*CollectionInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollectionInfo not implemented")
+}
+func (UnimplementedVectorDBServer) AlterCollection(context.Context, *AlterCollectionRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AlterCollection not implemented")
+}
+func (UnimplementedVectorDBServer) CheckpointCollection(context.Context, *CollectionRequest) (*CheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckpointCollection not implemented")
+}
+func (UnimplementedVectorDBServer) SnapshotCollection(*CollectionRequest, VectorDB_SnapshotCollectionServer) error {
+	return status.Errorf(codes.Unimplemented, "method SnapshotCollection not implemented")
+}
+func (UnimplementedVectorDBServer) RestoreCollection(VectorDB_RestoreCollectionServer) error {
+	return status.Errorf(codes.Unimplemented, "method RestoreCollection not implemented")
+}
+func (UnimplementedVectorDBServer) InsertObject(context.Context, *InsertObjectRequest) (*InsertObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertObject not implemented")
+}
+func (UnimplementedVectorDBServer) InsertObjects(context.Context, *InsertObjectsRequest) (*InsertObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertObjects not implemented")
+}
+func (UnimplementedVectorDBServer) BulkInsertObjects(VectorDB_BulkInsertObjectsServer) error {
+	return status.Errorf(codes.Unimplemented, "method BulkInsertObjects not implemented")
+}
+func (UnimplementedVectorDBServer) UpsertObject(context.Context, *InsertObjectRequest) (*InsertObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertObject not implemented")
+}
+func (UnimplementedVectorDBServer) UpsertObjects(context.Context, *InsertObjectsRequest) (*InsertObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertObjects not implemented")
+}
+func (UnimplementedVectorDBServer) DeleteObject(context.Context, *ObjectRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteObject not implemented")
+}
+func (UnimplementedVectorDBServer) UpdateObject(context.Context, *UpdateObjectRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateObject not implemented")
+}
+func (UnimplementedVectorDBServer) GetObjects(context.Context, *GetObjectsRequest) (*GetObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetObjects not implemented")
+}
+func (UnimplementedVectorDBServer) GetObjectInfo(context.Context, *ObjectRequest) (*Object, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetObjectInfo not implemented")
+}
+func (UnimplementedVectorDBServer) ScrollObjects(*ScrollObjectsRequest, VectorDB_ScrollObjectsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ScrollObjects not implemented")
+}
+func (UnimplementedVectorDBServer) SearchObjects(context.Context, *SearchObjectsRequest) (*SearchObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchObjects not implemented")
+}
+func (UnimplementedVectorDBServer) CountObjects(context.Context, *CountObjectsRequest) (*CountObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountObjects not implemented")
+}
+func (UnimplementedVectorDBServer) mustEmbedUnimplementedVectorDBServer() {}
+
+// UnsafeVectorDBServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VectorDBServer will
+// result in compilation errors.
+type UnsafeVectorDBServer interface {
+	mustEmbedUnimplementedVectorDBServer()
+}
+
+func RegisterVectorDBServer(s grpc.ServiceRegistrar, srv VectorDBServer) {
+	s.RegisterService(&VectorDB_ServiceDesc, srv)
+}
+
+func _VectorDB_GetDBInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDBInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).GetDBInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_GetDBInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).GetDBInfo(ctx, req.(*GetDBInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_Fsck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FsckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).Fsck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_Fsck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).Fsck(ctx, req.(*FsckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_Backup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VectorDBServer).Backup(m, &vectorDBBackupServer{ServerStream: stream})
+}
+
+type VectorDB_BackupServer interface {
+	Send(*Chunk) error
+	grpc.ServerStream
+}
+
+type vectorDBBackupServer struct {
+	grpc.ServerStream
+}
+
+func (x *vectorDBBackupServer) Send(m *Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VectorDB_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_DeleteCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).DeleteCollection(ctx, req.(*CollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_GetCollectionInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).GetCollectionInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_GetCollectionInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).GetCollectionInfo(ctx, req.(*CollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_AlterCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AlterCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).AlterCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_AlterCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).AlterCollection(ctx, req.(*AlterCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_CheckpointCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).CheckpointCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_CheckpointCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).CheckpointCollection(ctx, req.(*CollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_SnapshotCollection_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CollectionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VectorDBServer).SnapshotCollection(m, &vectorDBSnapshotCollectionServer{ServerStream: stream})
+}
+
+type VectorDB_SnapshotCollectionServer interface {
+	Send(*Chunk) error
+	grpc.ServerStream
+}
+
+type vectorDBSnapshotCollectionServer struct {
+	grpc.ServerStream
+}
+
+func (x *vectorDBSnapshotCollectionServer) Send(m *Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VectorDB_RestoreCollection_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VectorDBServer).RestoreCollection(&vectorDBRestoreCollectionServer{ServerStream: stream})
+}
+
+type VectorDB_RestoreCollectionServer interface {
+	SendAndClose(*Empty) error
+	Recv() (*RestoreCollectionRequest, error)
+	grpc.ServerStream
+}
+
+type vectorDBRestoreCollectionServer struct {
+	grpc.ServerStream
+}
+
+func (x *vectorDBRestoreCollectionServer) SendAndClose(m *Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *vectorDBRestoreCollectionServer) Recv() (*RestoreCollectionRequest, error) {
+	m := new(RestoreCollectionRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _VectorDB_InsertObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).InsertObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_InsertObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).InsertObject(ctx, req.(*InsertObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_InsertObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).InsertObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_InsertObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).InsertObjects(ctx, req.(*InsertObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_BulkInsertObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VectorDBServer).BulkInsertObjects(&vectorDBBulkInsertObjectsServer{ServerStream: stream})
+}
+
+type VectorDB_BulkInsertObjectsServer interface {
+	SendAndClose(*InsertObjectsResponse) error
+	Recv() (*InsertObjectsRequest, error)
+	grpc.ServerStream
+}
+
+type vectorDBBulkInsertObjectsServer struct {
+	grpc.ServerStream
+}
+
+func (x *vectorDBBulkInsertObjectsServer) SendAndClose(m *InsertObjectsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *vectorDBBulkInsertObjectsServer) Recv() (*InsertObjectsRequest, error) {
+	m := new(InsertObjectsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _VectorDB_UpsertObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).UpsertObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_UpsertObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).UpsertObject(ctx, req.(*InsertObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_UpsertObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).UpsertObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_UpsertObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).UpsertObjects(ctx, req.(*InsertObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_DeleteObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).DeleteObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_DeleteObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).DeleteObject(ctx, req.(*ObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_UpdateObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).UpdateObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_UpdateObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).UpdateObject(ctx, req.(*UpdateObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_GetObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).GetObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_GetObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).GetObjects(ctx, req.(*GetObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_GetObjectInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).GetObjectInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_GetObjectInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).GetObjectInfo(ctx, req.(*ObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_ScrollObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ScrollObjectsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VectorDBServer).ScrollObjects(m, &vectorDBScrollObjectsServer{ServerStream: stream})
+}
+
+type VectorDB_ScrollObjectsServer interface {
+	Send(*GetObjectsResponse) error
+	grpc.ServerStream
+}
+
+type vectorDBScrollObjectsServer struct {
+	grpc.ServerStream
+}
+
+func (x *vectorDBScrollObjectsServer) Send(m *GetObjectsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VectorDB_SearchObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).SearchObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_SearchObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).SearchObjects(ctx, req.(*SearchObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_CountObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).CountObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_CountObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).CountObjects(ctx, req.(*CountObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VectorDB_ServiceDesc is the grpc.ServiceDesc for VectorDB service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VectorDB_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vectordb.v1.VectorDB",
+	HandlerType: (*VectorDBServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDBInfo",
+			Handler:    _VectorDB_GetDBInfo_Handler,
+		},
+		{
+			MethodName: "Fsck",
+			Handler:    _VectorDB_Fsck_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _VectorDB_CreateCollection_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _VectorDB_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "GetCollectionInfo",
+			Handler:    _VectorDB_GetCollectionInfo_Handler,
+		},
+		{
+			MethodName: "AlterCollection",
+			Handler:    _VectorDB_AlterCollection_Handler,
+		},
+		{
+			MethodName: "CheckpointCollection",
+			Handler:    _VectorDB_CheckpointCollection_Handler,
+		},
+		{
+			MethodName: "InsertObject",
+			Handler:    _VectorDB_InsertObject_Handler,
+		},
+		{
+			MethodName: "InsertObjects",
+			Handler:    _VectorDB_InsertObjects_Handler,
+		},
+		{
+			MethodName: "UpsertObject",
+			Handler:    _VectorDB_UpsertObject_Handler,
+		},
+		{
+			MethodName: "UpsertObjects",
+			Handler:    _VectorDB_UpsertObjects_Handler,
+		},
+		{
+			MethodName: "DeleteObject",
+			Handler:    _VectorDB_DeleteObject_Handler,
+		},
+		{
+			MethodName: "UpdateObject",
+			Handler:    _VectorDB_UpdateObject_Handler,
+		},
+		{
+			MethodName: "GetObjects",
+			Handler:    _VectorDB_GetObjects_Handler,
+		},
+		{
+			MethodName: "GetObjectInfo",
+			Handler:    _VectorDB_GetObjectInfo_Handler,
+		},
+		{
+			MethodName: "SearchObjects",
+			Handler:    _VectorDB_SearchObjects_Handler,
+		},
+		{
+			MethodName: "CountObjects",
+			Handler:    _VectorDB_CountObjects_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Backup",
+			Handler:       _VectorDB_Backup_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SnapshotCollection",
+			Handler:       _VectorDB_SnapshotCollection_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RestoreCollection",
+			Handler:       _VectorDB_RestoreCollection_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "BulkInsertObjects",
+			Handler:       _VectorDB_BulkInsertObjects_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ScrollObjects",
+			Handler:       _VectorDB_ScrollObjects_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "vectordb.proto",
+}
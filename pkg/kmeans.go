@@ -0,0 +1,116 @@
+package pkg
+
+import (
+	"math/rand/v2"
+)
+
+// KMeans clusters the vectors into k centroids with Lloyd's algorithm, centroids are seeded by k-means++
+func KMeans(vectors [][]float32, k int, iterations int, distfunc func([]float32, []float32) float32) [][]float32 {
+	if len(vectors) == 0 || k <= 0 {
+		return nil
+	}
+	if k > len(vectors) {
+		k = len(vectors)
+	}
+	dim := len(vectors[0])
+
+	centroids := seedCentroids(vectors, k, distfunc)
+	assign := make([]int, len(vectors))
+
+	for iter := 0; iter < iterations; iter++ {
+		changed := false
+		for i, v := range vectors {
+			c := NearestCentroid(v, centroids, distfunc)
+			if iter == 0 || c != assign[i] {
+				assign[i] = c
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		sums := make([][]float32, k)
+		counts := make([]int, k)
+		for i := range sums {
+			sums[i] = make([]float32, dim)
+		}
+		for i, v := range vectors {
+			c := assign[i]
+			counts[c]++
+			for d := range v {
+				sums[c][d] += v[d]
+			}
+		}
+
+		for c := range centroids {
+			// reseed an empty cluster with a random vector
+			if counts[c] == 0 {
+				centroids[c] = append([]float32(nil), vectors[rand.IntN(len(vectors))]...)
+				continue
+			}
+			for d := range sums[c] {
+				sums[c][d] /= float32(counts[c])
+			}
+			centroids[c] = sums[c]
+		}
+	}
+
+	return centroids
+}
+
+// NearestCentroid returns the position of the centroid closest to the vector
+func NearestCentroid(vector []float32, centroids [][]float32, distfunc func([]float32, []float32) float32) int {
+	best, bestdist := 0, float32(0)
+	for i, c := range centroids {
+		if dist := distfunc(vector, c); i == 0 || dist < bestdist {
+			best, bestdist = i, dist
+		}
+	}
+	return best
+}
+
+func seedCentroids(vectors [][]float32, k int, distfunc func([]float32, []float32) float32) [][]float32 {
+	centroids := make([][]float32, 0, k)
+	first := vectors[rand.IntN(len(vectors))]
+	centroids = append(centroids, append([]float32(nil), first...))
+
+	// distance from each vector to its nearest centroid so far
+	mindists := make([]float64, len(vectors))
+	for i, v := range vectors {
+		mindists[i] = float64(distfunc(v, first))
+	}
+
+	// distances may be negative for dot product, so weights are shifted by the minimum
+	weights := make([]float64, len(vectors))
+	for len(centroids) < k {
+		lowest, total := mindists[0], 0.0
+		for _, d := range mindists {
+			lowest = min(lowest, d)
+		}
+		for i, d := range mindists {
+			weights[i] = (d - lowest) * (d - lowest)
+			total += weights[i]
+		}
+
+		next := rand.IntN(len(vectors))
+		if total > 0 {
+			r := rand.Float64() * total
+			for i, w := range weights {
+				r -= w
+				if r <= 0 {
+					next = i
+					break
+				}
+			}
+		}
+
+		centroid := append([]float32(nil), vectors[next]...)
+		centroids = append(centroids, centroid)
+		for i, v := range vectors {
+			mindists[i] = min(mindists[i], float64(distfunc(v, centroid)))
+		}
+	}
+
+	return centroids
+}
@@ -3,8 +3,17 @@ package pkg
 import (
 	"bytes"
 	"encoding/gob"
+	"time"
 )
 
+func init() {
+	// concrete types held by metadata and schema default values
+	gob.Register([]interface{}{})
+	gob.Register([]string{})
+	gob.Register(map[string]interface{}{})
+	gob.Register(time.Time{})
+}
+
 func Serialize(s interface{}) ([]byte, error) {
 	buf := bytes.NewBuffer(nil)
 	enc := gob.NewEncoder(buf)
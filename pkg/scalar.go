@@ -0,0 +1,57 @@
+package pkg
+
+// ScalarQuantizer maps every dimension from its [min, max] range onto the 256 values of an int8,
+// x = min + (code + 128) * scale, values outside the trained range are clipped
+type ScalarQuantizer struct {
+	Min   []float32
+	Scale []float32
+}
+
+// TrainScalarQuantizer learns the per-dimension range of the vectors
+func TrainScalarQuantizer(vectors [][]float32) *ScalarQuantizer {
+	if len(vectors) == 0 {
+		return nil
+	}
+
+	dim := len(vectors[0])
+	lo := append([]float32(nil), vectors[0]...)
+	hi := append([]float32(nil), vectors[0]...)
+	for _, v := range vectors[1:] {
+		for d := 0; d < dim; d++ {
+			lo[d] = min(lo[d], v[d])
+			hi[d] = max(hi[d], v[d])
+		}
+	}
+
+	scale := make([]float32, dim)
+	for d := range scale {
+		scale[d] = (hi[d] - lo[d]) / 255
+	}
+
+	return &ScalarQuantizer{
+		Min:   lo,
+		Scale: scale,
+	}
+}
+
+func (q *ScalarQuantizer) Encode(vector []float32) []int8 {
+	code := make([]int8, len(vector))
+	for d, val := range vector {
+		if q.Scale[d] == 0 {
+			code[d] = -128
+			continue
+		}
+		c := (val-q.Min[d])/q.Scale[d] + 0.5 // round to nearest
+		c = min(max(c, 0), 255)
+		code[d] = int8(int(c) - 128)
+	}
+	return code
+}
+
+func (q *ScalarQuantizer) Decode(code []int8) []float32 {
+	vector := make([]float32, len(code))
+	for d, c := range code {
+		vector[d] = q.Min[d] + float32(int(c)+128)*q.Scale[d]
+	}
+	return vector
+}
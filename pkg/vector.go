@@ -1,6 +1,10 @@
 package pkg
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
 
 func dotProduct(a, b []float32) float32 {
 	var sum float32
@@ -37,3 +41,81 @@ func EuclideanDistance(a, b []float32) float32 {
 	}
 	return float32(math.Sqrt(float64(sum)))
 }
+
+// GetDistanceFunc returns the distance function of a dist_type, smaller is more similar
+func GetDistanceFunc(distance string) (func([]float32, []float32) float32, error) {
+	switch distance {
+	case "dot":
+		return DotDistance, nil
+	case "cosine":
+		return CosineDistance, nil
+	case "euclidean":
+		return EuclideanDistance, nil
+	default:
+		return nil, fmt.Errorf("invalid distance metric")
+	}
+}
+
+// int8 variants compare a float32 query with a vector quantized by a ScalarQuantizer,
+// the code is decoded on the fly so nothing is allocated
+func DotDistanceInt8(a []float32, b []int8, q *ScalarQuantizer) float32 {
+	var sum float32
+	for i := 0; i < len(a); i++ {
+		sum += a[i] * (q.Min[i] + float32(int(b[i])+128)*q.Scale[i])
+	}
+	return -sum
+}
+
+func CosineDistanceInt8(a []float32, b []int8, q *ScalarQuantizer) float32 {
+	var dp, magA, magB float32
+	for i := 0; i < len(a); i++ {
+		val := q.Min[i] + float32(int(b[i])+128)*q.Scale[i]
+		dp += a[i] * val
+		magA += a[i] * a[i]
+		magB += val * val
+	}
+	return 1 - dp/float32(math.Sqrt(float64(magA))*math.Sqrt(float64(magB)))
+}
+
+func EuclideanDistanceInt8(a []float32, b []int8, q *ScalarQuantizer) float32 {
+	var sum float32
+	for i := 0; i < len(a); i++ {
+		diff := a[i] - (q.Min[i] + float32(int(b[i])+128)*q.Scale[i])
+		sum += diff * diff
+	}
+	return float32(math.Sqrt(float64(sum)))
+}
+
+// GetDistanceFuncInt8 returns the int8 variant of the distance function of a dist_type
+func GetDistanceFuncInt8(distance string) (func([]float32, []int8, *ScalarQuantizer) float32, error) {
+	switch distance {
+	case "dot":
+		return DotDistanceInt8, nil
+	case "cosine":
+		return CosineDistanceInt8, nil
+	case "euclidean":
+		return EuclideanDistanceInt8, nil
+	default:
+		return nil, fmt.Errorf("invalid distance metric")
+	}
+}
+
+// PackSignBits quantizes a vector to one bit per dimension, set when the value is positive
+func PackSignBits(v []float32) []uint64 {
+	code := make([]uint64, (len(v)+63)/64)
+	for i, val := range v {
+		if val > 0 {
+			code[i/64] |= 1 << (i % 64)
+		}
+	}
+	return code
+}
+
+// HammingDistance counts the differing bits of two sign bit packed vectors
+func HammingDistance(a, b []uint64) float32 {
+	var dist int
+	for i := 0; i < len(a); i++ {
+		dist += bits.OnesCount64(a[i] ^ b[i])
+	}
+	return float32(dist)
+}
@@ -9,10 +9,11 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"vectordb/db"
 	"vectordb/handler"
 )
 
-func SetupRouter(mode string) *gin.Engine {
+func SetupRouter(mode string, database *db.DB) *gin.Engine {
 	// mode: release / debug
 	if mode == gin.ReleaseMode {
 		gin.SetMode(gin.ReleaseMode)
@@ -22,24 +23,38 @@ func SetupRouter(mode string) *gin.Engine {
 	r.Use(ginzap.Ginzap(zap.L(), time.RFC3339, true))
 	r.Use(ginzap.RecoveryWithZap(zap.L(), true))
 
+	h := handler.New(database)
 	api := r.Group("/api")
 	{
 		// db
-		api.GET("/info", handler.GetDBInfo)
+		api.GET("/info", h.GetDBInfo)
+
+		// admin
+		api.POST("/admin/fsck", h.Fsck)
+		api.GET("/admin/backup", h.Backup)
 
 		// collection
-		api.POST("/collections", handler.CreateCollection)
-		api.DELETE("/collections/:collection_name", handler.DeleteCollection)
-		api.GET("/collections/:collection_name", handler.GetCollectionInfo)
+		api.POST("/collections", h.CreateCollection)
+		api.DELETE("/collections/:collection_name", h.DeleteCollection)
+		api.GET("/collections/:collection_name", h.GetCollectionInfo)
+		api.PATCH("/collections/:collection_name", h.AlterCollection)
+		api.POST("/collections/:collection_name/checkpoint", h.CheckpointCollection)
+		api.GET("/collections/:collection_name/snapshot", h.SnapshotCollection)
+		api.POST("/collections/:collection_name/restore", h.RestoreCollection)
 
 		// object
-		api.POST("/collections/:collection_name/objects", handler.InsertObject)
-		api.POST("/collections/:collection_name/objects/batch", handler.InsertObjects)
-		api.DELETE("/collections/:collection_name/objects/:object_id", handler.DeleteObject)
-		api.PUT("/collections/:collection_name/objects/:object_id", handler.UpdateObject)
-		api.GET("/collections/:collection_name/objects", handler.GetObjects)
-		api.GET("/collections/:collection_name/objects/:object_id", handler.GetObjectInfo)
-		api.POST("/collections/:collection_name/objects/search", handler.SearchObject)
+		api.POST("/collections/:collection_name/objects", h.InsertObject)
+		api.POST("/collections/:collection_name/objects/batch", h.InsertObjects)
+		api.POST("/collections/:collection_name/objects/upsert", h.UpsertObject)
+		api.POST("/collections/:collection_name/objects/upsert/batch", h.UpsertObjects)
+		api.DELETE("/collections/:collection_name/objects/:object_id", h.DeleteObject)
+		api.PUT("/collections/:collection_name/objects/:object_id", h.UpdateObject)
+		api.GET("/collections/:collection_name/objects", h.ScrollObjects)
+		api.GET("/collections/:collection_name/objects/:object_id", h.GetObjectInfo)
+		api.POST("/collections/:collection_name/objects/search", h.SearchObject)
+		api.POST("/collections/:collection_name/objects/count", h.CountObjects)
+		api.POST("/collections/:collection_name/objects/import", h.ImportObjects)
+		api.GET("/collections/:collection_name/objects/export", h.ExportObjects)
 	}
 
 	// host:port/debug/pprof/
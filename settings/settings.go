@@ -10,11 +10,12 @@ import (
 var Conf = new(AppConfig)
 
 type AppConfig struct {
-	Name    string `mapstructure:"name"`
-	Host    string `mapstructure:"host"`
-	Port    int    `mapstructure:"port"`
-	Mode    string `mapstructure:"mode"`
-	Version string `mapstructure:"version"`
+	Name     string `mapstructure:"name"`
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	GRPCPort int    `mapstructure:"grpc_port"` // 0 disables the gRPC API
+	Mode     string `mapstructure:"mode"`
+	Version  string `mapstructure:"version"`
 
 	*LogConfig `mapstructure:"log"`
 	*DBConfig  `mapstructure:"db"`
@@ -29,7 +30,9 @@ type LogConfig struct {
 }
 
 type DBConfig struct {
-	PersistPath string `mapstructure:"persist_path"`
+	PersistPath        string `mapstructure:"persist_path"`
+	CheckpointInterval int    `mapstructure:"checkpoint_interval"` // seconds, 0 disables
+	CheckpointWALSize  int    `mapstructure:"checkpoint_wal_size"` // MB, 0 disables
 }
 
 func Init() (err error) {
@@ -0,0 +1,207 @@
+package transfer
+
+import (
+	"archive/tar"
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"vectordb/model"
+)
+
+// files of the npy export archive, row i of the array is object i of the JSONL file
+const (
+	ExportVectorsName = "vectors.npy"
+	ExportObjectsName = "objects.jsonl"
+)
+
+// Objects is a consistent view of the objects of a collection, *db.Export implements it
+type Objects interface {
+	Count() int
+	Dimension() int
+	// Each calls fn for every object in the same order on every call
+	Each(fn func(obj model.ResObjectInfo) error) error
+}
+
+// exportedObject is a line of the JSONL file next to the npy array, without the vector
+type exportedObject struct {
+	ID       string                 `json:"id"`
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+// ExportJSONL writes every object as a line of JSON, id, metadata and vector
+func ExportJSONL(w io.Writer, objs Objects) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	if err := objs.Each(func(obj model.ResObjectInfo) error {
+		return enc.Encode(obj)
+	}); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ExportNpy writes the vectors as a float32 npy array of shape (count, dimension) to vectors and
+// the ids and metadata as JSONL to objects, in one pass
+func ExportNpy(vectors io.Writer, objects io.Writer, objs Objects) error {
+	vw, ow := bufio.NewWriter(vectors), bufio.NewWriter(objects)
+	if err := writeNpyHeader(vw, objs.Count(), objs.Dimension()); err != nil {
+		return err
+	}
+	enc := json.NewEncoder(ow)
+	if err := eachRow(objs, func(obj model.ResObjectInfo) error {
+		if err := writeNpyRow(vw, obj.Vector); err != nil {
+			return err
+		}
+		return enc.Encode(exportedObject{ID: obj.ID, Metadata: obj.Metadata})
+	}); err != nil {
+		return err
+	}
+
+	if err := vw.Flush(); err != nil {
+		return err
+	}
+	return ow.Flush()
+}
+
+// ExportNpyTar writes the files of ExportNpy into an uncompressed tar. Tar headers need the
+// size of the JSONL file, it's measured by a first pass over the objects.
+func ExportNpyTar(w io.Writer, objs Objects) error {
+	counter := &countingWriter{}
+	enc := json.NewEncoder(counter)
+	if err := eachRow(objs, func(obj model.ResObjectInfo) error {
+		return enc.Encode(exportedObject{ID: obj.ID, Metadata: obj.Metadata})
+	}); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	tw := tar.NewWriter(bw)
+	now := time.Now()
+
+	header := new(strings.Builder)
+	if err := writeNpyHeader(header, objs.Count(), objs.Dimension()); err != nil {
+		return err
+	}
+	vectorsSize := int64(header.Len()) + int64(objs.Count())*int64(objs.Dimension())*4
+	if err := tw.WriteHeader(&tar.Header{Name: ExportVectorsName, Mode: 0600, Size: vectorsSize, ModTime: now}); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ExportVectorsName, err)
+	}
+	if _, err := io.WriteString(tw, header.String()); err != nil {
+		return err
+	}
+	if err := eachRow(objs, func(obj model.ResObjectInfo) error {
+		return writeNpyRow(tw, obj.Vector)
+	}); err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{Name: ExportObjectsName, Mode: 0600, Size: counter.n, ModTime: now}); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ExportObjectsName, err)
+	}
+	enc = json.NewEncoder(tw)
+	if err := eachRow(objs, func(obj model.ResObjectInfo) error {
+		return enc.Encode(exportedObject{ID: obj.ID, Metadata: obj.Metadata})
+	}); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to close export: %w", err)
+	}
+	return bw.Flush()
+}
+
+// ExtractNpyTar splits an archive of ExportNpyTar back into the vectors and the objects file
+func ExtractNpyTar(r io.Reader, vectors io.Writer, objects io.Writer) error {
+	tr := tar.NewReader(r)
+	found := 0
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read export: %w", err)
+		}
+
+		var w io.Writer
+		switch header.Name {
+		case ExportVectorsName:
+			w = vectors
+		case ExportObjectsName:
+			w = objects
+		default:
+			return fmt.Errorf("unexpected file %s in export", header.Name)
+		}
+		if _, err := io.Copy(w, tr); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", header.Name, err)
+		}
+		found++
+	}
+	if found != 2 {
+		return errors.New("incomplete export")
+	}
+	return nil
+}
+
+// eachRow checks that the objects agree with the shape of the npy header written for them
+func eachRow(objs Objects, fn func(obj model.ResObjectInfo) error) error {
+	rows := 0
+	if err := objs.Each(func(obj model.ResObjectInfo) error {
+		if rows++; rows > objs.Count() {
+			return fmt.Errorf("more than %d objects in export", objs.Count())
+		}
+		if len(obj.Vector) != objs.Dimension() {
+			return fmt.Errorf("object %s has a vector of dimension %d instead of %d", obj.ID, len(obj.Vector), objs.Dimension())
+		}
+		return fn(obj)
+	}); err != nil {
+		return err
+	}
+	if rows != objs.Count() {
+		return fmt.Errorf("%d objects in export instead of %d", rows, objs.Count())
+	}
+	return nil
+}
+
+// writeNpyHeader writes a version 1.0 header for a C ordered float32 array, padded to a
+// multiple of 64 bytes like NumPy does
+func writeNpyHeader(w io.Writer, rows int, dim int) error {
+	dict := fmt.Sprintf("{'descr': '<f4', 'fortran_order': False, 'shape': (%d, %d), }", rows, dim)
+	// magic, version, header length, dict, padding and newline
+	prefix := len(npyMagic) + 2 + 2
+	dict += strings.Repeat(" ", 63-(prefix+len(dict))%64) + "\n"
+
+	buf := make([]byte, 0, prefix+len(dict))
+	buf = append(buf, npyMagic...)
+	buf = append(buf, 1, 0)
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(dict)))
+	buf = append(buf, dict...)
+	_, err := w.Write(buf)
+	return err
+}
+
+func writeNpyRow(w io.Writer, vector []float32) error {
+	buf := make([]byte, 4*len(vector))
+	for i, f := range vector {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(f))
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,102 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"vectordb/model"
+)
+
+type testObjects []model.ResObjectInfo
+
+func (o testObjects) Count() int {
+	return len(o)
+}
+
+func (o testObjects) Dimension() int {
+	return 2
+}
+
+func (o testObjects) Each(fn func(obj model.ResObjectInfo) error) error {
+	for _, obj := range o {
+		if err := fn(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var exportObjects = testObjects{
+	{ID: "a", Metadata: map[string]interface{}{"category": "x"}, Vector: []float32{1, 2}},
+	{ID: "b", Metadata: map[string]interface{}{"category": "y"}, Vector: []float32{3, 4}},
+	{ID: "c", Metadata: map[string]interface{}{}, Vector: []float32{5, 6}},
+}
+
+func TestExportJSONL(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.NoError(t, ExportJSONL(buf, exportObjects))
+
+	// the export is read back by the import
+	batches, err := importAll(t, buf.Bytes(), ImportOptions{Format: FormatJSONL, Dimension: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 2}, {3, 4}, {5, 6}}, vectors(batches))
+	assert.Equal(t, "b", batches[0][1].ID)
+	assert.Equal(t, "y", batches[0][1].Metadata["category"])
+}
+
+func TestExportNpy(t *testing.T) {
+	vectorsBuf, objectsBuf := new(bytes.Buffer), new(bytes.Buffer)
+	assert.NoError(t, ExportNpy(vectorsBuf, objectsBuf, exportObjects))
+	// the data starts aligned like in the files of NumPy
+	assert.Zero(t, (bytes.IndexByte(vectorsBuf.Bytes(), '\n')+1)%64)
+
+	batches, err := importAll(t, vectorsBuf.Bytes(), ImportOptions{Format: FormatNpy, Dimension: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 2}, {3, 4}, {5, 6}}, vectors(batches))
+	lines := strings.Split(strings.TrimSpace(objectsBuf.String()), "\n")
+	if assert.Len(t, lines, 3) {
+		assert.JSONEq(t, `{"id": "a", "metadata": {"category": "x"}}`, lines[0])
+	}
+
+	// the tar holds the same files
+	archive := new(bytes.Buffer)
+	assert.NoError(t, ExportNpyTar(archive, exportObjects))
+	vectorsTar, objectsTar := new(bytes.Buffer), new(bytes.Buffer)
+	assert.NoError(t, ExtractNpyTar(bytes.NewReader(archive.Bytes()), vectorsTar, objectsTar))
+	assert.Equal(t, vectorsBuf.Bytes(), vectorsTar.Bytes())
+	assert.Equal(t, objectsBuf.Bytes(), objectsTar.Bytes())
+	assert.Error(t, ExtractNpyTar(bytes.NewReader(archive.Bytes()[:archive.Len()/2]), new(bytes.Buffer), new(bytes.Buffer)))
+
+	// rows must match the shape of the header
+	invalid := append(testObjects{}, exportObjects...)
+	invalid[1].Vector = []float32{1, 2, 3}
+	assert.EqualError(t, ExportNpy(new(bytes.Buffer), new(bytes.Buffer), invalid), "object b has a vector of dimension 3 instead of 2")
+}
+
+func TestExportError(t *testing.T) {
+	failing := errors.New("disk full")
+	err := ExportJSONL(failingWriter{failing}, exportObjects)
+	assert.ErrorIs(t, err, failing)
+
+	// an empty collection is a (0, dimension) array
+	buf := new(bytes.Buffer)
+	assert.NoError(t, ExportNpy(buf, new(bytes.Buffer), testObjects{}))
+	n, err := Import(context.Background(), buf, ImportOptions{Format: FormatNpy}, func(objs *model.ReqInsertObjects) error {
+		return errors.New("no batch expected")
+	})
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
@@ -0,0 +1,237 @@
+// Package transfer streams objects between files and collections
+package transfer
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"vectordb/model"
+)
+
+const (
+	FormatJSONL = "jsonl" // one insert body per line
+	FormatCSV   = "csv"   // header row, id and vector columns, the other columns are metadata
+	FormatFvecs = "fvecs" // int32 dimension then float32 components per vector
+	FormatBvecs = "bvecs" // int32 dimension then uint8 components per vector
+	FormatNpy   = "npy"   // NumPy array of shape (n, dimension)
+)
+
+const DefaultBatchSize = 1000
+
+// progressInterval is the minimum time between two progress reports of an import
+const progressInterval = time.Second
+
+// FormatOf returns the format of a file from its extension, jsonl if it's unknown
+func FormatOf(name string) string {
+	switch ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), "."); ext {
+	case FormatCSV, FormatFvecs, FormatBvecs, FormatNpy:
+		return ext
+	default:
+		return FormatJSONL
+	}
+}
+
+type ImportOptions struct {
+	Format    string
+	Dimension int           // vectors of another dimension are rejected, unchecked if 0
+	Schema    []model.Field // types the metadata columns of CSV files
+	BatchSize int           // objects per insert, DefaultBatchSize if 0
+	// Progress is called with the number of imported objects after a batch, at most once a second
+	Progress func(imported int)
+}
+
+// objectReader returns the objects of a file one by one, io.EOF after the last one
+type objectReader interface {
+	next() (model.ReqInsertObject, error)
+}
+
+func newObjectReader(r io.Reader, opts *ImportOptions) (objectReader, error) {
+	br := bufio.NewReader(r)
+	switch opts.Format {
+	case FormatJSONL:
+		return &jsonlReader{dec: json.NewDecoder(br)}, nil
+	case FormatCSV:
+		return newCSVReader(br, opts.Schema)
+	case FormatFvecs:
+		return &vecsReader{r: br, size: 4}, nil
+	case FormatBvecs:
+		return &vecsReader{r: br, size: 1}, nil
+	case FormatNpy:
+		return newNpyReader(br)
+	default:
+		return nil, fmt.Errorf("unsupported import format '%s'", opts.Format)
+	}
+}
+
+// Import reads the objects of r and passes them to insert in batches, only one batch is held in
+// memory. It returns the number of imported objects, errors tell how many were imported before.
+func Import(ctx context.Context, r io.Reader, opts ImportOptions, insert func(*model.ReqInsertObjects) error) (int, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	objects, err := newObjectReader(r, &opts)
+	if err != nil {
+		return 0, err
+	}
+
+	imported := 0
+	reported := time.Now()
+	flush := func(objs *model.ReqInsertObjects) error {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("%w, %d objects imported before", err, imported)
+		}
+		if err := insert(objs); err != nil {
+			return fmt.Errorf("%w, %d objects imported before", err, imported)
+		}
+		imported += len(objs.Objects)
+		if opts.Progress != nil && time.Since(reported) >= progressInterval {
+			opts.Progress(imported)
+			reported = time.Now()
+		}
+		return nil
+	}
+
+	// batches grow with the objects read, the batch size may be much larger than the file
+	objs := &model.ReqInsertObjects{}
+	for n := 1; ; n++ {
+		obj, err := objects.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err == nil && opts.Dimension > 0 && len(obj.Vector) != opts.Dimension {
+			err = fmt.Errorf("vector dimension %d, the collection has %d", len(obj.Vector), opts.Dimension)
+		}
+		if err != nil {
+			return imported, fmt.Errorf("object %d: %w, %d objects imported before", n, err, imported)
+		}
+		if obj.Metadata == nil {
+			obj.Metadata = map[string]interface{}{}
+		}
+
+		objs.Objects = append(objs.Objects, obj)
+		if len(objs.Objects) == opts.BatchSize {
+			if err := flush(objs); err != nil {
+				return imported, err
+			}
+			// the batch was handed over, it may still be referenced
+			objs = &model.ReqInsertObjects{}
+		}
+	}
+	if len(objs.Objects) > 0 {
+		if err := flush(objs); err != nil {
+			return imported, err
+		}
+	}
+	return imported, nil
+}
+
+type jsonlReader struct {
+	dec *json.Decoder
+}
+
+func (r *jsonlReader) next() (model.ReqInsertObject, error) {
+	obj := model.ReqInsertObject{}
+	err := r.dec.Decode(&obj)
+	return obj, err
+}
+
+// csvReader reads a header row naming the columns, id is the optional object id, vector holds
+// the vector as a JSON array or as numbers separated by spaces or commas. Other columns are
+// metadata fields, decoded as JSON values if they parse and kept as strings otherwise or if the
+// schema types them as string or datetime. Empty cells leave the field out.
+type csvReader struct {
+	r        *csv.Reader
+	columns  []string
+	id       int
+	vector   int
+	isString map[string]bool
+}
+
+func newCSVReader(r io.Reader, schema []model.Field) (*csvReader, error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("csv header row is missing")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	c := &csvReader{r: cr, columns: append([]string{}, header...), id: -1, vector: -1, isString: map[string]bool{}}
+	for i, name := range c.columns {
+		switch name {
+		case "id":
+			c.id = i
+		case "vector":
+			c.vector = i
+		}
+	}
+	if c.vector < 0 {
+		return nil, errors.New("csv header has no vector column")
+	}
+	for _, field := range schema {
+		c.isString[field.Name] = field.Type == "string" || field.Type == "datetime"
+	}
+	return c, nil
+}
+
+func (c *csvReader) next() (model.ReqInsertObject, error) {
+	record, err := c.r.Read()
+	if err != nil {
+		return model.ReqInsertObject{}, err
+	}
+
+	obj := model.ReqInsertObject{Metadata: make(map[string]interface{}, len(record))}
+	if obj.Vector, err = parseVector(record[c.vector]); err != nil {
+		return obj, err
+	}
+	for i, cell := range record {
+		switch {
+		case i == c.vector:
+		case i == c.id:
+			obj.ID = cell
+		case cell == "":
+		case c.isString[c.columns[i]]:
+			obj.Metadata[c.columns[i]] = cell
+		default:
+			var value interface{}
+			if err := json.Unmarshal([]byte(cell), &value); err != nil {
+				value = cell
+			}
+			obj.Metadata[c.columns[i]] = value
+		}
+	}
+	return obj, nil
+}
+
+func parseVector(s string) ([]float32, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "[") {
+		vector := []float32{}
+		if err := json.Unmarshal([]byte(s), &vector); err != nil {
+			return nil, fmt.Errorf("invalid vector: %w", err)
+		}
+		return vector, nil
+	}
+
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+	vector := make([]float32, len(fields))
+	for i, field := range fields {
+		f, err := strconv.ParseFloat(field, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vector: %w", err)
+		}
+		vector[i] = float32(f)
+	}
+	return vector, nil
+}
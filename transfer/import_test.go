@@ -0,0 +1,185 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"vectordb/model"
+)
+
+// importAll imports data in batches of 2 and returns the batches
+func importAll(t *testing.T, data []byte, opts ImportOptions) ([][]model.ReqInsertObject, error) {
+	batches := [][]model.ReqInsertObject{}
+	opts.BatchSize = 2
+	n, err := Import(context.Background(), bytes.NewReader(data), opts, func(objs *model.ReqInsertObjects) error {
+		batches = append(batches, objs.Objects)
+		return nil
+	})
+	imported := 0
+	for _, batch := range batches {
+		imported += len(batch)
+	}
+	assert.Equal(t, imported, n)
+	return batches, err
+}
+
+func vectors(batches [][]model.ReqInsertObject) [][]float32 {
+	res := [][]float32{}
+	for _, batch := range batches {
+		for _, obj := range batch {
+			res = append(res, obj.Vector)
+		}
+	}
+	return res
+}
+
+func npyFile(descr string, shape string, data []byte) []byte {
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': %s, }", descr, shape)
+	header += strings.Repeat(" ", 63-(len(npyMagic)+4+len(header))%64) + "\n"
+	buf := bytes.NewBufferString(npyMagic)
+	buf.Write([]byte{1, 0})
+	binary.Write(buf, binary.LittleEndian, uint16(len(header)))
+	buf.WriteString(header)
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+func TestFormatOf(t *testing.T) {
+	assert.Equal(t, FormatCSV, FormatOf("objects.CSV"))
+	assert.Equal(t, FormatFvecs, FormatOf("/data/sift_base.fvecs"))
+	assert.Equal(t, FormatNpy, FormatOf("train.npy"))
+	assert.Equal(t, FormatJSONL, FormatOf("objects.ndjson"))
+	assert.Equal(t, FormatJSONL, FormatOf("-"))
+}
+
+func TestImportJSONL(t *testing.T) {
+	data := `{"id": "a", "metadata": {"category": "x"}, "vector": [1, 0]}
+{"metadata": {"category": "y"}, "vector": [2, 0]}
+{"id": "c", "metadata": {"category": "x"}, "vector": [3, 0]}
+`
+	batches, err := importAll(t, []byte(data), ImportOptions{Format: FormatJSONL, Dimension: 2})
+	assert.NoError(t, err)
+	if assert.Len(t, batches, 2) {
+		assert.Len(t, batches[0], 2)
+		assert.Equal(t, "a", batches[0][0].ID)
+		assert.Equal(t, "y", batches[0][1].Metadata["category"])
+		assert.Equal(t, []float32{3, 0}, batches[1][0].Vector)
+	}
+
+	// the objects before the failing batch are imported
+	_, err = importAll(t, []byte(data+`{"metadata": {}, "vector": [1, 2, 3]}`), ImportOptions{Format: FormatJSONL, Dimension: 2})
+	assert.EqualError(t, err, "object 4: vector dimension 3, the collection has 2, 2 objects imported before")
+	_, err = importAll(t, []byte(data+`{"metadata": `), ImportOptions{Format: FormatJSONL})
+	assert.ErrorContains(t, err, "object 4: unexpected EOF")
+}
+
+func TestImportCSV(t *testing.T) {
+	data := `id,vector,category,count,created
+a,"[1, 0]",007,3,2024-01-02T03:04:05Z
+b,2 0,book,,2024-01-02T03:04:05Z
+,"3,0",book,4.5,
+`
+	schema := []model.Field{{Name: "category", Type: "string"}, {Name: "count", Type: "int"}, {Name: "created", Type: "datetime"}}
+	batches, err := importAll(t, []byte(data), ImportOptions{Format: FormatCSV, Dimension: 2, Schema: schema})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 0}, {2, 0}, {3, 0}}, vectors(batches))
+	assert.Equal(t, map[string]interface{}{"category": "007", "count": 3.0, "created": "2024-01-02T03:04:05Z"}, batches[0][0].Metadata)
+	assert.Equal(t, map[string]interface{}{"category": "book", "created": "2024-01-02T03:04:05Z"}, batches[0][1].Metadata)
+	assert.Equal(t, "", batches[1][0].ID)
+	assert.Equal(t, map[string]interface{}{"category": "book", "count": 4.5}, batches[1][0].Metadata)
+
+	// without a schema cells are JSON values when they parse
+	batches, err = importAll(t, []byte(data), ImportOptions{Format: FormatCSV})
+	assert.NoError(t, err)
+	assert.Equal(t, "007", batches[0][0].Metadata["category"])
+	assert.Equal(t, 3.0, batches[0][0].Metadata["count"])
+
+	_, err = importAll(t, []byte("id,category\na,x\n"), ImportOptions{Format: FormatCSV})
+	assert.EqualError(t, err, "csv header has no vector column")
+	_, err = importAll(t, []byte("vector\n1 x\n"), ImportOptions{Format: FormatCSV})
+	assert.ErrorContains(t, err, "object 1: invalid vector")
+	_, err = importAll(t, []byte("vector,category\n1 0\n"), ImportOptions{Format: FormatCSV})
+	assert.ErrorContains(t, err, "wrong number of fields")
+}
+
+func TestImportVecs(t *testing.T) {
+	fvecs := new(bytes.Buffer)
+	bvecs := new(bytes.Buffer)
+	for i := 0; i < 3; i++ {
+		binary.Write(fvecs, binary.LittleEndian, int32(2))
+		binary.Write(fvecs, binary.LittleEndian, []float32{float32(i) + 0.5, -1})
+		binary.Write(bvecs, binary.LittleEndian, int32(2))
+		bvecs.Write([]byte{byte(i), 255})
+	}
+
+	batches, err := importAll(t, fvecs.Bytes(), ImportOptions{Format: FormatFvecs, Dimension: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{0.5, -1}, {1.5, -1}, {2.5, -1}}, vectors(batches))
+	assert.NotNil(t, batches[0][0].Metadata)
+
+	batches, err = importAll(t, bvecs.Bytes(), ImportOptions{Format: FormatBvecs, Dimension: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{0, 255}, {1, 255}, {2, 255}}, vectors(batches))
+
+	_, err = importAll(t, fvecs.Bytes()[:fvecs.Len()-2], ImportOptions{Format: FormatFvecs})
+	assert.ErrorContains(t, err, "object 3: failed to read vector: unexpected EOF, 2 objects imported before")
+	_, err = importAll(t, bvecs.Bytes(), ImportOptions{Format: FormatBvecs, Dimension: 3})
+	assert.ErrorContains(t, err, "object 1: vector dimension 2, the collection has 3")
+}
+
+func TestImportNpy(t *testing.T) {
+	data := new(bytes.Buffer)
+	binary.Write(data, binary.LittleEndian, []float32{1, 2, 3, 4, 5, 6})
+	batches, err := importAll(t, npyFile("<f4", "(3, 2)", data.Bytes()), ImportOptions{Format: FormatNpy, Dimension: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 2}, {3, 4}, {5, 6}}, vectors(batches))
+
+	data.Reset()
+	binary.Write(data, binary.LittleEndian, []float64{1.5, math.Pi})
+	batches, err = importAll(t, npyFile("<f8", "(1, 2)", data.Bytes()), ImportOptions{Format: FormatNpy})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{1.5, math.Pi}}, vectors(batches))
+
+	batches, err = importAll(t, npyFile("|u1", "(2, 3)", []byte{1, 2, 3, 4, 5, 6}), ImportOptions{Format: FormatNpy})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 2, 3}, {4, 5, 6}}, vectors(batches))
+
+	_, err = importAll(t, npyFile("<f4", "(3, 2)", data.Bytes()), ImportOptions{Format: FormatNpy})
+	assert.ErrorContains(t, err, "object 3: failed to read row 2 of 3: unexpected EOF, 2 objects imported before")
+	_, err = importAll(t, npyFile(">f4", "(1, 2)", data.Bytes()), ImportOptions{Format: FormatNpy})
+	assert.EqualError(t, err, "unsupported npy dtype '>f4'")
+	_, err = importAll(t, npyFile("<f4", "(8,)", data.Bytes()), ImportOptions{Format: FormatNpy})
+	assert.EqualError(t, err, "unsupported npy shape (8,), expected (rows, dimension)")
+	_, err = importAll(t, []byte("not numpy"), ImportOptions{Format: FormatNpy})
+	assert.EqualError(t, err, "invalid npy file: bad magic string")
+}
+
+func TestImportInsertError(t *testing.T) {
+	data := "vector\n1 0\n2 0\n3 0\n"
+	batch := 0
+	n, err := Import(context.Background(), strings.NewReader(data), ImportOptions{Format: FormatCSV, BatchSize: 2}, func(objs *model.ReqInsertObjects) error {
+		if batch++; batch == 2 {
+			return errors.New("index is full")
+		}
+		return nil
+	})
+	assert.Equal(t, 2, n)
+	assert.EqualError(t, err, "index is full, 2 objects imported before")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	n, err = Import(ctx, strings.NewReader(data), ImportOptions{Format: FormatCSV}, func(objs *model.ReqInsertObjects) error { return nil })
+	assert.Equal(t, 0, n)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = Import(context.Background(), strings.NewReader(data), ImportOptions{Format: "parquet"}, nil)
+	assert.EqualError(t, err, "unsupported import format 'parquet'")
+}
@@ -0,0 +1,149 @@
+package transfer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"vectordb/model"
+)
+
+const npyMagic = "\x93NUMPY"
+
+var (
+	npyDescr   = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	npyFortran = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyShape   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+// npyReader reads the rows of a C ordered, 2 dimensional NumPy array of little endian floats or
+// integers as vectors
+type npyReader struct {
+	r    io.Reader
+	rows int
+	dim  int
+	size int
+	conv func([]byte) float32
+	row  int
+	buf  []byte
+}
+
+func newNpyReader(r io.Reader) (*npyReader, error) {
+	header, err := readNpyHeader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid npy file: %w", err)
+	}
+
+	m := npyDescr.FindStringSubmatch(header)
+	if m == nil {
+		return nil, errors.New("invalid npy file: descr is missing")
+	}
+	n := &npyReader{r: r}
+	switch m[1] {
+	case "<f4":
+		n.size, n.conv = 4, func(b []byte) float32 { return math.Float32frombits(binary.LittleEndian.Uint32(b)) }
+	case "<f8":
+		n.size, n.conv = 8, func(b []byte) float32 { return float32(math.Float64frombits(binary.LittleEndian.Uint64(b))) }
+	case "|u1", "<u1":
+		n.size, n.conv = 1, func(b []byte) float32 { return float32(b[0]) }
+	case "|i1", "<i1":
+		n.size, n.conv = 1, func(b []byte) float32 { return float32(int8(b[0])) }
+	case "<i2":
+		n.size, n.conv = 2, func(b []byte) float32 { return float32(int16(binary.LittleEndian.Uint16(b))) }
+	case "<i4":
+		n.size, n.conv = 4, func(b []byte) float32 { return float32(int32(binary.LittleEndian.Uint32(b))) }
+	case "<i8":
+		n.size, n.conv = 8, func(b []byte) float32 { return float32(int64(binary.LittleEndian.Uint64(b))) }
+	default:
+		return nil, fmt.Errorf("unsupported npy dtype '%s'", m[1])
+	}
+
+	if m := npyFortran.FindStringSubmatch(header); m == nil || m[1] != "False" {
+		return nil, errors.New("unsupported npy file: the array must be in C order")
+	}
+
+	m = npyShape.FindStringSubmatch(header)
+	if m == nil {
+		return nil, errors.New("invalid npy file: shape is missing")
+	}
+	shape := []int{}
+	for _, s := range strings.Split(m[1], ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		d, err := strconv.Atoi(s)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid npy shape (%s)", m[1])
+		}
+		shape = append(shape, d)
+	}
+	if len(shape) != 2 || shape[1] == 0 || shape[1] > maxDimension {
+		return nil, fmt.Errorf("unsupported npy shape (%s), expected (rows, dimension)", m[1])
+	}
+	n.rows, n.dim = shape[0], shape[1]
+	n.buf = make([]byte, n.dim*n.size)
+	return n, nil
+}
+
+// readNpyHeader reads the magic string, the version and the header dict of the format
+func readNpyHeader(r io.Reader) (string, error) {
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return "", err
+	}
+	if !bytes.Equal(prefix[:len(npyMagic)], []byte(npyMagic)) {
+		return "", errors.New("bad magic string")
+	}
+
+	var length int
+	switch major := prefix[len(npyMagic)]; major {
+	case 1:
+		var l uint16
+		if err := binary.Read(r, binary.LittleEndian, &l); err != nil {
+			return "", err
+		}
+		length = int(l)
+	case 2, 3:
+		var l uint32
+		if err := binary.Read(r, binary.LittleEndian, &l); err != nil {
+			return "", err
+		}
+		if l > 1<<20 {
+			return "", fmt.Errorf("header of %d bytes", l)
+		}
+		length = int(l)
+	default:
+		return "", fmt.Errorf("unsupported version %d", major)
+	}
+
+	header := make([]byte, length)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return "", err
+	}
+	return string(header), nil
+}
+
+func (n *npyReader) next() (model.ReqInsertObject, error) {
+	if n.row == n.rows {
+		return model.ReqInsertObject{}, io.EOF
+	}
+	if _, err := io.ReadFull(n.r, n.buf); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return model.ReqInsertObject{}, fmt.Errorf("failed to read row %d of %d: %w", n.row, n.rows, err)
+	}
+	n.row++
+
+	vector := make([]float32, n.dim)
+	for i := range vector {
+		vector[i] = n.conv(n.buf[i*n.size:])
+	}
+	return model.ReqInsertObject{Vector: vector}, nil
+}
@@ -0,0 +1,55 @@
+package transfer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"vectordb/model"
+)
+
+// maxDimension bounds the dimensions read from file headers before allocating a vector
+const maxDimension = 1 << 16
+
+// vecsReader reads the fvecs and bvecs formats of the TEXMEX corpus, every vector is a little
+// endian int32 dimension followed by the components, float32 or uint8
+type vecsReader struct {
+	r    io.Reader
+	size int
+	buf  []byte
+}
+
+func (v *vecsReader) next() (model.ReqInsertObject, error) {
+	var dim int32
+	if err := binary.Read(v.r, binary.LittleEndian, &dim); err != nil {
+		// a clean end of file is the only io.EOF, a partial header is unexpected
+		return model.ReqInsertObject{}, err
+	}
+	if dim <= 0 || dim > maxDimension {
+		return model.ReqInsertObject{}, fmt.Errorf("invalid vector dimension %d", dim)
+	}
+
+	n := int(dim) * v.size
+	if cap(v.buf) < n {
+		v.buf = make([]byte, n)
+	}
+	buf := v.buf[:n]
+	if _, err := io.ReadFull(v.r, buf); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return model.ReqInsertObject{}, fmt.Errorf("failed to read vector: %w", err)
+	}
+
+	vector := make([]float32, dim)
+	for i := range vector {
+		if v.size == 4 {
+			vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[i*4:]))
+		} else {
+			vector[i] = float32(buf[i])
+		}
+	}
+	return model.ReqInsertObject{Vector: vector}, nil
+}